@@ -6,21 +6,34 @@ package main
 import (
 	"context"
 	"fmt"
+	"metar-service/src/archive"
+	cacheImpl "metar-service/src/cache"
+	"metar-service/src/control"
 	grpcImpl "metar-service/src/grpc"
 	c "metar-service/src/interfaces/config"
 	"metar-service/src/interfaces/content"
 	g "metar-service/src/interfaces/global"
 	pb "metar-service/src/interfaces/grpc"
+	metarInterface "metar-service/src/interfaces/metar"
 	"metar-service/src/metar"
+	"metar-service/src/metar/category"
+	"metar-service/src/metar/encoder"
+	"metar-service/src/metar/parser"
+	"metar-service/src/metar/translator"
+	"metar-service/src/override"
+	"metar-service/src/replay"
 	"metar-service/src/server"
+	"metar-service/src/station"
+	"os"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
-	"half-nothing.cn/service-core/cache"
 	"half-nothing.cn/service-core/cleaner"
 	"half-nothing.cn/service-core/config"
 	"half-nothing.cn/service-core/discovery"
 	grpcUtils "half-nothing.cn/service-core/grpc"
+	"half-nothing.cn/service-core/interfaces/cache"
 	"half-nothing.cn/service-core/interfaces/global"
 	"half-nothing.cn/service-core/logger"
 	"half-nothing.cn/service-core/telemetry"
@@ -67,9 +80,22 @@ func main() {
 		cl.Add("Telemetry", shutdown)
 	}
 
-	metarManagerMemoryCache := cache.NewMemoryCache[string, *string](*g.CacheCleanInterval)
+	stationDatabase, err := station.NewDatabase(lg, applicationConfig.StationConfig)
+	if err != nil {
+		lg.Fatalf("fail to initialize station database: %v", err)
+		return
+	}
+
+	metarParser := parser.NewMetarParser()
+	tafParser := parser.NewTafParser()
+
+	metarManagerCache, err := cacheImpl.NewCache(lg, applicationConfig.CacheConfig, "metar", *g.CacheCleanInterval)
+	if err != nil {
+		lg.Fatalf("fail to initialize metar cache: %v", err)
+		return
+	}
 	cl.Add("Metar Cache", func(ctx context.Context) error {
-		metarManagerMemoryCache.Close()
+		metarManagerCache.Close()
 		return nil
 	})
 	metarManager := metar.NewManager(
@@ -77,12 +103,18 @@ func main() {
 		utils.Filter(applicationConfig.ProviderConfigs, func(providerConfig *c.ProviderConfig) bool {
 			return providerConfig.Type == c.ProviderTypeMetar.Value
 		}),
-		metarManagerMemoryCache,
+		metarManagerCache,
+		metarParser,
+		metar.NewCachePolicy(applicationConfig.CacheConfig.Metar),
 	)
 
-	tafManagerMemoryCache := cache.NewMemoryCache[string, *string](*g.CacheCleanInterval)
+	tafManagerCache, err := cacheImpl.NewCache(lg, applicationConfig.CacheConfig, "taf", *g.CacheCleanInterval)
+	if err != nil {
+		lg.Fatalf("fail to initialize taf cache: %v", err)
+		return
+	}
 	cl.Add("Taf Cache", func(ctx context.Context) error {
-		tafManagerMemoryCache.Close()
+		tafManagerCache.Close()
 		return nil
 	})
 	tafManager := metar.NewManager(
@@ -90,19 +122,133 @@ func main() {
 		utils.Filter(applicationConfig.ProviderConfigs, func(providerConfig *c.ProviderConfig) bool {
 			return providerConfig.Type == c.ProviderTypeTaf.Value
 		}),
-		tafManagerMemoryCache,
+		tafManagerCache,
+		tafParser,
+		metar.NewCachePolicy(applicationConfig.CacheConfig.Taf),
 	)
 
+	metarManager.SetStations(stationDatabase)
+	tafManager.SetStations(stationDatabase)
+	fallback := metar.NewFallback(applicationConfig.FallbackConfig, stationDatabase)
+	metarManager.SetFallback(fallback)
+	tafManager.SetFallback(fallback)
+
+	if applicationConfig.RefreshConfig.Enable {
+		metarRefresher := metar.NewRefresher(lg, "metar", applicationConfig.RefreshConfig, metarManager.Refresh)
+		metarManager.SetRefresher(metarRefresher)
+		metarRefresher.Start()
+		cl.Add("Metar Refresher", func(ctx context.Context) error {
+			metarRefresher.Stop()
+			return nil
+		})
+		tafRefresher := metar.NewRefresher(lg, "taf", applicationConfig.RefreshConfig, tafManager.Refresh)
+		tafManager.SetRefresher(tafRefresher)
+		tafRefresher.Start()
+		cl.Add("Taf Refresher", func(ctx context.Context) error {
+			tafRefresher.Stop()
+			return nil
+		})
+	}
+
+	var metarArchive, tafArchive metarInterface.ArchiveInterface
+	var replayer metarInterface.ReplayInterface
+	if applicationConfig.ArchiveConfig.Enable {
+		db, err := archive.NewDatabase(applicationConfig.ArchiveConfig)
+		if err != nil {
+			lg.Fatalf("fail to initialize archive: %v", err)
+			return
+		}
+		cl.Add("Archive", func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.Close()
+		})
+		metarArchive = archive.NewArchive(lg, db, metarInterface.ReportTypeMetar)
+		metarManager.SetArchive(metarArchive)
+		tafArchive = archive.NewArchive(lg, db, metarInterface.ReportTypeTaf)
+		tafManager.SetArchive(tafArchive)
+		replayer = replay.NewReplay(lg, applicationConfig.ReplayConfig, metarArchive, tafArchive, stationDatabase, fallback)
+	}
+
+	// 使用Redis共享缓存时指定报文与场景状态保存在共享缓存中, 对所有实例生效
+	var metarOverride, tafOverride metarInterface.OverrideInterface
+	var overrideCache cache.Interface[string, *string]
+	if strings.ToLower(applicationConfig.CacheConfig.Type) == c.CacheTypeRedis.Value {
+		overrideCache, err = cacheImpl.NewCache(lg, applicationConfig.CacheConfig, "override", *g.CacheCleanInterval)
+		if err != nil {
+			lg.Fatalf("fail to initialize override cache: %v", err)
+			return
+		}
+		cl.Add("Override Cache", func(ctx context.Context) error {
+			overrideCache.Close()
+			return nil
+		})
+		metarOverride = override.NewSharedStore(lg, overrideCache, metarInterface.ReportTypeMetar)
+		tafOverride = override.NewSharedStore(lg, overrideCache, metarInterface.ReportTypeTaf)
+	} else {
+		metarOverride = override.NewStore()
+		tafOverride = override.NewStore()
+	}
+	metarManager.SetOverride(metarOverride)
+	tafManager.SetOverride(tafOverride)
+	scenarioRunner := override.NewRunner(lg, metarOverride, tafOverride)
+	if overrideCache != nil {
+		scenarioRunner.SetShared(overrideCache)
+	}
+	cl.Add("Scenario", func(ctx context.Context) error {
+		scenarioRunner.Close()
+		return nil
+	})
+	// 场景与指定报文属于管理功能, 未启用管理接口时不加载场景脚本
+	if applicationConfig.AdminConfig.Scenario != "" && !applicationConfig.AdminConfig.Enable {
+		lg.Warnf("admin is disabled, scenario file %s is ignored", applicationConfig.AdminConfig.Scenario)
+	} else if applicationConfig.AdminConfig.Scenario != "" {
+		data, err := os.ReadFile(applicationConfig.AdminConfig.Scenario)
+		if err != nil {
+			lg.Fatalf("fail to read scenario file: %v", err)
+			return
+		}
+		scenario, err := override.ParseScenario(data)
+		if err != nil {
+			lg.Fatalf("fail to parse scenario file: %v", err)
+			return
+		}
+		// 多个实例共享场景状态时, 只有一个实例运行启动时加载的场景
+		if loaded, err := scenarioRunner.LoadIdle(scenario); err != nil {
+			lg.Fatalf("fail to load scenario file: %v", err)
+			return
+		} else if !loaded {
+			lg.Infof("scenario file %s is not loaded, another scenario is running", applicationConfig.AdminConfig.Scenario)
+		}
+	}
+
+	classifier := category.NewClassifier(applicationConfig.FlightCategoryConfig)
+
 	contentBuilder := content.NewApplicationContentBuilder().
 		SetConfigManager(configManager).
 		SetCleaner(cl).
 		SetLogger(lg).
 		SetMetarManager(metarManager).
-		SetTafManager(tafManager)
+		SetTafManager(tafManager).
+		SetMetarParser(metarParser).
+		SetTafParser(tafParser).
+		SetMetarEncoder(encoder.NewMetarEncoder()).
+		SetTafEncoder(encoder.NewTafEncoder()).
+		SetClassifier(classifier).
+		SetTranslator(translator.NewTranslator()).
+		SetMetarArchive(metarArchive).
+		SetTafArchive(tafArchive).
+		SetReplay(replayer).
+		SetMetarOverride(metarOverride).
+		SetTafOverride(tafOverride).
+		SetScenario(scenarioRunner).
+		SetStationDatabase(stationDatabase)
 
 	started := make(chan bool)
 	initFunc := func(s *grpc.Server) {
-		grpcServer := grpcImpl.NewMetarServer(lg, metarManager, tafManager)
+		grpcServer := grpcImpl.NewMetarServer(lg, metarManager, tafManager, tafParser, classifier, metarArchive, tafArchive, replayer, stationDatabase)
 		pb.RegisterMetarServer(s, grpcServer)
 	}
 	if applicationConfig.TelemetryConfig.Enable && applicationConfig.TelemetryConfig.GrpcServerTrace {
@@ -125,9 +271,10 @@ func main() {
 
 	cl.Add("Discovery", consulClient.UnregisterServer)
 
+	eventHandler := control.NewEventHandler(lg, metarManager, tafManager, stationDatabase)
 	go func() {
-		for {
-			<-consulClient.EventChan
+		for event := range consulClient.EventChan {
+			eventHandler.Handle(event.Name, event.Payload)
 		}
 	}()
 
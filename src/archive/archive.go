@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package archive
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"metar-service/src/interfaces/config"
+	"metar-service/src/interfaces/metar"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	gormLogger "gorm.io/gorm/logger"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// reportEntity 归档表中的一条报文, 报文类型、站点与报文时间唯一
+type reportEntity struct {
+	ID         uint      `gorm:"primaryKey"`
+	Type       string    `gorm:"size:8;not null;uniqueIndex:idx_report"`
+	ICAO       string    `gorm:"column:icao;size:4;not null;uniqueIndex:idx_report"`
+	ReportTime time.Time `gorm:"not null;uniqueIndex:idx_report"`
+	Data       string    `gorm:"type:text;not null"`
+	Provider   string    `gorm:"size:64"`
+	FetchTime  time.Time `gorm:"not null"`
+}
+
+func (reportEntity) TableName() string {
+	return "report_archive"
+}
+
+// NewDatabase 根据配置连接归档数据库并创建归档表
+func NewDatabase(c *config.ArchiveConfig) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch strings.ToLower(c.Driver) {
+	case config.ArchiveDriverMysql.Value:
+		dialector = mysql.Open(c.DSN)
+	case config.ArchiveDriverPostgres.Value:
+		dialector = postgres.Open(c.DSN)
+	default:
+		if err := os.MkdirAll(filepath.Dir(c.DSN), 0755); err != nil {
+			return nil, fmt.Errorf("fail to create archive directory: %w", err)
+		}
+		dialector = sqlite.Open(fmt.Sprintf("%s?_journal_mode=WAL&_busy_timeout=5000", c.DSN))
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: gormLogger.Discard})
+	if err != nil {
+		return nil, fmt.Errorf("fail to open archive database: %w", err)
+	}
+	if err := db.AutoMigrate(&reportEntity{}); err != nil {
+		return nil, fmt.Errorf("fail to migrate archive table: %w", err)
+	}
+	return db, nil
+}
+
+// Archive 单一报文类型的历史报文归档
+type Archive struct {
+	logger     logger.Interface
+	db         *gorm.DB
+	reportType string
+}
+
+func NewArchive(
+	lg logger.Interface,
+	db *gorm.DB,
+	reportType string,
+) *Archive {
+	return &Archive{
+		logger:     logger.NewLoggerAdapter(lg, fmt.Sprintf("%s-archive", reportType)),
+		db:         db,
+		reportType: reportType,
+	}
+}
+
+// Save 保存报文, 已存在相同站点与报文时间的报文时忽略
+func (a *Archive) Save(report *metar.ArchivedReport) error {
+	entity := &reportEntity{
+		Type:       a.reportType,
+		ICAO:       report.ICAO,
+		ReportTime: report.ReportTime.UTC(),
+		Data:       report.Data,
+		Provider:   report.Provider,
+		FetchTime:  report.FetchTime.UTC(),
+	}
+	result := a.db.Clauses(clause.OnConflict{DoNothing: true}).Create(entity)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		a.logger.Debugf("Report of %s at %s already archived", report.ICAO, entity.ReportTime.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// History 按报文时间升序返回站点在[from, to]内的报文, limit不大于0时不限制数量
+func (a *Archive) History(icao string, from time.Time, to time.Time, limit int) ([]*metar.ArchivedReport, error) {
+	entities := make([]*reportEntity, 0)
+	query := a.db.
+		Where("type = ? AND icao = ? AND report_time BETWEEN ? AND ?", a.reportType, icao, from.UTC(), to.UTC()).
+		Order("report_time")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	reports := make([]*metar.ArchivedReport, 0, len(entities))
+	for _, entity := range entities {
+		reports = append(reports, entity.toReport())
+	}
+	return reports, nil
+}
+
+func (a *Archive) At(icao string, at time.Time) (*metar.ArchivedReport, error) {
+	entity := &reportEntity{}
+	err := a.db.
+		Where("type = ? AND icao = ? AND report_time <= ?", a.reportType, icao, at.UTC()).
+		Order("report_time DESC").
+		Take(entity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, metar.ErrTargetNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entity.toReport(), nil
+}
+
+func (entity *reportEntity) toReport() *metar.ArchivedReport {
+	return &metar.ArchivedReport{
+		ICAO:       entity.ICAO,
+		ReportTime: entity.ReportTime.UTC(),
+		Data:       entity.Data,
+		Provider:   entity.Provider,
+		FetchTime:  entity.FetchTime.UTC(),
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package archive
+package archive
+
+import (
+	"errors"
+	"metar-service/src/interfaces/config"
+	"metar-service/src/interfaces/metar"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// nopLogger 丢弃测试中的日志
+type nopLogger struct {
+	logger.Interface
+}
+
+func (nopLogger) Debugf(string, ...any) {}
+
+var reference = time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+func newTestArchive(t *testing.T, reportType string) *Archive {
+	db, err := NewDatabase(&config.ArchiveConfig{Driver: "sqlite", DSN: filepath.Join(t.TempDir(), "archive", "archive.db")})
+	if err != nil {
+		t.Fatalf("NewDatabase() error = %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+	return NewArchive(nopLogger{}, db, reportType)
+}
+
+func report(icao string, reportTime time.Time, data string) *metar.ArchivedReport {
+	return &metar.ArchivedReport{ICAO: icao, ReportTime: reportTime, Data: data, Provider: "test", FetchTime: reportTime.Add(5 * time.Minute)}
+}
+
+func TestArchiveSaveDedupe(t *testing.T) {
+	archive := newTestArchive(t, metar.ReportTypeMetar)
+	first := report("ZBAA", reference, "METAR ZBAA 151200Z 36005MPS CAVOK 12/M05 Q1021")
+	if err := archive.Save(first); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	// 同一站点同一报文时间的报文再次获取时忽略, 保留首次归档的内容
+	duplicate := report("ZBAA", reference.In(time.FixedZone("CST", 8*3600)), "METAR ZBAA 151200Z 36004MPS CAVOK 12/M05 Q1021")
+	if err := archive.Save(duplicate); err != nil {
+		t.Fatalf("Save() of duplicate error = %v", err)
+	}
+	if err := archive.Save(report("ZSSS", reference, "METAR ZSSS 151200Z 09004MPS CAVOK 15/05 Q1018")); err != nil {
+		t.Fatalf("Save() of other station error = %v", err)
+	}
+
+	reports, err := archive.History("ZBAA", reference.Add(-time.Hour), reference.Add(time.Hour), 0)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(reports) != 1 || reports[0].Data != first.Data {
+		t.Errorf("History() = %+v, want only first report", reports)
+	}
+
+	// 报文类型不同的归档共用同一张表, 相同站点与报文时间互不影响
+	taf := NewArchive(nopLogger{}, archive.db, metar.ReportTypeTaf)
+	if err := taf.Save(report("ZBAA", reference, "TAF ZBAA 151100Z 1512/1618 36005MPS CAVOK")); err != nil {
+		t.Fatalf("Save() of taf error = %v", err)
+	}
+	if reports, _ := taf.History("ZBAA", reference, reference, 0); len(reports) != 1 {
+		t.Errorf("taf History() = %d reports, want 1", len(reports))
+	}
+}
+
+func TestArchiveHistoryRange(t *testing.T) {
+	archive := newTestArchive(t, metar.ReportTypeMetar)
+	for i := range 6 {
+		reportTime := reference.Add(time.Duration(i) * 30 * time.Minute)
+		if err := archive.Save(report("ZBAA", reportTime, reportTime.Format("METAR ZBAA 021504Z"))); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		from  time.Time
+		to    time.Time
+		limit int
+		want  []string
+	}{
+		{"inclusive bounds", reference.Add(30 * time.Minute), reference.Add(90 * time.Minute), 0,
+			[]string{"METAR ZBAA 151230Z", "METAR ZBAA 151300Z", "METAR ZBAA 151330Z"}},
+		{"limit keeps earliest", reference, reference.Add(3 * time.Hour), 2,
+			[]string{"METAR ZBAA 151200Z", "METAR ZBAA 151230Z"}},
+		{"other time zone", reference.Add(2 * time.Hour).In(time.FixedZone("CST", 8*3600)), reference.Add(5 * time.Hour), 0,
+			[]string{"METAR ZBAA 151400Z", "METAR ZBAA 151430Z"}},
+		{"empty range", reference.Add(-2 * time.Hour), reference.Add(-time.Minute), 0, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reports, err := archive.History("ZBAA", tt.from, tt.to, tt.limit)
+			if err != nil {
+				t.Fatalf("History() error = %v", err)
+			}
+			if len(reports) != len(tt.want) {
+				t.Fatalf("History() = %d reports, want %d", len(reports), len(tt.want))
+			}
+			for i, report := range reports {
+				if report.Data != tt.want[i] || report.ReportTime.Location() != time.UTC {
+					t.Errorf("History()[%d] = %q at %s, want %q in UTC", i, report.Data, report.ReportTime, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestArchiveAt(t *testing.T) {
+	archive := newTestArchive(t, metar.ReportTypeMetar)
+	for _, offset := range []time.Duration{0, time.Hour} {
+		if err := archive.Save(report("ZBAA", reference.Add(offset), reference.Add(offset).Format("METAR ZBAA 021504Z"))); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	if got, err := archive.At("ZBAA", reference.Add(59*time.Minute)); err != nil || got.Data != "METAR ZBAA 151200Z" {
+		t.Errorf("At() = %+v, %v, want report at 12:00", got, err)
+	}
+	if got, err := archive.At("ZBAA", reference.Add(time.Hour)); err != nil || got.Data != "METAR ZBAA 151300Z" {
+		t.Errorf("At() = %+v, %v, want report at 13:00", got, err)
+	}
+	if _, err := archive.At("ZBAA", reference.Add(-time.Minute)); !errors.Is(err, metar.ErrTargetNotFound) {
+		t.Errorf("At() before first report error = %v, want %v", err, metar.ErrTargetNotFound)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package cache
+package cache
+
+import (
+	"fmt"
+	"metar-service/src/interfaces/config"
+	"strings"
+	"time"
+
+	"half-nothing.cn/service-core/cache"
+	cacheInterface "half-nothing.cn/service-core/interfaces/cache"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// NewCache 根据配置创建报文缓存, name用于区分不同报文类型的缓存
+func NewCache(
+	lg logger.Interface,
+	c *config.CacheConfig,
+	name string,
+	cleanInterval time.Duration,
+) (cacheInterface.Interface[string, *string], error) {
+	switch strings.ToLower(c.Type) {
+	case config.CacheTypeSqlite.Value:
+		return NewSqliteCache(lg, c.Path, fmt.Sprintf("%s_cache", name), cleanInterval)
+	case config.CacheTypeRedis.Value:
+		return NewRedisCache(lg, c.Redis, name)
+	default:
+		return cache.NewMemoryCache[string, *string](cleanInterval), nil
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package cache
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"metar-service/src/interfaces/config"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"half-nothing.cn/service-core/interfaces/logger"
+	"half-nothing.cn/service-core/utils"
+)
+
+const (
+	// redisNilValue 表示缓存了空值, 与键不存在区分
+	redisNilValue = "n"
+	// redisValuePrefix 非空值的前缀
+	redisValuePrefix = "v"
+	redisTimeout     = 3 * time.Second
+	// redisScanCount 清除缓存时每次SCAN返回的键数量
+	redisScanCount = 500
+)
+
+// unlockScript 只删除由自己持有的锁
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisCache 基于Redis协议的共享缓存, 多个实例共用同一份数据, 并提供跨实例的互斥锁
+type RedisCache struct {
+	logger logger.Interface
+	client *redis.Client
+	prefix string
+	lock   sync.Mutex
+	tokens map[string]string
+}
+
+func NewRedisCache(
+	lg logger.Interface,
+	c *config.RedisConfig,
+	name string,
+) (*RedisCache, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     c.Address,
+		Username: c.Username,
+		Password: c.Password,
+		DB:       c.DB,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("fail to connect redis %s: %w", c.Address, err)
+	}
+	return &RedisCache{
+		logger: logger.NewLoggerAdapter(lg, fmt.Sprintf("redis-cache-%s", name)),
+		client: client,
+		prefix: fmt.Sprintf("%s:%s:", c.Prefix, name),
+		tokens: make(map[string]string),
+	}, nil
+}
+
+func (c *RedisCache) Set(key string, value *string) {
+	c.SetWithTTL(key, value, 0)
+}
+
+func (c *RedisCache) SetWithTTL(key string, value *string, ttl time.Duration) {
+	data := redisNilValue
+	if value != nil {
+		data = redisValuePrefix + *value
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := c.client.Set(ctx, c.prefix+key, data, ttl).Err(); err != nil {
+		c.logger.Errorf("Fail to set cache %s: %v", key, err)
+	}
+}
+
+func (c *RedisCache) Get(key string) (*string, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	data, err := c.client.Get(ctx, c.prefix+key).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, false
+	}
+	if err != nil {
+		c.logger.Errorf("Fail to get cache %s: %v", key, err)
+		return nil, false
+	}
+	if data == redisNilValue || len(data) == 0 {
+		return nil, true
+	}
+	value := data[len(redisValuePrefix):]
+	return &value, true
+}
+
+func (c *RedisCache) Del(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := c.client.Del(ctx, c.prefix+key).Err(); err != nil {
+		c.logger.Errorf("Fail to delete cache %s: %v", key, err)
+	}
+}
+
+// Clear 使用SCAN清除命名空间下的所有缓存, 不影响其他实例持有的锁
+// 先完成遍历再删除, 避免遍历过程中删除键导致游标跳过部分键
+func (c *RedisCache) Clear() {
+	pattern := escapePattern(c.prefix) + "*"
+	lockPrefix := c.prefix + "lock:"
+	keys := make([]string, 0)
+	var cursor uint64
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+		batch, next, err := c.client.Scan(ctx, cursor, pattern, redisScanCount).Result()
+		cancel()
+		if err != nil {
+			c.logger.Errorf("Fail to scan cache: %v", err)
+			return
+		}
+		keys = append(keys, utils.Filter(batch, func(key string) bool { return !strings.HasPrefix(key, lockPrefix) })...)
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+	count := 0
+	for start := 0; start < len(keys); start += redisScanCount {
+		batch := keys[start:min(start+redisScanCount, len(keys))]
+		ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+		err := c.client.Unlink(ctx, batch...).Err()
+		cancel()
+		if err != nil {
+			c.logger.Errorf("Fail to clear cache: %v", err)
+			return
+		}
+		count += len(batch)
+	}
+	c.logger.Infof("Cleared %d cache entries", count)
+}
+
+// escapePattern 转义SCAN匹配模式中的特殊字符
+func escapePattern(value string) string {
+	builder := strings.Builder{}
+	for _, char := range value {
+		if strings.ContainsRune(`*?[]^\`, char) {
+			builder.WriteByte('\\')
+		}
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
+
+func (c *RedisCache) Close() {
+	_ = c.client.Close()
+}
+
+// TryLock 尝试获取跨实例的互斥锁, 锁在ttl后自动释放
+func (c *RedisCache) TryLock(key string, ttl time.Duration) (bool, error) {
+	buffer := make([]byte, 16)
+	_, _ = rand.Read(buffer)
+	token := hex.EncodeToString(buffer)
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	ok, err := c.client.SetNX(ctx, c.prefix+"lock:"+key, token, ttl).Result()
+	if err != nil || !ok {
+		return false, err
+	}
+	c.lock.Lock()
+	c.tokens[key] = token
+	c.lock.Unlock()
+	return true, nil
+}
+
+func (c *RedisCache) Unlock(key string) {
+	c.lock.Lock()
+	token, ok := c.tokens[key]
+	delete(c.tokens, key)
+	c.lock.Unlock()
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := unlockScript.Run(ctx, c.client, []string{c.prefix + "lock:" + key}, token).Err(); err != nil {
+		c.logger.Errorf("Fail to release lock %s: %v", key, err)
+	}
+}
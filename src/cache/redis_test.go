@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package cache
+package cache
+
+import (
+	"fmt"
+	"metar-service/src/interfaces/config"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newTestRedisCache(t *testing.T, server *miniredis.Miniredis, name string) *RedisCache {
+	c, err := NewRedisCache(nopLogger{}, &config.RedisConfig{Address: server.Addr(), Prefix: "metar-service"}, name)
+	if err != nil {
+		t.Fatalf("NewRedisCache() error = %v", err)
+	}
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestRedisCacheRoundTrip(t *testing.T) {
+	server := miniredis.RunT(t)
+	c := newTestRedisCache(t, server, "metar")
+	value := "ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021"
+
+	c.SetWithTTL("ZBAA", &value, time.Minute)
+	c.SetWithTTL("ZZZZ", nil, time.Minute)
+	if data, ok := c.Get("ZBAA"); !ok || data == nil || *data != value {
+		t.Errorf("Get(ZBAA) = %v, %v, want %q", data, ok, value)
+	}
+	if data, ok := c.Get("ZZZZ"); !ok || data != nil {
+		t.Errorf("Get(ZZZZ) = %v, %v, want cached nil", data, ok)
+	}
+	if !server.Exists("metar-service:metar:ZBAA") {
+		t.Error("key metar-service:metar:ZBAA not found in redis")
+	}
+
+	server.FastForward(2 * time.Minute)
+	if _, ok := c.Get("ZBAA"); ok {
+		t.Error("Get(ZBAA) found entry after ttl")
+	}
+}
+
+func TestRedisCacheLock(t *testing.T) {
+	server := miniredis.RunT(t)
+	first := newTestRedisCache(t, server, "metar")
+	second := newTestRedisCache(t, server, "metar")
+
+	if ok, err := first.TryLock("ZBAA", time.Second); err != nil || !ok {
+		t.Fatalf("first TryLock() = %v, %v, want acquired", ok, err)
+	}
+	if ok, err := second.TryLock("ZBAA", time.Second); err != nil || ok {
+		t.Fatalf("second TryLock() = %v, %v, want held by first", ok, err)
+	}
+	// 未持有锁的实例释放锁不影响持有锁的实例
+	second.Unlock("ZBAA")
+	if !server.Exists("metar-service:metar:lock:ZBAA") {
+		t.Fatal("lock released by instance not holding it")
+	}
+
+	// 锁过期后被其他实例获取, 原持有者释放时令牌不匹配, 不会删除新的锁
+	server.FastForward(2 * time.Second)
+	if ok, err := second.TryLock("ZBAA", time.Second); err != nil || !ok {
+		t.Fatalf("second TryLock() after expiry = %v, %v, want acquired", ok, err)
+	}
+	first.Unlock("ZBAA")
+	if ok, _ := first.TryLock("ZBAA", time.Second); ok {
+		t.Fatal("first Unlock() released lock held by second")
+	}
+
+	second.Unlock("ZBAA")
+	if ok, err := first.TryLock("ZBAA", time.Second); err != nil || !ok {
+		t.Errorf("first TryLock() after Unlock() = %v, %v, want acquired", ok, err)
+	}
+}
+
+func TestRedisCacheClear(t *testing.T) {
+	server := miniredis.RunT(t)
+	c := newTestRedisCache(t, server, "metar")
+	other := newTestRedisCache(t, server, "taf")
+	value := "ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021"
+
+	// 键数量超过单次SCAN返回的数量, 需要多次SCAN才能清除
+	for i := range 2*redisScanCount + 10 {
+		c.SetWithTTL(fmt.Sprintf("K%03d", i), &value, time.Hour)
+	}
+	c.Set("stale:ZBAA", &value)
+	other.Set("ZBAA", &value)
+	if ok, err := c.TryLock("ZSSS", time.Minute); err != nil || !ok {
+		t.Fatalf("TryLock() = %v, %v, want acquired", ok, err)
+	}
+
+	c.Clear()
+	if keys := server.Keys(); len(keys) != 2 {
+		t.Errorf("keys after Clear() = %v, want taf entry and lock", keys)
+	}
+	if _, ok := other.Get("ZBAA"); !ok {
+		t.Error("Clear() removed entry of other namespace")
+	}
+	if !server.Exists("metar-service:metar:lock:ZSSS") {
+		t.Error("Clear() removed lock")
+	}
+}
+
+func TestEscapePattern(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"metar-service:metar:", "metar-service:metar:"},
+		{"metar*:taf:", `metar\*:taf:`},
+		{"a?b[c]^d", `a\?b\[c\]\^d`},
+		{`a\b`, `a\\b`},
+	}
+	for _, tt := range tests {
+		if got := escapePattern(tt.value); got != tt.want {
+			t.Errorf("escapePattern(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package cache
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	gormLogger "gorm.io/gorm/logger"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// sqliteEntry 缓存表中的一行, Value为空表示缓存了空值
+type sqliteEntry struct {
+	Key      string  `gorm:"primaryKey"`
+	Value    *string `gorm:"type:text"`
+	ExpireAt int64   `gorm:"index"` // 过期时间(unix毫秒), 0表示永不过期
+}
+
+// SqliteCache 基于SQLite的持久化缓存, 服务重启后仍可使用之前的数据
+type SqliteCache struct {
+	logger   logger.Interface
+	db       *gorm.DB
+	table    string
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewSqliteCache(
+	lg logger.Interface,
+	path string,
+	table string,
+	cleanInterval time.Duration,
+) (*SqliteCache, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("fail to create cache directory: %w", err)
+	}
+	// 多个缓存共用同一个数据库文件, 使用WAL并设置等待时间避免写入冲突
+	dsn := fmt.Sprintf("%s?_journal_mode=WAL&_busy_timeout=5000", path)
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: gormLogger.Discard})
+	if err != nil {
+		return nil, fmt.Errorf("fail to open cache database %s: %w", path, err)
+	}
+	if err := db.Table(table).AutoMigrate(&sqliteEntry{}); err != nil {
+		return nil, fmt.Errorf("fail to migrate cache table %s: %w", table, err)
+	}
+
+	c := &SqliteCache{
+		logger: logger.NewLoggerAdapter(lg, fmt.Sprintf("sqlite-cache-%s", table)),
+		db:     db,
+		table:  table,
+		stop:   make(chan struct{}),
+	}
+	go c.clean(cleanInterval)
+	return c, nil
+}
+
+func (c *SqliteCache) Set(key string, value *string) {
+	c.save(&sqliteEntry{Key: key, Value: value})
+}
+
+func (c *SqliteCache) SetWithTTL(key string, value *string, ttl time.Duration) {
+	c.save(&sqliteEntry{Key: key, Value: value, ExpireAt: time.Now().Add(ttl).UnixMilli()})
+}
+
+func (c *SqliteCache) Get(key string) (*string, bool) {
+	entry := &sqliteEntry{}
+	err := c.db.Table(c.table).Where("key = ?", key).Take(entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false
+	}
+	if err != nil {
+		c.logger.Errorf("Fail to get cache %s: %v", key, err)
+		return nil, false
+	}
+	if entry.ExpireAt != 0 && entry.ExpireAt <= time.Now().UnixMilli() {
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+func (c *SqliteCache) Del(key string) {
+	if err := c.db.Table(c.table).Where("key = ?", key).Delete(&sqliteEntry{}).Error; err != nil {
+		c.logger.Errorf("Fail to delete cache %s: %v", key, err)
+	}
+}
+
+// Clear 清除缓存表中的所有缓存, 包括服务重启前写入的缓存
+func (c *SqliteCache) Clear() {
+	err := c.db.Table(c.table).Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&sqliteEntry{}).Error
+	if err != nil {
+		c.logger.Errorf("Fail to clear cache: %v", err)
+	}
+}
+
+func (c *SqliteCache) Close() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		if db, err := c.db.DB(); err == nil {
+			_ = db.Close()
+		}
+	})
+}
+
+func (c *SqliteCache) save(entry *sqliteEntry) {
+	err := c.db.Table(c.table).Clauses(clause.OnConflict{UpdateAll: true}).Create(entry).Error
+	if err != nil {
+		c.logger.Errorf("Fail to set cache %s: %v", entry.Key, err)
+	}
+}
+
+// clean 定期删除已过期的缓存
+func (c *SqliteCache) clean(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			err := c.db.Table(c.table).
+				Where("expire_at != 0 AND expire_at <= ?", time.Now().UnixMilli()).
+				Delete(&sqliteEntry{}).Error
+			if err != nil {
+				c.logger.Errorf("Fail to clean expired cache: %v", err)
+			}
+		}
+	}
+}
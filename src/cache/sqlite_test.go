@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package cache
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// nopLogger 丢弃测试中的日志
+type nopLogger struct {
+	logger.Interface
+}
+
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+
+func newTestSqliteCache(t *testing.T) *SqliteCache {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "cache.db")), &gorm.Config{Logger: gormLogger.Discard})
+	if err != nil {
+		t.Fatalf("open database error = %v", err)
+	}
+	if err := db.Table("metar_cache").AutoMigrate(&sqliteEntry{}); err != nil {
+		t.Fatalf("migrate error = %v", err)
+	}
+	c := &SqliteCache{logger: nopLogger{}, db: db, table: "metar_cache", stop: make(chan struct{})}
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestSqliteCacheClear(t *testing.T) {
+	c := newTestSqliteCache(t)
+	value := "ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021"
+	c.Set("ZBAA", &value)
+	c.SetWithTTL("ZSSS", nil, time.Hour)
+	c.SetWithTTL("stale:ZBAA", &value, time.Hour)
+
+	c.Clear()
+	for _, key := range []string{"ZBAA", "ZSSS", "stale:ZBAA"} {
+		if _, ok := c.Get(key); ok {
+			t.Errorf("Get(%s) found entry after Clear()", key)
+		}
+	}
+
+	c.Set("ZBAA", &value)
+	if data, ok := c.Get("ZBAA"); !ok || *data != value {
+		t.Errorf("Get(ZBAA) after Clear() = %v, %v, want %q", data, ok, value)
+	}
+}
+
+func TestSqliteCacheRoundTrip(t *testing.T) {
+	c := newTestSqliteCache(t)
+	if _, ok := c.Get("ZBAA"); ok {
+		t.Fatal("Get(ZBAA) found entry in empty cache")
+	}
+
+	first, second := "ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021", "ZBAA 151130Z 36004MPS CAVOK 12/M05 Q1021"
+	c.Set("ZBAA", &first)
+	if data, ok := c.Get("ZBAA"); !ok || data == nil || *data != first {
+		t.Fatalf("Get(ZBAA) = %v, %v, want %q", data, ok, first)
+	}
+	c.SetWithTTL("ZBAA", &second, time.Hour)
+	if data, ok := c.Get("ZBAA"); !ok || data == nil || *data != second {
+		t.Errorf("Get(ZBAA) after overwrite = %v, %v, want %q", data, ok, second)
+	}
+
+	// 空值表示缓存了站点不存在的结果, 与未缓存区分
+	c.SetWithTTL("ZZZZ", nil, time.Hour)
+	if data, ok := c.Get("ZZZZ"); !ok || data != nil {
+		t.Errorf("Get(ZZZZ) = %v, %v, want cached nil", data, ok)
+	}
+
+	c.Del("ZBAA")
+	if _, ok := c.Get("ZBAA"); ok {
+		t.Error("Get(ZBAA) found entry after Del()")
+	}
+}
+
+func TestSqliteCachePersistent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "cache.db")
+	value := "ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021"
+	c, err := NewSqliteCache(nopLogger{}, path, "metar_cache", time.Hour)
+	if err != nil {
+		t.Fatalf("NewSqliteCache() error = %v", err)
+	}
+	c.SetWithTTL("ZBAA", &value, time.Hour)
+	c.Close()
+
+	reopened, err := NewSqliteCache(nopLogger{}, path, "metar_cache", time.Hour)
+	if err != nil {
+		t.Fatalf("NewSqliteCache() after reopen error = %v", err)
+	}
+	defer reopened.Close()
+	if data, ok := reopened.Get("ZBAA"); !ok || data == nil || *data != value {
+		t.Errorf("Get(ZBAA) after reopen = %v, %v, want %q", data, ok, value)
+	}
+}
+
+func TestSqliteCacheExpire(t *testing.T) {
+	c := newTestSqliteCache(t)
+	value := "ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021"
+	c.Set("ZBAA", &value)
+	c.SetWithTTL("ZSSS", &value, time.Hour)
+	c.SetWithTTL("ZSPD", &value, 50*time.Millisecond)
+	c.SetWithTTL("ZZZZ", nil, 50*time.Millisecond)
+	if _, ok := c.Get("ZSPD"); !ok {
+		t.Fatal("Get(ZSPD) missing before expiry")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	for key, want := range map[string]bool{"ZBAA": true, "ZSSS": true, "ZSPD": false, "ZZZZ": false} {
+		if _, ok := c.Get(key); ok != want {
+			t.Errorf("Get(%s) found = %v, want %v", key, ok, want)
+		}
+	}
+
+	// 定期清理删除已过期的行, 未过期与永不过期的行保留
+	go c.clean(10 * time.Millisecond)
+	deadline := time.Now().Add(time.Second)
+	var count int64
+	for time.Now().Before(deadline) {
+		c.db.Table(c.table).Count(&count)
+		if count == 2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if count != 2 {
+		t.Errorf("rows after clean = %d, want 2", count)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package control
+package control
+
+import (
+	"encoding/json"
+	"metar-service/src/interfaces/metar"
+	"strings"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// 通过consul事件下发到所有实例的控制指令
+const (
+	EventPurge           = "metar-service-purge"            // 清除指定站点的缓存
+	EventPurgeAll        = "metar-service-purge-all"        // 清除所有缓存
+	EventRefreshProvider = "metar-service-refresh-provider" // 重新获取由指定数据源提供的报文
+)
+
+// EventPayload 控制事件的内容, Type为空时同时作用于METAR与TAF
+type EventPayload struct {
+	Type     string   `json:"type"`
+	ICAO     []string `json:"icao"`
+	Provider string   `json:"provider"`
+}
+
+type EventHandler struct {
+	logger       logger.Interface
+	metarManager metar.ManagerInterface
+	tafManager   metar.ManagerInterface
+	stations     metar.StationDatabaseInterface
+}
+
+func NewEventHandler(
+	lg logger.Interface,
+	metarManager metar.ManagerInterface,
+	tafManager metar.ManagerInterface,
+	stations metar.StationDatabaseInterface,
+) *EventHandler {
+	return &EventHandler{
+		logger:       logger.NewLoggerAdapter(lg, "event-handler"),
+		metarManager: metarManager,
+		tafManager:   tafManager,
+		stations:     stations,
+	}
+}
+
+// Handle 处理一条consul用户事件, 忽略不属于本服务的事件
+func (h *EventHandler) Handle(name string, data []byte) {
+	if !strings.HasPrefix(name, "metar-service-") {
+		return
+	}
+
+	payload := &EventPayload{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, payload); err != nil {
+			h.logger.Errorf("Invalid payload of event %s: %v", name, err)
+			return
+		}
+	}
+
+	managers := h.managers(payload.Type)
+	if managers == nil {
+		h.logger.Errorf("Invalid report type %s of event %s", payload.Type, name)
+		return
+	}
+
+	switch name {
+	case EventPurge:
+		// 缓存以解析后的ICAO代码为键, 与查询时一致解析IATA代码与FAA LID
+		icaos := make([]string, 0, len(payload.ICAO))
+		for _, code := range payload.ICAO {
+			icao, err := metar.ResolveICAO(h.stations, code)
+			if err != nil {
+				h.logger.Errorf("Invalid icao %s of event %s: %v", code, name, err)
+				continue
+			}
+			icaos = append(icaos, icao)
+		}
+		for _, manager := range managers {
+			for _, icao := range icaos {
+				manager.Purge(icao)
+			}
+		}
+		h.logger.Infof("Purged cache of %v", icaos)
+	case EventPurgeAll:
+		for _, manager := range managers {
+			manager.PurgeAll()
+		}
+		h.logger.Info("Purged all cache")
+	case EventRefreshProvider:
+		if payload.Provider == "" {
+			h.logger.Errorf("Event %s need a provider", name)
+			return
+		}
+		count := 0
+		for _, manager := range managers {
+			count += manager.RefreshProvider(payload.Provider)
+		}
+		h.logger.Infof("Refreshed %d reports from provider %s", count, payload.Provider)
+	default:
+		h.logger.Warnf("Unknown event %s", name)
+	}
+}
+
+func (h *EventHandler) managers(reportType string) []metar.ManagerInterface {
+	switch strings.ToLower(reportType) {
+	case "":
+		return []metar.ManagerInterface{h.metarManager, h.tafManager}
+	case metar.ReportTypeMetar:
+		return []metar.ManagerInterface{h.metarManager}
+	case metar.ReportTypeTaf:
+		return []metar.ManagerInterface{h.tafManager}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package control
+package control
+
+import (
+	"metar-service/src/interfaces/metar"
+	"slices"
+	"testing"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// nopLogger 丢弃处理事件时的日志
+type nopLogger struct {
+	logger.Interface
+}
+
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+
+// manager 记录清除的站点
+type manager struct {
+	metar.ManagerInterface
+	purged []string
+}
+
+func (m *manager) Purge(icao string) {
+	m.purged = append(m.purged, icao)
+}
+
+func TestHandlePurge(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		metar   []string
+		taf     []string
+	}{
+		{"both types", `{"icao": ["ZBAA", "zsss"]}`, []string{"ZBAA", "ZSSS"}, []string{"ZBAA", "ZSSS"}},
+		{"metar only", `{"type": "metar", "icao": [" zbaa "]}`, []string{"ZBAA"}, nil},
+		{"skip invalid icao", `{"type": "taf", "icao": ["ZB1", "ZGGG"]}`, nil, []string{"ZGGG"}},
+		{"invalid type", `{"type": "sigmet", "icao": ["ZBAA"]}`, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metarManager, tafManager := &manager{}, &manager{}
+			h := &EventHandler{logger: nopLogger{}, metarManager: metarManager, tafManager: tafManager}
+			h.Handle(EventPurge, []byte(tt.payload))
+			if !slices.Equal(metarManager.purged, tt.metar) {
+				t.Errorf("metar purged = %v, want %v", metarManager.purged, tt.metar)
+			}
+			if !slices.Equal(tafManager.purged, tt.taf) {
+				t.Errorf("taf purged = %v, want %v", tafManager.purged, tt.taf)
+			}
+		})
+	}
+}
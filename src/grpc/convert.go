@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package grpc
+package grpc
+
+import (
+	pb "metar-service/src/interfaces/grpc"
+	"metar-service/src/interfaces/metar"
+)
+
+func int32Ptr(value *int) *int32 {
+	if value == nil {
+		return nil
+	}
+	result := int32(*value)
+	return &result
+}
+
+func toPbConditions(conditions *metar.Conditions) *pb.Conditions {
+	if conditions == nil {
+		return nil
+	}
+	result := &pb.Conditions{
+		Cavok:              conditions.Cavok,
+		Weather:            make([]string, 0, len(conditions.Weather)),
+		Clouds:             make([]*pb.Cloud, 0, len(conditions.Clouds)),
+		VerticalVisibility: int32Ptr(conditions.VerticalVisibility),
+		FlightCategory:     conditions.FlightCategory,
+	}
+	if wind := conditions.Wind; wind != nil {
+		result.Wind = &pb.Wind{
+			Direction:    int32Ptr(wind.Direction),
+			Variable:     wind.Variable,
+			Speed:        int32Ptr(wind.Speed),
+			Gust:         int32Ptr(wind.Gust),
+			Unit:         wind.Unit,
+			VariableFrom: int32Ptr(wind.VariableFrom),
+			VariableTo:   int32Ptr(wind.VariableTo),
+		}
+	}
+	if visibility := conditions.Visibility; visibility != nil {
+		result.Visibility = &pb.Visibility{Meters: visibility.Meters, Modifier: visibility.Modifier}
+	}
+	for _, weather := range conditions.Weather {
+		result.Weather = append(result.Weather, weather.Raw)
+	}
+	for _, cloud := range conditions.Clouds {
+		result.Clouds = append(result.Clouds, &pb.Cloud{Cover: cloud.Cover, Height: int32Ptr(cloud.Height), Type: cloud.Type})
+	}
+	return result
+}
+
+func toTafAtReply(conditions *metar.TafConditions) *pb.TafAtReply {
+	reply := &pb.TafAtReply{
+		Icao:       conditions.Station,
+		Time:       conditions.Time.Unix(),
+		Raw:        conditions.Raw,
+		Prevailing: toPbConditions(conditions.Prevailing),
+		Worst:      toPbConditions(conditions.Worst),
+		Periods:    make([]string, 0, len(conditions.Periods)),
+	}
+	for _, period := range conditions.Periods {
+		reply.Periods = append(reply.Periods, period.Raw)
+	}
+	return reply
+}
+
+func toBatchReply(results []*metar.QueryResult) *pb.BatchReply {
+	reply := &pb.BatchReply{Results: make([]*pb.QueryResult, 0, len(results))}
+	for _, result := range results {
+		item := &pb.QueryResult{
+			Icao:     result.ICAO,
+			Query:    result.Query,
+			Status:   result.Status,
+			Data:     result.Data,
+			Provider: result.Provider,
+			Age:      result.Age,
+			CacheHit: result.CacheHit,
+			Stale:    result.Stale,
+			Override: result.Override,
+		}
+		if result.Fallback != nil {
+			item.Fallback = &pb.Fallback{
+				Requested:  result.Fallback.Requested,
+				DistanceNm: result.Fallback.DistanceNM,
+				Bearing:    result.Fallback.Bearing,
+			}
+		}
+		if result.FetchTime != nil {
+			item.FetchTime = result.FetchTime.Unix()
+		}
+		if result.ObservationTime != nil {
+			item.ObservationTime = result.ObservationTime.Unix()
+		}
+		reply.Results = append(reply.Results, item)
+	}
+	return reply
+}
+
+func toHistoryReply(reports []*metar.ArchivedReport) *pb.HistoryReply {
+	reply := &pb.HistoryReply{Reports: make([]*pb.ArchivedReport, 0, len(reports))}
+	for _, report := range reports {
+		reply.Reports = append(reply.Reports, &pb.ArchivedReport{
+			Icao:       report.ICAO,
+			ReportTime: report.ReportTime.Unix(),
+			Data:       report.Data,
+			Provider:   report.Provider,
+			FetchTime:  report.FetchTime.Unix(),
+		})
+	}
+	return reply
+}
+
+func toPbStation(station *metar.Station) *pb.Station {
+	result := &pb.Station{
+		Icao:         station.ICAO,
+		Iata:         station.IATA,
+		LocalCode:    station.LocalCode,
+		Name:         station.Name,
+		Type:         station.Type,
+		Latitude:     station.Latitude,
+		Longitude:    station.Longitude,
+		Elevation:    int32Ptr(station.Elevation),
+		Country:      station.Country,
+		Region:       station.Region,
+		Municipality: station.Municipality,
+		Runways:      make([]*pb.Runway, 0, len(station.Runways)),
+	}
+	for _, runway := range station.Runways {
+		item := &pb.Runway{
+			Length:  int32Ptr(runway.Length),
+			Width:   int32Ptr(runway.Width),
+			Surface: runway.Surface,
+			Lighted: runway.Lighted,
+			Closed:  runway.Closed,
+			Ends:    make([]*pb.RunwayEnd, 0, len(runway.Ends)),
+		}
+		for _, end := range runway.Ends {
+			item.Ends = append(item.Ends, &pb.RunwayEnd{
+				Ident:     end.Ident,
+				Heading:   end.Heading,
+				Latitude:  end.Latitude,
+				Longitude: end.Longitude,
+				Elevation: int32Ptr(end.Elevation),
+			})
+		}
+		result.Runways = append(result.Runways, item)
+	}
+	return result
+}
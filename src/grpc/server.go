@@ -6,10 +6,15 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	pb "metar-service/src/interfaces/grpc"
 	"metar-service/src/interfaces/metar"
+	"metar-service/src/metar/forecast"
+	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"half-nothing.cn/service-core/interfaces/logger"
 )
@@ -19,30 +24,297 @@ type MetarServer struct {
 	logger       logger.Interface
 	metarManager metar.ManagerInterface
 	tafManager   metar.ManagerInterface
+	tafParser    metar.ParserInterface[*metar.Taf]
+	classifier   metar.ClassifierInterface
+	metarArchive metar.ArchiveInterface
+	tafArchive   metar.ArchiveInterface
+	replay       metar.ReplayInterface
+	stations     metar.StationDatabaseInterface
 }
 
 func NewMetarServer(
 	lg logger.Interface,
 	metarManager metar.ManagerInterface,
 	tafManager metar.ManagerInterface,
+	tafParser metar.ParserInterface[*metar.Taf],
+	classifier metar.ClassifierInterface,
+	metarArchive metar.ArchiveInterface,
+	tafArchive metar.ArchiveInterface,
+	replay metar.ReplayInterface,
+	stations metar.StationDatabaseInterface,
 ) *MetarServer {
 	return &MetarServer{
 		logger:       logger.NewLoggerAdapter(lg, "grpc-server"),
 		metarManager: metarManager,
 		tafManager:   tafManager,
+		tafParser:    tafParser,
+		classifier:   classifier,
+		metarArchive: metarArchive,
+		tafArchive:   tafArchive,
+		replay:       replay,
+		stations:     stations,
 	}
 }
 
-func (m MetarServer) GetMetar(_ context.Context, in *pb.MetarQuery) (*pb.MetarReply, error) {
+// ReplayMetadata 指定单次调用回放的模拟时间(RFC3339), 值为live时忽略全局回放返回实时报文
+const ReplayMetadata = "x-replay-time"
+
+// managers 根据调用元数据与全局回放选择实时或回放的报文管理器, 同时返回当前时间或模拟时间
+func (m MetarServer) managers(ctx context.Context) (metar.ManagerInterface, metar.ManagerInterface, time.Time, error) {
+	value := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(ReplayMetadata); len(values) > 0 {
+			value = values[0]
+		}
+	}
+	if strings.EqualFold(value, "live") || (value == "" && m.replay == nil) {
+		return m.metarManager, m.tafManager, time.Now(), nil
+	}
+	if m.replay == nil {
+		return nil, nil, time.Time{}, status.Error(codes.Unimplemented, "History archive is disabled")
+	}
+	at, replay := m.replay.Now()
+	if value != "" {
+		var err error
+		if at, err = time.Parse(time.RFC3339, value); err != nil {
+			return nil, nil, time.Time{}, status.Error(codes.InvalidArgument, "Invalid replay time")
+		}
+		replay = true
+	}
+	if !replay {
+		return m.metarManager, m.tafManager, time.Now(), nil
+	}
+	return m.replay.MetarManager(at), m.replay.TafManager(at), at, nil
+}
+
+func (m MetarServer) GetMetar(ctx context.Context, in *pb.MetarQuery) (*pb.MetarReply, error) {
 	if len(in.Icao) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "Invalid ICAO")
 	}
-	return &pb.MetarReply{Metar: m.metarManager.BatchQuery(in.Icao)}, nil
+	metarManager, _, _, err := m.managers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.MetarReply{Metar: metarManager.BatchQuery(in.Icao)}, nil
+}
+
+func (m MetarServer) GetTaf(ctx context.Context, in *pb.TafQuery) (*pb.TafReply, error) {
+	if len(in.Icao) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid ICAO")
+	}
+	_, tafManager, _, err := m.managers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TafReply{Taf: tafManager.BatchQuery(in.Icao)}, nil
+}
+
+func (m MetarServer) GetMetarBatch(ctx context.Context, in *pb.MetarQuery) (*pb.BatchReply, error) {
+	if len(in.Icao) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid ICAO")
+	}
+	metarManager, _, _, err := m.managers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toBatchReply(metarManager.BatchQueryResult(in.Icao)), nil
 }
 
-func (m MetarServer) GetTaf(_ context.Context, in *pb.TafQuery) (*pb.TafReply, error) {
+func (m MetarServer) GetTafBatch(ctx context.Context, in *pb.TafQuery) (*pb.BatchReply, error) {
 	if len(in.Icao) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "Invalid ICAO")
 	}
-	return &pb.TafReply{Taf: m.tafManager.BatchQuery(in.Icao)}, nil
+	_, tafManager, _, err := m.managers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toBatchReply(tafManager.BatchQueryResult(in.Icao)), nil
+}
+
+func (m MetarServer) GetMetarNearest(ctx context.Context, in *pb.NearestQuery) (*pb.BatchReply, error) {
+	metarManager, _, _, err := m.managers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.getNearest(metarManager, in)
+}
+
+func (m MetarServer) GetTafNearest(ctx context.Context, in *pb.NearestQuery) (*pb.BatchReply, error) {
+	_, tafManager, _, err := m.managers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.getNearest(tafManager, in)
+}
+
+// getNearest 查询附近有报文的站点, 数量的默认值与上限与HTTP接口一致
+func (m MetarServer) getNearest(manager metar.ManagerInterface, in *pb.NearestQuery) (*pb.BatchReply, error) {
+	count := int(in.Count)
+	if count <= 0 {
+		count = metar.DefaultNearestCount
+	}
+	count = min(count, metar.MaxNearestCount)
+	results, err := manager.Nearest(in.Icao, count)
+	if errors.Is(err, metar.ErrICAOInvalid) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid ICAO")
+	}
+	if errors.Is(err, metar.ErrTargetNotFound) {
+		return nil, status.Error(codes.NotFound, "No nearby station found")
+	}
+	if errors.Is(err, metar.ErrStationUnknown) {
+		return nil, status.Error(codes.NotFound, "Station not in station database")
+	}
+	if errors.Is(err, metar.ErrUpstreamFailed) {
+		return nil, status.Error(codes.Unavailable, "All providers fail")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return toBatchReply(results), nil
+}
+
+func (m MetarServer) GetMetarArea(ctx context.Context, in *pb.AreaQuery) (*pb.BatchReply, error) {
+	metarManager, _, _, err := m.managers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.getArea(metarManager, in)
+}
+
+func (m MetarServer) GetTafArea(ctx context.Context, in *pb.AreaQuery) (*pb.BatchReply, error) {
+	_, tafManager, _, err := m.managers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.getArea(tafManager, in)
+}
+
+// getArea 查询区域内所有已知站点的报文, 与HTTP接口一致只返回有报文的站点
+func (m MetarServer) getArea(manager metar.ManagerInterface, in *pb.AreaQuery) (*pb.BatchReply, error) {
+	// 未指定中心时无法与赤道或本初子午线上的中心区分, 不使用默认值0
+	if in.RadiusNm > 0 && (in.Latitude == nil || in.Longitude == nil) {
+		return nil, status.Error(codes.InvalidArgument, "Latitude and longitude are required with radius_nm")
+	}
+	area := &metar.Area{
+		South:     in.South,
+		West:      in.West,
+		North:     in.North,
+		East:      in.East,
+		Latitude:  in.GetLatitude(),
+		Longitude: in.GetLongitude(),
+		Radius:    in.RadiusNm * metar.NauticalMile,
+	}
+	if !area.Valid() {
+		return nil, status.Error(codes.InvalidArgument, "Invalid area")
+	}
+	stations := m.stations.Area(area, metar.MaxAreaStations)
+	if len(stations) == 0 {
+		return nil, status.Error(codes.NotFound, "No station of station database in area")
+	}
+	icaos := make([]string, 0, len(stations))
+	for _, station := range stations {
+		icaos = append(icaos, station.ICAO)
+	}
+	results := make([]*metar.QueryResult, 0, len(icaos))
+	for _, result := range manager.BatchQueryResult(icaos) {
+		if result.Status == metar.QueryStatusOk && result.Fallback == nil {
+			results = append(results, result)
+		}
+	}
+	return toBatchReply(results), nil
+}
+
+func (m MetarServer) GetMetarHistory(_ context.Context, in *pb.HistoryQuery) (*pb.HistoryReply, error) {
+	return m.getHistory(m.metarArchive, in)
+}
+
+func (m MetarServer) GetTafHistory(_ context.Context, in *pb.HistoryQuery) (*pb.HistoryReply, error) {
+	return m.getHistory(m.tafArchive, in)
+}
+
+// getHistory 查询归档报文, 时间范围与数量的默认值与HTTP接口一致
+func (m MetarServer) getHistory(archive metar.ArchiveInterface, in *pb.HistoryQuery) (*pb.HistoryReply, error) {
+	if archive == nil {
+		return nil, status.Error(codes.Unimplemented, "History archive is disabled")
+	}
+	icao, err := metar.ResolveICAO(m.stations, in.Icao)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid ICAO")
+	}
+	to := time.Now()
+	if in.To != 0 {
+		to = time.Unix(in.To, 0)
+	}
+	from := to.Add(-metar.DefaultHistoryRange)
+	if in.From != 0 {
+		from = time.Unix(in.From, 0)
+	}
+	if from.After(to) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid time range")
+	}
+	limit := int(in.Limit)
+	if limit <= 0 {
+		limit = metar.DefaultHistoryLimit
+	}
+	limit = min(limit, metar.MaxHistoryLimit)
+	reports, err := archive.History(icao, from, to, limit)
+	if err != nil {
+		m.logger.Errorf("GetHistory fail, cannot query history of %s: %v", icao, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return toHistoryReply(reports), nil
+}
+
+func (m MetarServer) GetStation(_ context.Context, in *pb.StationQuery) (*pb.Station, error) {
+	icao, err := metar.ResolveICAO(m.stations, in.Icao)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid ICAO")
+	}
+	station, ok := m.stations.Get(icao)
+	if !ok {
+		return nil, status.Error(codes.NotFound, "Station not found")
+	}
+	return toPbStation(station), nil
+}
+
+func (m MetarServer) GetTafAt(ctx context.Context, in *pb.TafAtQuery) (*pb.TafAtReply, error) {
+	_, tafManager, reference, err := m.managers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	at := reference
+	if in.Time != 0 {
+		at = time.Unix(in.Time, 0)
+	}
+	data, err := tafManager.Query(in.Icao)
+	if errors.Is(err, metar.ErrICAOInvalid) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid ICAO")
+	}
+	if errors.Is(err, metar.ErrTargetNotFound) {
+		return nil, status.Error(codes.NotFound, "Taf not found")
+	}
+	if errors.Is(err, metar.ErrUpstreamFailed) {
+		return nil, status.Error(codes.Unavailable, "All providers fail")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	taf, err := m.tafParser.ParseAt(data, reference)
+	if err != nil {
+		m.logger.Errorf("GetTafAt fail, cannot parse %s: %v", data, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	result, err := forecast.ConditionsAt(taf, at)
+	if errors.Is(err, metar.ErrTargetNotFound) {
+		return nil, status.Error(codes.NotFound, "Taf not found")
+	}
+	if errors.Is(err, metar.ErrTimeOutOfRange) {
+		return nil, status.Error(codes.OutOfRange, "Time out of taf validity")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	result.Prevailing.FlightCategory = m.classifier.Classify(result.Prevailing)
+	result.Worst.FlightCategory = m.classifier.Classify(result.Worst)
+	return toTafAtReply(result), nil
 }
@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package grpc
+package grpc
+
+import (
+	"context"
+	"metar-service/src/interfaces/config"
+	pb "metar-service/src/interfaces/grpc"
+	"metar-service/src/interfaces/metar"
+	"metar-service/src/metar/category"
+	"metar-service/src/metar/parser"
+	"metar-service/src/replay"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type archive struct {
+	reports []*metar.ArchivedReport
+	queried string
+}
+
+func (a *archive) Save(report *metar.ArchivedReport) error {
+	a.reports = append(a.reports, report)
+	return nil
+}
+
+func (a *archive) History(icao string, _ time.Time, _ time.Time, _ int) ([]*metar.ArchivedReport, error) {
+	a.queried = icao
+	return a.reports, nil
+}
+
+func (a *archive) At(icao string, at time.Time) (*metar.ArchivedReport, error) {
+	var latest *metar.ArchivedReport
+	for _, report := range a.reports {
+		if report.ICAO == icao && !report.ReportTime.After(at) {
+			latest = report
+		}
+	}
+	if latest == nil {
+		return nil, metar.ErrTargetNotFound
+	}
+	return latest, nil
+}
+
+type fixedReplay struct {
+	archive *archive
+}
+
+func (r *fixedReplay) Now() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (r *fixedReplay) MetarManager(at time.Time) metar.ManagerInterface {
+	return replay.NewManager(r.archive, nil, nil, at, 2*time.Hour)
+}
+
+func (r *fixedReplay) TafManager(at time.Time) metar.ManagerInterface {
+	return replay.NewManager(r.archive, nil, nil, at, 30*time.Hour)
+}
+
+func TestGetTafAtReplaysPastYear(t *testing.T) {
+	issued := time.Date(2023, time.February, 27, 11, 0, 0, 0, time.UTC)
+	server := &MetarServer{
+		tafParser:  parser.NewTafParser(),
+		classifier: category.NewClassifier(&config.FlightCategoryConfig{Standard: "faa"}),
+		replay: &fixedReplay{archive: &archive{reports: []*metar.ArchivedReport{{
+			ICAO:       "ZBAA",
+			ReportTime: issued,
+			Data:       "TAF ZBAA 271100Z 2712/2818 36005MPS 9999 FEW030 BECMG 2800/2802 1500 BR OVC004",
+			Provider:   "test",
+			FetchTime:  issued,
+		}}}},
+	}
+
+	tests := []struct {
+		name     string
+		replay   time.Time
+		at       time.Time
+		category string
+	}{
+		{"replay time", issued.Add(2 * time.Hour), time.Time{}, category.FlightCategoryVFR},
+		{"after change", issued.Add(2 * time.Hour), time.Date(2023, time.February, 28, 6, 0, 0, 0, time.UTC), category.FlightCategoryLIFR},
+		{"validity end crosses month", issued.Add(20 * time.Hour), time.Date(2023, time.February, 28, 17, 0, 0, 0, time.UTC), category.FlightCategoryLIFR},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ReplayMetadata, tt.replay.Format(time.RFC3339)))
+			query := &pb.TafAtQuery{Icao: "zbaa"}
+			expected := tt.replay
+			if !tt.at.IsZero() {
+				query.Time = tt.at.Unix()
+				expected = tt.at
+			}
+			reply, err := server.GetTafAt(ctx, query)
+			if err != nil {
+				t.Fatalf("GetTafAt() error = %v", err)
+			}
+			if reply.Time != expected.Unix() {
+				t.Errorf("GetTafAt() time = %s, want %s", time.Unix(reply.Time, 0).UTC(), expected)
+			}
+			if reply.Prevailing.FlightCategory != tt.category {
+				t.Errorf("GetTafAt() prevailing category = %s, want %s", reply.Prevailing.FlightCategory, tt.category)
+			}
+		})
+	}
+}
+
+func TestGetHistoryResolvesICAO(t *testing.T) {
+	tests := []struct {
+		icao string
+		want string
+		code codes.Code
+	}{
+		{"ZBAA", "ZBAA", codes.OK},
+		{" zsss ", "ZSSS", codes.OK},
+		{"ZB1", "", codes.InvalidArgument},
+		{"", "", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		history := &archive{}
+		server := &MetarServer{metarArchive: history}
+		_, err := server.GetMetarHistory(context.Background(), &pb.HistoryQuery{Icao: tt.icao})
+		if code := status.Code(err); code != tt.code {
+			t.Errorf("GetMetarHistory(%q) code = %s, want %s", tt.icao, code, tt.code)
+		}
+		if history.queried != tt.want {
+			t.Errorf("GetMetarHistory(%q) queried %q, want %q", tt.icao, history.queried, tt.want)
+		}
+	}
+}
+
+func TestGetAreaRequiresCenter(t *testing.T) {
+	latitude, longitude, invalid := 31.2, 121.3, 91.0
+	tests := []struct {
+		name  string
+		query *pb.AreaQuery
+	}{
+		{"missing center", &pb.AreaQuery{RadiusNm: 50}},
+		{"missing longitude", &pb.AreaQuery{Latitude: &latitude, RadiusNm: 50}},
+		{"missing latitude", &pb.AreaQuery{Longitude: &longitude, RadiusNm: 50}},
+		{"latitude out of range", &pb.AreaQuery{Latitude: &invalid, Longitude: &longitude, RadiusNm: 50}},
+		{"invalid box", &pb.AreaQuery{South: 32, West: 120, North: 30, East: 122}},
+	}
+	server := &MetarServer{}
+	for _, tt := range tests {
+		if _, err := server.getArea(nil, tt.query); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("getArea() %s error = %v, want %v", tt.name, err, codes.InvalidArgument)
+		}
+	}
+}
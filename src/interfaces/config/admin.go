@@ -0,0 +1,27 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import "fmt"
+
+// AdminConfig 管理接口配置, 用于指定报文与运行天气场景
+type AdminConfig struct {
+	Enable   bool   `yaml:"enable"`
+	Token    string `yaml:"token"`    // 访问令牌, 请求时通过 Authorization: Bearer <token> 提供
+	Scenario string `yaml:"scenario"` // 启动时加载的场景脚本路径, 为空或未启用管理接口时不加载
+}
+
+func (a *AdminConfig) InitDefaults() {
+	a.Enable = false
+	a.Token = ""
+	a.Scenario = ""
+}
+
+func (a *AdminConfig) Verify() (bool, error) {
+	if a.Enable && len(a.Token) < 16 {
+		return false, fmt.Errorf("admin token must be at least 16 characters")
+	}
+	return true, nil
+}
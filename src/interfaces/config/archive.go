@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"half-nothing.cn/service-core/utils"
+)
+
+// ArchiveConfig 历史报文归档配置
+type ArchiveConfig struct {
+	Enable bool   `yaml:"enable"`
+	Driver string `yaml:"driver"` // 数据库类型, sqlite mysql 或 postgres
+	DSN    string `yaml:"dsn"`    // 数据库连接字符串, sqlite为数据库文件路径
+}
+
+type ArchiveDriver *utils.Enum[string, string]
+
+var (
+	ArchiveDriverSqlite   ArchiveDriver = utils.NewEnum("sqlite", "SQLite")
+	ArchiveDriverMysql    ArchiveDriver = utils.NewEnum("mysql", "MySQL")
+	ArchiveDriverPostgres ArchiveDriver = utils.NewEnum("postgres", "PostgreSQL")
+)
+
+var ArchiveDrivers = utils.NewEnums(ArchiveDriverSqlite, ArchiveDriverMysql, ArchiveDriverPostgres)
+
+func (a *ArchiveConfig) InitDefaults() {
+	a.Enable = false
+	a.Driver = "sqlite"
+	a.DSN = "./data/archive.db"
+}
+
+func (a *ArchiveConfig) Verify() (bool, error) {
+	if !a.Enable {
+		return true, nil
+	}
+	if !ArchiveDrivers.IsValidEnum(strings.ToLower(a.Driver)) {
+		return false, fmt.Errorf("archive driver %s is not supported", a.Driver)
+	}
+	if a.DSN == "" {
+		return false, fmt.Errorf("archive need a dsn")
+	}
+	return true, nil
+}
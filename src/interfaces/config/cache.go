@@ -0,0 +1,179 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"half-nothing.cn/service-core/utils"
+)
+
+// CachePolicyConfig 报文缓存过期策略
+type CachePolicyConfig struct {
+	Interval    string            `yaml:"interval"`      // 默认发报间隔
+	Stations    map[string]string `yaml:"stations"`      // 指定站点的发报间隔
+	Delay       string            `yaml:"delay"`         // 新报文在数据源上可用的延迟
+	MinTTL      string            `yaml:"min_ttl"`       // 最短缓存时间, 报文过期未更新时使用
+	MaxTTL      string            `yaml:"max_ttl"`       // 最长缓存时间
+	NegativeTTL string            `yaml:"negative_ttl"`  // 未找到报文时的缓存时间
+	StaleMaxAge string            `yaml:"stale_max_age"` // 数据源全部失败时可返回的过期报文的最大报文年龄, 0表示不返回过期报文
+
+	IntervalDuration    time.Duration            `yaml:"-"`
+	StationDurations    map[string]time.Duration `yaml:"-"`
+	DelayDuration       time.Duration            `yaml:"-"`
+	MinTTLDuration      time.Duration            `yaml:"-"`
+	MaxTTLDuration      time.Duration            `yaml:"-"`
+	NegativeTTLDuration time.Duration            `yaml:"-"`
+	StaleMaxAgeDuration time.Duration            `yaml:"-"`
+}
+
+type CacheConfig struct {
+	Type  string             `yaml:"type"` // 缓存类型, memory 或 sqlite
+	Path  string             `yaml:"path"` // sqlite数据库文件路径
+	Redis *RedisConfig       `yaml:"redis"`
+	Metar *CachePolicyConfig `yaml:"metar"`
+	Taf   *CachePolicyConfig `yaml:"taf"`
+}
+
+// RedisConfig Redis协议缓存服务配置, 多个实例共用时使用相同的前缀
+type RedisConfig struct {
+	Address  string `yaml:"address"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+	Prefix   string `yaml:"prefix"`
+}
+
+type CacheType *utils.Enum[string, string]
+
+var (
+	CacheTypeMemory CacheType = utils.NewEnum("memory", "Memory")
+	CacheTypeSqlite CacheType = utils.NewEnum("sqlite", "SQLite")
+	CacheTypeRedis  CacheType = utils.NewEnum("redis", "Redis")
+)
+
+var CacheTypes = utils.NewEnums(CacheTypeMemory, CacheTypeSqlite, CacheTypeRedis)
+
+func (r *RedisConfig) InitDefaults() {
+	r.Address = "localhost:6379"
+	r.Username = ""
+	r.Password = ""
+	r.DB = 0
+	r.Prefix = "metar-service"
+}
+
+func (r *RedisConfig) Verify() (bool, error) {
+	if r.Address == "" {
+		return false, fmt.Errorf("cache with type 'redis' need an address")
+	}
+	if r.Prefix == "" {
+		return false, fmt.Errorf("cache with type 'redis' need a prefix")
+	}
+	return true, nil
+}
+
+func (c *CachePolicyConfig) InitDefaults() {
+	c.Interval = "30m"
+	c.Stations = make(map[string]string)
+	c.Delay = "5m"
+	c.MinTTL = "1m"
+	c.MaxTTL = "1h"
+	c.NegativeTTL = "5m"
+	c.StaleMaxAge = "3h"
+}
+
+func (c *CachePolicyConfig) Verify() (bool, error) {
+	var err error
+	// 旧版本配置文件中没有该项, 使用默认值
+	if c.StaleMaxAge == "" {
+		c.StaleMaxAge = "3h"
+	}
+	durations := []struct {
+		name   string
+		value  string
+		target *time.Duration
+	}{
+		{"interval", c.Interval, &c.IntervalDuration},
+		{"delay", c.Delay, &c.DelayDuration},
+		{"min_ttl", c.MinTTL, &c.MinTTLDuration},
+		{"max_ttl", c.MaxTTL, &c.MaxTTLDuration},
+		{"negative_ttl", c.NegativeTTL, &c.NegativeTTLDuration},
+		{"stale_max_age", c.StaleMaxAge, &c.StaleMaxAgeDuration},
+	}
+	for _, duration := range durations {
+		if *duration.target, err = time.ParseDuration(duration.value); err != nil {
+			return false, fmt.Errorf("cache %s %s is invalid: %v", duration.name, duration.value, err)
+		}
+		if *duration.target < 0 {
+			return false, fmt.Errorf("cache %s must not be negative", duration.name)
+		}
+	}
+	if c.IntervalDuration == 0 {
+		return false, fmt.Errorf("cache interval must be positive")
+	}
+	if c.MaxTTLDuration < c.MinTTLDuration {
+		return false, fmt.Errorf("cache max_ttl must not be less than min_ttl")
+	}
+	c.StationDurations = make(map[string]time.Duration, len(c.Stations))
+	for station, interval := range c.Stations {
+		duration, err := time.ParseDuration(interval)
+		if err != nil || duration <= 0 {
+			return false, fmt.Errorf("cache interval %s of station %s is invalid", interval, station)
+		}
+		c.StationDurations[strings.ToUpper(station)] = duration
+	}
+	return true, nil
+}
+
+func (c *CacheConfig) InitDefaults() {
+	c.Type = "memory"
+	c.Path = "./data/cache.db"
+	c.Redis = &RedisConfig{}
+	c.Redis.InitDefaults()
+	c.Metar = &CachePolicyConfig{}
+	c.Metar.InitDefaults()
+	c.Taf = &CachePolicyConfig{}
+	c.Taf.InitDefaults()
+	c.Taf.Interval = "6h"
+}
+
+func (c *CacheConfig) Verify() (bool, error) {
+	if c.Type == "" {
+		c.Type = CacheTypeMemory.Value
+	}
+	cacheType := strings.ToLower(c.Type)
+	if !CacheTypes.IsValidEnum(cacheType) {
+		return false, fmt.Errorf("cache type %s is not supported", c.Type)
+	}
+	if cacheType == CacheTypeSqlite.Value && c.Path == "" {
+		return false, fmt.Errorf("cache with type 'sqlite' need a path")
+	}
+	if cacheType == CacheTypeRedis.Value {
+		if c.Redis == nil {
+			return false, fmt.Errorf("cache with type 'redis' need redis config")
+		}
+		if ok, err := c.Redis.Verify(); !ok {
+			return false, err
+		}
+	}
+	if c.Metar == nil {
+		c.Metar = &CachePolicyConfig{}
+		c.Metar.InitDefaults()
+	}
+	if c.Taf == nil {
+		c.Taf = &CachePolicyConfig{}
+		c.Taf.InitDefaults()
+		c.Taf.Interval = "6h"
+	}
+	if ok, err := c.Metar.Verify(); !ok {
+		return false, fmt.Errorf("metar %v", err)
+	}
+	if ok, err := c.Taf.Verify(); !ok {
+		return false, fmt.Errorf("taf %v", err)
+	}
+	return true, nil
+}
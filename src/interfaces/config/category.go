@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"half-nothing.cn/service-core/utils"
+)
+
+// FlightCategoryRule 飞行类别规则, 云幕高或能见度任一低于阈值即属于该类别
+type FlightCategoryRule struct {
+	Name       string  `yaml:"name"`
+	Ceiling    int     `yaml:"ceiling"`    // 云幕高阈值(英尺), 0表示不限制
+	Visibility float64 `yaml:"visibility"` // 能见度阈值(米), 0表示不限制
+}
+
+type FlightCategoryConfig struct {
+	Standard string                `yaml:"standard"`
+	Default  string                `yaml:"default"`
+	Rules    []*FlightCategoryRule `yaml:"rules"`
+}
+
+type CategoryStandard *utils.Enum[string, string]
+
+var (
+	CategoryStandardFaa    CategoryStandard = utils.NewEnum("faa", "FAA")
+	CategoryStandardCustom CategoryStandard = utils.NewEnum("custom", "Custom")
+)
+
+var CategoryStandards = utils.NewEnums(CategoryStandardFaa, CategoryStandardCustom)
+
+func (f *FlightCategoryConfig) InitDefaults() {
+	f.Standard = "faa"
+	f.Default = "VFR"
+	f.Rules = make([]*FlightCategoryRule, 0)
+}
+
+func (f *FlightCategoryConfig) Verify() (bool, error) {
+	standard := strings.ToLower(f.Standard)
+	if !CategoryStandards.IsValidEnum(standard) {
+		return false, fmt.Errorf("flight category standard %s is not supported", f.Standard)
+	}
+	if standard != CategoryStandardCustom.Value {
+		return true, nil
+	}
+	if f.Default == "" {
+		return false, fmt.Errorf("custom flight category need a default category")
+	}
+	if len(f.Rules) == 0 {
+		return false, fmt.Errorf("custom flight category need at least one rule")
+	}
+	for _, rule := range f.Rules {
+		if rule.Name == "" {
+			return false, fmt.Errorf("flight category rule name is required")
+		}
+		if rule.Ceiling < 0 || rule.Visibility < 0 {
+			return false, fmt.Errorf("flight category rule %s error: threshold must not be negative", rule.Name)
+		}
+	}
+	return true, nil
+}
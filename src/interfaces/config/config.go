@@ -11,10 +11,18 @@ import (
 )
 
 type Config struct {
-	GlobalConfig    *GlobalConfig           `yaml:"global"`
-	ServerConfig    *config.ServerConfig    `yaml:"server"`
-	ProviderConfigs []*ProviderConfig       `yaml:"provider"`
-	TelemetryConfig *config.TelemetryConfig `yaml:"telemetry"`
+	GlobalConfig         *GlobalConfig           `yaml:"global"`
+	ServerConfig         *config.ServerConfig    `yaml:"server"`
+	ProviderConfigs      []*ProviderConfig       `yaml:"provider"`
+	CacheConfig          *CacheConfig            `yaml:"cache"`
+	RefreshConfig        *RefreshConfig          `yaml:"refresh"`
+	ArchiveConfig        *ArchiveConfig          `yaml:"archive"`
+	ReplayConfig         *ReplayConfig           `yaml:"replay"`
+	AdminConfig          *AdminConfig            `yaml:"admin"`
+	StationConfig        *StationConfig          `yaml:"station"`
+	FallbackConfig       *FallbackConfig         `yaml:"fallback"`
+	FlightCategoryConfig *FlightCategoryConfig   `yaml:"flight_category"`
+	TelemetryConfig      *config.TelemetryConfig `yaml:"telemetry"`
 }
 
 func (c *Config) InitDefaults() {
@@ -24,6 +32,22 @@ func (c *Config) InitDefaults() {
 	c.ServerConfig.InitDefaults()
 	c.ProviderConfigs = []*ProviderConfig{{}}
 	c.ProviderConfigs[0].InitDefaults()
+	c.CacheConfig = &CacheConfig{}
+	c.CacheConfig.InitDefaults()
+	c.RefreshConfig = &RefreshConfig{}
+	c.RefreshConfig.InitDefaults()
+	c.ArchiveConfig = &ArchiveConfig{}
+	c.ArchiveConfig.InitDefaults()
+	c.ReplayConfig = &ReplayConfig{}
+	c.ReplayConfig.InitDefaults()
+	c.AdminConfig = &AdminConfig{}
+	c.AdminConfig.InitDefaults()
+	c.StationConfig = &StationConfig{}
+	c.StationConfig.InitDefaults()
+	c.FallbackConfig = &FallbackConfig{}
+	c.FallbackConfig.InitDefaults()
+	c.FlightCategoryConfig = &FlightCategoryConfig{}
+	c.FlightCategoryConfig.InitDefaults()
 	c.TelemetryConfig = &config.TelemetryConfig{}
 	c.TelemetryConfig.InitDefaults()
 }
@@ -49,6 +73,66 @@ func (c *Config) Verify() (bool, error) {
 			return false, err
 		}
 	}
+	// 旧版本配置文件中没有以下配置项, 使用默认值
+	if c.CacheConfig == nil {
+		c.CacheConfig = &CacheConfig{}
+		c.CacheConfig.InitDefaults()
+	}
+	if ok, err := c.CacheConfig.Verify(); !ok {
+		return false, err
+	}
+	if c.RefreshConfig == nil {
+		c.RefreshConfig = &RefreshConfig{}
+		c.RefreshConfig.InitDefaults()
+	}
+	if ok, err := c.RefreshConfig.Verify(); !ok {
+		return false, err
+	}
+	if c.ArchiveConfig == nil {
+		c.ArchiveConfig = &ArchiveConfig{}
+		c.ArchiveConfig.InitDefaults()
+	}
+	if ok, err := c.ArchiveConfig.Verify(); !ok {
+		return false, err
+	}
+	if c.ReplayConfig == nil {
+		c.ReplayConfig = &ReplayConfig{}
+		c.ReplayConfig.InitDefaults()
+	}
+	if ok, err := c.ReplayConfig.Verify(); !ok {
+		return false, err
+	}
+	if c.ReplayConfig.Enable && !c.ArchiveConfig.Enable {
+		return false, fmt.Errorf("replay need archive to be enabled")
+	}
+	if c.AdminConfig == nil {
+		c.AdminConfig = &AdminConfig{}
+		c.AdminConfig.InitDefaults()
+	}
+	if ok, err := c.AdminConfig.Verify(); !ok {
+		return false, err
+	}
+	if c.StationConfig == nil {
+		c.StationConfig = &StationConfig{}
+		c.StationConfig.InitDefaults()
+	}
+	if ok, err := c.StationConfig.Verify(); !ok {
+		return false, err
+	}
+	if c.FallbackConfig == nil {
+		c.FallbackConfig = &FallbackConfig{}
+		c.FallbackConfig.InitDefaults()
+	}
+	if ok, err := c.FallbackConfig.Verify(); !ok {
+		return false, err
+	}
+	if c.FlightCategoryConfig == nil {
+		c.FlightCategoryConfig = &FlightCategoryConfig{}
+		c.FlightCategoryConfig.InitDefaults()
+	}
+	if ok, err := c.FlightCategoryConfig.Verify(); !ok {
+		return false, err
+	}
 	if ok, err := c.TelemetryConfig.Verify(); !ok {
 		return false, err
 	}
@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import "fmt"
+
+// FallbackConfig 请求的站点没有报文时使用附近站点报文的配置
+type FallbackConfig struct {
+	Enable     bool    `yaml:"enable"`     // 普通查询没有报文时自动使用最近的有报文站点
+	RadiusNM   float64 `yaml:"radius_nm"`  // 搜索半径(海里), 与区域查询的单位一致
+	Candidates int     `yaml:"candidates"` // 最多查询的附近站点数
+}
+
+func (f *FallbackConfig) InitDefaults() {
+	f.Enable = false
+	f.RadiusNM = 30
+	f.Candidates = 10
+}
+
+func (f *FallbackConfig) Verify() (bool, error) {
+	if f.RadiusNM <= 0 {
+		return false, fmt.Errorf("fallback radius_nm must be positive")
+	}
+	if f.Candidates <= 0 {
+		return false, fmt.Errorf("fallback candidates must be positive")
+	}
+	return true, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// RefreshConfig 热门站点提前刷新配置
+type RefreshConfig struct {
+	Enable      bool   `yaml:"enable"`
+	Interval    string `yaml:"interval"`     // 扫描间隔
+	Ahead       string `yaml:"ahead"`        // 在缓存过期前多久刷新
+	Window      string `yaml:"window"`       // 统计查询次数的时间窗口
+	MinHits     int    `yaml:"min_hits"`     // 时间窗口内查询次数达到该值视为热门站点
+	MaxStations int    `yaml:"max_stations"` // 最多同时跟踪的站点数
+	Concurrency int    `yaml:"concurrency"`  // 同时刷新的站点数
+
+	IntervalDuration time.Duration `yaml:"-"`
+	AheadDuration    time.Duration `yaml:"-"`
+	WindowDuration   time.Duration `yaml:"-"`
+}
+
+func (r *RefreshConfig) InitDefaults() {
+	r.Enable = true
+	r.Interval = "10s"
+	r.Ahead = "2m"
+	r.Window = "1h"
+	r.MinHits = 3
+	r.MaxStations = 500
+	r.Concurrency = 4
+}
+
+func (r *RefreshConfig) Verify() (bool, error) {
+	var err error
+	durations := []struct {
+		name   string
+		value  string
+		target *time.Duration
+	}{
+		{"interval", r.Interval, &r.IntervalDuration},
+		{"ahead", r.Ahead, &r.AheadDuration},
+		{"window", r.Window, &r.WindowDuration},
+	}
+	for _, duration := range durations {
+		if *duration.target, err = time.ParseDuration(duration.value); err != nil {
+			return false, fmt.Errorf("refresh %s %s is invalid: %v", duration.name, duration.value, err)
+		}
+		if *duration.target <= 0 {
+			return false, fmt.Errorf("refresh %s must be positive", duration.name)
+		}
+	}
+	if r.MinHits <= 0 {
+		return false, fmt.Errorf("refresh min_hits must be positive")
+	}
+	if r.MaxStations <= 0 {
+		return false, fmt.Errorf("refresh max_stations must be positive")
+	}
+	if r.Concurrency <= 0 {
+		return false, fmt.Errorf("refresh concurrency must be positive")
+	}
+	return true, nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReplayConfig 历史回放配置, 回放的报文来自历史报文归档
+type ReplayConfig struct {
+	Enable      bool    `yaml:"enable"`        // 是否启用全局回放, 未启用时仍可通过请求头指定模拟时间
+	Start       string  `yaml:"start"`         // 全局模拟时钟的起始时间, RFC3339格式, 服务启动时从该时间开始计时
+	Speed       float64 `yaml:"speed"`         // 全局模拟时钟的速度倍率
+	MetarMaxAge string  `yaml:"metar_max_age"` // 回放时METAR的最大报文年龄, 超过时视为无报文
+	TafMaxAge   string  `yaml:"taf_max_age"`   // 回放时TAF的最大报文年龄, 超过时视为无报文
+
+	StartTime           time.Time     `yaml:"-"`
+	MetarMaxAgeDuration time.Duration `yaml:"-"`
+	TafMaxAgeDuration   time.Duration `yaml:"-"`
+}
+
+func (r *ReplayConfig) InitDefaults() {
+	r.Enable = false
+	r.Start = ""
+	r.Speed = 1
+	r.MetarMaxAge = "3h"
+	r.TafMaxAge = "30h"
+}
+
+func (r *ReplayConfig) Verify() (bool, error) {
+	var err error
+	if r.Enable {
+		if r.StartTime, err = time.Parse(time.RFC3339, r.Start); err != nil {
+			return false, fmt.Errorf("replay start %s is invalid: %v", r.Start, err)
+		}
+	}
+	if r.Speed <= 0 {
+		return false, fmt.Errorf("replay speed must be positive")
+	}
+	if r.MetarMaxAgeDuration, err = time.ParseDuration(r.MetarMaxAge); err != nil || r.MetarMaxAgeDuration <= 0 {
+		return false, fmt.Errorf("replay metar_max_age %s is invalid", r.MetarMaxAge)
+	}
+	if r.TafMaxAgeDuration, err = time.ParseDuration(r.TafMaxAge); err != nil || r.TafMaxAgeDuration <= 0 {
+		return false, fmt.Errorf("replay taf_max_age %s is invalid", r.TafMaxAge)
+	}
+	return true, nil
+}
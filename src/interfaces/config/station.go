@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package config
+package config
+
+import "fmt"
+
+// StationConfig 机场数据库配置, 文件格式与OurAirports的CSV数据集一致
+type StationConfig struct {
+	Airports string `yaml:"airports"` // 机场数据文件路径, 为空时使用内置数据
+	Runways  string `yaml:"runways"`  // 跑道数据文件路径, 为空时使用内置数据
+	Strict   bool   `yaml:"strict"`   // 只接受机场数据库中存在的ICAO代码
+}
+
+func (s *StationConfig) InitDefaults() {
+	s.Airports = ""
+	s.Runways = ""
+	s.Strict = false
+}
+
+func (s *StationConfig) Verify() (bool, error) {
+	if s.Airports == "" && s.Runways != "" {
+		return false, fmt.Errorf("station runways need airports to be set")
+	}
+	if s.Strict && s.Airports == "" {
+		return false, fmt.Errorf("station strict mode need airports to be set")
+	}
+	return true, nil
+}
@@ -48,6 +48,71 @@ func (builder *ApplicationContentBuilder) SetTafManager(tafManager metar.Manager
 	return builder
 }
 
+func (builder *ApplicationContentBuilder) SetMetarParser(metarParser metar.ParserInterface[*metar.Metar]) *ApplicationContentBuilder {
+	builder.content.metarParser = metarParser
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetTafParser(tafParser metar.ParserInterface[*metar.Taf]) *ApplicationContentBuilder {
+	builder.content.tafParser = tafParser
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetMetarEncoder(metarEncoder metar.EncoderInterface[*metar.Metar]) *ApplicationContentBuilder {
+	builder.content.metarEncoder = metarEncoder
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetTafEncoder(tafEncoder metar.EncoderInterface[*metar.Taf]) *ApplicationContentBuilder {
+	builder.content.tafEncoder = tafEncoder
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetClassifier(classifier metar.ClassifierInterface) *ApplicationContentBuilder {
+	builder.content.classifier = classifier
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetTranslator(translator metar.TranslatorInterface) *ApplicationContentBuilder {
+	builder.content.translator = translator
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetMetarArchive(metarArchive metar.ArchiveInterface) *ApplicationContentBuilder {
+	builder.content.metarArchive = metarArchive
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetTafArchive(tafArchive metar.ArchiveInterface) *ApplicationContentBuilder {
+	builder.content.tafArchive = tafArchive
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetReplay(replay metar.ReplayInterface) *ApplicationContentBuilder {
+	builder.content.replay = replay
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetMetarOverride(metarOverride metar.OverrideInterface) *ApplicationContentBuilder {
+	builder.content.metarOverride = metarOverride
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetTafOverride(tafOverride metar.OverrideInterface) *ApplicationContentBuilder {
+	builder.content.tafOverride = tafOverride
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetScenario(scenario metar.ScenarioRunnerInterface) *ApplicationContentBuilder {
+	builder.content.scenario = scenario
+	return builder
+}
+
+func (builder *ApplicationContentBuilder) SetStationDatabase(stationDatabase metar.StationDatabaseInterface) *ApplicationContentBuilder {
+	builder.content.stationDatabase = stationDatabase
+	return builder
+}
+
 func (builder *ApplicationContentBuilder) Build() *ApplicationContent {
 	return builder.content
 }
@@ -15,11 +15,24 @@ import (
 
 // ApplicationContent 应用程序上下文结构体，包含所有核心组件的接口
 type ApplicationContent struct {
-	configManager config.ManagerInterface[*c.Config] // 配置管理器
-	cleaner       cleaner.Interface                  // 清理器
-	logger        logger.Interface                   // 日志
-	metarManager  metar.ManagerInterface             // METAR气象数据管理器
-	tafManager    metar.ManagerInterface             // TAF天气预报数据管理器
+	configManager   config.ManagerInterface[*c.Config]   // 配置管理器
+	cleaner         cleaner.Interface                    // 清理器
+	logger          logger.Interface                     // 日志
+	metarManager    metar.ManagerInterface               // METAR气象数据管理器
+	tafManager      metar.ManagerInterface               // TAF天气预报数据管理器
+	metarParser     metar.ParserInterface[*metar.Metar]  // METAR报文解析器
+	tafParser       metar.ParserInterface[*metar.Taf]    // TAF报文解析器
+	metarEncoder    metar.EncoderInterface[*metar.Metar] // METAR报文编码器
+	tafEncoder      metar.EncoderInterface[*metar.Taf]   // TAF报文编码器
+	classifier      metar.ClassifierInterface            // 飞行类别计算器
+	translator      metar.TranslatorInterface            // 报文翻译器
+	metarArchive    metar.ArchiveInterface               // METAR历史报文归档, 未启用时为空
+	tafArchive      metar.ArchiveInterface               // TAF历史报文归档, 未启用时为空
+	replay          metar.ReplayInterface                // 历史回放, 未启用归档时为空
+	metarOverride   metar.OverrideInterface              // 管理员指定的METAR
+	tafOverride     metar.OverrideInterface              // 管理员指定的TAF
+	scenario        metar.ScenarioRunnerInterface        // 天气场景运行器
+	stationDatabase metar.StationDatabaseInterface       // 机场数据库
 }
 
 func (app *ApplicationContent) ConfigManager() config.ManagerInterface[*c.Config] {
@@ -33,3 +46,35 @@ func (app *ApplicationContent) Logger() logger.Interface { return app.logger }
 func (app *ApplicationContent) MetarManager() metar.ManagerInterface { return app.metarManager }
 
 func (app *ApplicationContent) TafManager() metar.ManagerInterface { return app.tafManager }
+
+func (app *ApplicationContent) MetarParser() metar.ParserInterface[*metar.Metar] {
+	return app.metarParser
+}
+
+func (app *ApplicationContent) TafParser() metar.ParserInterface[*metar.Taf] { return app.tafParser }
+
+func (app *ApplicationContent) MetarEncoder() metar.EncoderInterface[*metar.Metar] {
+	return app.metarEncoder
+}
+
+func (app *ApplicationContent) TafEncoder() metar.EncoderInterface[*metar.Taf] { return app.tafEncoder }
+
+func (app *ApplicationContent) Classifier() metar.ClassifierInterface { return app.classifier }
+
+func (app *ApplicationContent) Translator() metar.TranslatorInterface { return app.translator }
+
+func (app *ApplicationContent) MetarArchive() metar.ArchiveInterface { return app.metarArchive }
+
+func (app *ApplicationContent) TafArchive() metar.ArchiveInterface { return app.tafArchive }
+
+func (app *ApplicationContent) Replay() metar.ReplayInterface { return app.replay }
+
+func (app *ApplicationContent) MetarOverride() metar.OverrideInterface { return app.metarOverride }
+
+func (app *ApplicationContent) TafOverride() metar.OverrideInterface { return app.tafOverride }
+
+func (app *ApplicationContent) Scenario() metar.ScenarioRunnerInterface { return app.scenario }
+
+func (app *ApplicationContent) StationDatabase() metar.StationDatabaseInterface {
+	return app.stationDatabase
+}
@@ -200,6 +200,1364 @@ func (x *TafReply) GetTaf() []string {
 	return nil
 }
 
+// QueryResult 批量查询中单个机场的结果, status为 ok not_found invalid_icao upstream_error
+type QueryResult struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Icao   string                 `protobuf:"bytes,1,opt,name=icao,proto3" json:"icao,omitempty"`
+	Status string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Data   string                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// 提供报文的数据源名称
+	Provider string `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	// 获取时间与观测时间, unix时间戳(秒), 未知时为0
+	FetchTime       int64 `protobuf:"varint,5,opt,name=fetch_time,json=fetchTime,proto3" json:"fetch_time,omitempty"`
+	ObservationTime int64 `protobuf:"varint,6,opt,name=observation_time,json=observationTime,proto3" json:"observation_time,omitempty"`
+	// 距观测时间的秒数
+	Age      *int64 `protobuf:"varint,7,opt,name=age,proto3,oneof" json:"age,omitempty"`
+	CacheHit bool   `protobuf:"varint,8,opt,name=cache_hit,json=cacheHit,proto3" json:"cache_hit,omitempty"`
+	// 数据源全部失败时返回的过期报文
+	Stale bool `protobuf:"varint,9,opt,name=stale,proto3" json:"stale,omitempty"`
+	// 管理员指定的报文
+	Override bool `protobuf:"varint,10,opt,name=override,proto3" json:"override,omitempty"`
+	// 请求中的机场代码, 可能为IATA代码或FAA LID
+	Query string `protobuf:"bytes,11,opt,name=query,proto3" json:"query,omitempty"`
+	// 请求的站点没有报文时使用的附近站点信息, 此时icao为附近站点
+	Fallback      *Fallback `protobuf:"bytes,12,opt,name=fallback,proto3" json:"fallback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryResult) Reset() {
+	*x = QueryResult{}
+	mi := &file_metar_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResult) ProtoMessage() {}
+
+func (x *QueryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResult.ProtoReflect.Descriptor instead.
+func (*QueryResult) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *QueryResult) GetIcao() string {
+	if x != nil {
+		return x.Icao
+	}
+	return ""
+}
+
+func (x *QueryResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *QueryResult) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *QueryResult) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *QueryResult) GetFetchTime() int64 {
+	if x != nil {
+		return x.FetchTime
+	}
+	return 0
+}
+
+func (x *QueryResult) GetObservationTime() int64 {
+	if x != nil {
+		return x.ObservationTime
+	}
+	return 0
+}
+
+func (x *QueryResult) GetAge() int64 {
+	if x != nil && x.Age != nil {
+		return *x.Age
+	}
+	return 0
+}
+
+func (x *QueryResult) GetCacheHit() bool {
+	if x != nil {
+		return x.CacheHit
+	}
+	return false
+}
+
+func (x *QueryResult) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *QueryResult) GetOverride() bool {
+	if x != nil {
+		return x.Override
+	}
+	return false
+}
+
+func (x *QueryResult) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *QueryResult) GetFallback() *Fallback {
+	if x != nil {
+		return x.Fallback
+	}
+	return nil
+}
+
+type Fallback struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Requested string                 `protobuf:"bytes,1,opt,name=requested,proto3" json:"requested,omitempty"`
+	// 距离(海里)
+	DistanceNm float64 `protobuf:"fixed64,2,opt,name=distance_nm,json=distanceNm,proto3" json:"distance_nm,omitempty"`
+	// 相对请求站点的真方位(度)
+	Bearing       float64 `protobuf:"fixed64,3,opt,name=bearing,proto3" json:"bearing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fallback) Reset() {
+	*x = Fallback{}
+	mi := &file_metar_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fallback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fallback) ProtoMessage() {}
+
+func (x *Fallback) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fallback.ProtoReflect.Descriptor instead.
+func (*Fallback) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Fallback) GetRequested() string {
+	if x != nil {
+		return x.Requested
+	}
+	return ""
+}
+
+func (x *Fallback) GetDistanceNm() float64 {
+	if x != nil {
+		return x.DistanceNm
+	}
+	return 0
+}
+
+func (x *Fallback) GetBearing() float64 {
+	if x != nil {
+		return x.Bearing
+	}
+	return 0
+}
+
+type NearestQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Icao  string                 `protobuf:"bytes,1,opt,name=icao,proto3" json:"icao,omitempty"`
+	// 返回的站点数, 为0时返回1个, 最多10个
+	Count         int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NearestQuery) Reset() {
+	*x = NearestQuery{}
+	mi := &file_metar_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NearestQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearestQuery) ProtoMessage() {}
+
+func (x *NearestQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearestQuery.ProtoReflect.Descriptor instead.
+func (*NearestQuery) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *NearestQuery) GetIcao() string {
+	if x != nil {
+		return x.Icao
+	}
+	return ""
+}
+
+func (x *NearestQuery) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type BatchReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*QueryResult         `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchReply) Reset() {
+	*x = BatchReply{}
+	mi := &file_metar_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchReply) ProtoMessage() {}
+
+func (x *BatchReply) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchReply.ProtoReflect.Descriptor instead.
+func (*BatchReply) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchReply) GetResults() []*QueryResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type Wind struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Direction     *int32                 `protobuf:"varint,1,opt,name=direction,proto3,oneof" json:"direction,omitempty"`
+	Variable      bool                   `protobuf:"varint,2,opt,name=variable,proto3" json:"variable,omitempty"`
+	Speed         *int32                 `protobuf:"varint,3,opt,name=speed,proto3,oneof" json:"speed,omitempty"`
+	Gust          *int32                 `protobuf:"varint,4,opt,name=gust,proto3,oneof" json:"gust,omitempty"`
+	Unit          string                 `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
+	VariableFrom  *int32                 `protobuf:"varint,6,opt,name=variable_from,json=variableFrom,proto3,oneof" json:"variable_from,omitempty"`
+	VariableTo    *int32                 `protobuf:"varint,7,opt,name=variable_to,json=variableTo,proto3,oneof" json:"variable_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Wind) Reset() {
+	*x = Wind{}
+	mi := &file_metar_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Wind) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Wind) ProtoMessage() {}
+
+func (x *Wind) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Wind.ProtoReflect.Descriptor instead.
+func (*Wind) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Wind) GetDirection() int32 {
+	if x != nil && x.Direction != nil {
+		return *x.Direction
+	}
+	return 0
+}
+
+func (x *Wind) GetVariable() bool {
+	if x != nil {
+		return x.Variable
+	}
+	return false
+}
+
+func (x *Wind) GetSpeed() int32 {
+	if x != nil && x.Speed != nil {
+		return *x.Speed
+	}
+	return 0
+}
+
+func (x *Wind) GetGust() int32 {
+	if x != nil && x.Gust != nil {
+		return *x.Gust
+	}
+	return 0
+}
+
+func (x *Wind) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *Wind) GetVariableFrom() int32 {
+	if x != nil && x.VariableFrom != nil {
+		return *x.VariableFrom
+	}
+	return 0
+}
+
+func (x *Wind) GetVariableTo() int32 {
+	if x != nil && x.VariableTo != nil {
+		return *x.VariableTo
+	}
+	return 0
+}
+
+type Visibility struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meters        float64                `protobuf:"fixed64,1,opt,name=meters,proto3" json:"meters,omitempty"`
+	Modifier      string                 `protobuf:"bytes,2,opt,name=modifier,proto3" json:"modifier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Visibility) Reset() {
+	*x = Visibility{}
+	mi := &file_metar_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Visibility) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Visibility) ProtoMessage() {}
+
+func (x *Visibility) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Visibility.ProtoReflect.Descriptor instead.
+func (*Visibility) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Visibility) GetMeters() float64 {
+	if x != nil {
+		return x.Meters
+	}
+	return 0
+}
+
+func (x *Visibility) GetModifier() string {
+	if x != nil {
+		return x.Modifier
+	}
+	return ""
+}
+
+type Cloud struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cover         string                 `protobuf:"bytes,1,opt,name=cover,proto3" json:"cover,omitempty"`
+	Height        *int32                 `protobuf:"varint,2,opt,name=height,proto3,oneof" json:"height,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Cloud) Reset() {
+	*x = Cloud{}
+	mi := &file_metar_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Cloud) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cloud) ProtoMessage() {}
+
+func (x *Cloud) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cloud.ProtoReflect.Descriptor instead.
+func (*Cloud) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Cloud) GetCover() string {
+	if x != nil {
+		return x.Cover
+	}
+	return ""
+}
+
+func (x *Cloud) GetHeight() int32 {
+	if x != nil && x.Height != nil {
+		return *x.Height
+	}
+	return 0
+}
+
+func (x *Cloud) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type Conditions struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Wind               *Wind                  `protobuf:"bytes,1,opt,name=wind,proto3" json:"wind,omitempty"`
+	Visibility         *Visibility            `protobuf:"bytes,2,opt,name=visibility,proto3" json:"visibility,omitempty"`
+	Cavok              bool                   `protobuf:"varint,3,opt,name=cavok,proto3" json:"cavok,omitempty"`
+	Weather            []string               `protobuf:"bytes,4,rep,name=weather,proto3" json:"weather,omitempty"`
+	Clouds             []*Cloud               `protobuf:"bytes,5,rep,name=clouds,proto3" json:"clouds,omitempty"`
+	VerticalVisibility *int32                 `protobuf:"varint,6,opt,name=vertical_visibility,json=verticalVisibility,proto3,oneof" json:"vertical_visibility,omitempty"`
+	FlightCategory     string                 `protobuf:"bytes,7,opt,name=flight_category,json=flightCategory,proto3" json:"flight_category,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Conditions) Reset() {
+	*x = Conditions{}
+	mi := &file_metar_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Conditions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conditions) ProtoMessage() {}
+
+func (x *Conditions) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conditions.ProtoReflect.Descriptor instead.
+func (*Conditions) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Conditions) GetWind() *Wind {
+	if x != nil {
+		return x.Wind
+	}
+	return nil
+}
+
+func (x *Conditions) GetVisibility() *Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return nil
+}
+
+func (x *Conditions) GetCavok() bool {
+	if x != nil {
+		return x.Cavok
+	}
+	return false
+}
+
+func (x *Conditions) GetWeather() []string {
+	if x != nil {
+		return x.Weather
+	}
+	return nil
+}
+
+func (x *Conditions) GetClouds() []*Cloud {
+	if x != nil {
+		return x.Clouds
+	}
+	return nil
+}
+
+func (x *Conditions) GetVerticalVisibility() int32 {
+	if x != nil && x.VerticalVisibility != nil {
+		return *x.VerticalVisibility
+	}
+	return 0
+}
+
+func (x *Conditions) GetFlightCategory() string {
+	if x != nil {
+		return x.FlightCategory
+	}
+	return ""
+}
+
+type TafAtQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Icao  string                 `protobuf:"bytes,1,opt,name=icao,proto3" json:"icao,omitempty"`
+	// unix时间戳(秒), 为0时使用当前时间
+	Time          int64 `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TafAtQuery) Reset() {
+	*x = TafAtQuery{}
+	mi := &file_metar_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TafAtQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TafAtQuery) ProtoMessage() {}
+
+func (x *TafAtQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TafAtQuery.ProtoReflect.Descriptor instead.
+func (*TafAtQuery) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TafAtQuery) GetIcao() string {
+	if x != nil {
+		return x.Icao
+	}
+	return ""
+}
+
+func (x *TafAtQuery) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type TafAtReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Icao          string                 `protobuf:"bytes,1,opt,name=icao,proto3" json:"icao,omitempty"`
+	Time          int64                  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Raw           string                 `protobuf:"bytes,3,opt,name=raw,proto3" json:"raw,omitempty"`
+	Prevailing    *Conditions            `protobuf:"bytes,4,opt,name=prevailing,proto3" json:"prevailing,omitempty"`
+	Worst         *Conditions            `protobuf:"bytes,5,opt,name=worst,proto3" json:"worst,omitempty"`
+	Periods       []string               `protobuf:"bytes,6,rep,name=periods,proto3" json:"periods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TafAtReply) Reset() {
+	*x = TafAtReply{}
+	mi := &file_metar_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TafAtReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TafAtReply) ProtoMessage() {}
+
+func (x *TafAtReply) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TafAtReply.ProtoReflect.Descriptor instead.
+func (*TafAtReply) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TafAtReply) GetIcao() string {
+	if x != nil {
+		return x.Icao
+	}
+	return ""
+}
+
+func (x *TafAtReply) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *TafAtReply) GetRaw() string {
+	if x != nil {
+		return x.Raw
+	}
+	return ""
+}
+
+func (x *TafAtReply) GetPrevailing() *Conditions {
+	if x != nil {
+		return x.Prevailing
+	}
+	return nil
+}
+
+func (x *TafAtReply) GetWorst() *Conditions {
+	if x != nil {
+		return x.Worst
+	}
+	return nil
+}
+
+func (x *TafAtReply) GetPeriods() []string {
+	if x != nil {
+		return x.Periods
+	}
+	return nil
+}
+
+// AreaQuery radius_nm大于0时查询圆形区域, 否则查询矩形区域
+type AreaQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 矩形区域的边界(度), 西边界大于东边界时跨越180度经线
+	South float64 `protobuf:"fixed64,1,opt,name=south,proto3" json:"south,omitempty"`
+	West  float64 `protobuf:"fixed64,2,opt,name=west,proto3" json:"west,omitempty"`
+	North float64 `protobuf:"fixed64,3,opt,name=north,proto3" json:"north,omitempty"`
+	East  float64 `protobuf:"fixed64,4,opt,name=east,proto3" json:"east,omitempty"`
+	// 圆形区域的中心(度)与半径(海里), 查询圆形区域时必须指定中心
+	Latitude      *float64 `protobuf:"fixed64,5,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude     *float64 `protobuf:"fixed64,6,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	RadiusNm      float64  `protobuf:"fixed64,7,opt,name=radius_nm,json=radiusNm,proto3" json:"radius_nm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AreaQuery) Reset() {
+	*x = AreaQuery{}
+	mi := &file_metar_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AreaQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AreaQuery) ProtoMessage() {}
+
+func (x *AreaQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AreaQuery.ProtoReflect.Descriptor instead.
+func (*AreaQuery) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AreaQuery) GetSouth() float64 {
+	if x != nil {
+		return x.South
+	}
+	return 0
+}
+
+func (x *AreaQuery) GetWest() float64 {
+	if x != nil {
+		return x.West
+	}
+	return 0
+}
+
+func (x *AreaQuery) GetNorth() float64 {
+	if x != nil {
+		return x.North
+	}
+	return 0
+}
+
+func (x *AreaQuery) GetEast() float64 {
+	if x != nil {
+		return x.East
+	}
+	return 0
+}
+
+func (x *AreaQuery) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *AreaQuery) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *AreaQuery) GetRadiusNm() float64 {
+	if x != nil {
+		return x.RadiusNm
+	}
+	return 0
+}
+
+type HistoryQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Icao  string                 `protobuf:"bytes,1,opt,name=icao,proto3" json:"icao,omitempty"`
+	// 查询范围, unix时间戳(秒), to为0时使用当前时间, from为0时查询to之前24小时
+	From int64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   int64 `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	// 最多返回的报文数, 为0时返回100条
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryQuery) Reset() {
+	*x = HistoryQuery{}
+	mi := &file_metar_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryQuery) ProtoMessage() {}
+
+func (x *HistoryQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryQuery.ProtoReflect.Descriptor instead.
+func (*HistoryQuery) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *HistoryQuery) GetIcao() string {
+	if x != nil {
+		return x.Icao
+	}
+	return ""
+}
+
+func (x *HistoryQuery) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *HistoryQuery) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *HistoryQuery) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ArchivedReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Icao  string                 `protobuf:"bytes,1,opt,name=icao,proto3" json:"icao,omitempty"`
+	// 报文中的观测时间或发布时间, unix时间戳(秒)
+	ReportTime    int64  `protobuf:"varint,2,opt,name=report_time,json=reportTime,proto3" json:"report_time,omitempty"`
+	Data          string `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Provider      string `protobuf:"bytes,4,opt,name=provider,proto3" json:"provider,omitempty"`
+	FetchTime     int64  `protobuf:"varint,5,opt,name=fetch_time,json=fetchTime,proto3" json:"fetch_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchivedReport) Reset() {
+	*x = ArchivedReport{}
+	mi := &file_metar_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchivedReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchivedReport) ProtoMessage() {}
+
+func (x *ArchivedReport) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchivedReport.ProtoReflect.Descriptor instead.
+func (*ArchivedReport) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ArchivedReport) GetIcao() string {
+	if x != nil {
+		return x.Icao
+	}
+	return ""
+}
+
+func (x *ArchivedReport) GetReportTime() int64 {
+	if x != nil {
+		return x.ReportTime
+	}
+	return 0
+}
+
+func (x *ArchivedReport) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *ArchivedReport) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ArchivedReport) GetFetchTime() int64 {
+	if x != nil {
+		return x.FetchTime
+	}
+	return 0
+}
+
+type HistoryReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reports       []*ArchivedReport      `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryReply) Reset() {
+	*x = HistoryReply{}
+	mi := &file_metar_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryReply) ProtoMessage() {}
+
+func (x *HistoryReply) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryReply.ProtoReflect.Descriptor instead.
+func (*HistoryReply) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *HistoryReply) GetReports() []*ArchivedReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+type StationQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Icao          string                 `protobuf:"bytes,1,opt,name=icao,proto3" json:"icao,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StationQuery) Reset() {
+	*x = StationQuery{}
+	mi := &file_metar_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StationQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StationQuery) ProtoMessage() {}
+
+func (x *StationQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StationQuery.ProtoReflect.Descriptor instead.
+func (*StationQuery) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *StationQuery) GetIcao() string {
+	if x != nil {
+		return x.Icao
+	}
+	return ""
+}
+
+type RunwayEnd struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Ident string                 `protobuf:"bytes,1,opt,name=ident,proto3" json:"ident,omitempty"`
+	// 真航向(度)
+	Heading   *float64 `protobuf:"fixed64,2,opt,name=heading,proto3,oneof" json:"heading,omitempty"`
+	Latitude  *float64 `protobuf:"fixed64,3,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude *float64 `protobuf:"fixed64,4,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	// 跑道入口标高(英尺)
+	Elevation     *int32 `protobuf:"varint,5,opt,name=elevation,proto3,oneof" json:"elevation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunwayEnd) Reset() {
+	*x = RunwayEnd{}
+	mi := &file_metar_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunwayEnd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunwayEnd) ProtoMessage() {}
+
+func (x *RunwayEnd) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunwayEnd.ProtoReflect.Descriptor instead.
+func (*RunwayEnd) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RunwayEnd) GetIdent() string {
+	if x != nil {
+		return x.Ident
+	}
+	return ""
+}
+
+func (x *RunwayEnd) GetHeading() float64 {
+	if x != nil && x.Heading != nil {
+		return *x.Heading
+	}
+	return 0
+}
+
+func (x *RunwayEnd) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *RunwayEnd) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *RunwayEnd) GetElevation() int32 {
+	if x != nil && x.Elevation != nil {
+		return *x.Elevation
+	}
+	return 0
+}
+
+type Runway struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 长度与宽度(英尺)
+	Length        *int32       `protobuf:"varint,1,opt,name=length,proto3,oneof" json:"length,omitempty"`
+	Width         *int32       `protobuf:"varint,2,opt,name=width,proto3,oneof" json:"width,omitempty"`
+	Surface       string       `protobuf:"bytes,3,opt,name=surface,proto3" json:"surface,omitempty"`
+	Lighted       bool         `protobuf:"varint,4,opt,name=lighted,proto3" json:"lighted,omitempty"`
+	Closed        bool         `protobuf:"varint,5,opt,name=closed,proto3" json:"closed,omitempty"`
+	Ends          []*RunwayEnd `protobuf:"bytes,6,rep,name=ends,proto3" json:"ends,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Runway) Reset() {
+	*x = Runway{}
+	mi := &file_metar_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Runway) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Runway) ProtoMessage() {}
+
+func (x *Runway) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Runway.ProtoReflect.Descriptor instead.
+func (*Runway) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Runway) GetLength() int32 {
+	if x != nil && x.Length != nil {
+		return *x.Length
+	}
+	return 0
+}
+
+func (x *Runway) GetWidth() int32 {
+	if x != nil && x.Width != nil {
+		return *x.Width
+	}
+	return 0
+}
+
+func (x *Runway) GetSurface() string {
+	if x != nil {
+		return x.Surface
+	}
+	return ""
+}
+
+func (x *Runway) GetLighted() bool {
+	if x != nil {
+		return x.Lighted
+	}
+	return false
+}
+
+func (x *Runway) GetClosed() bool {
+	if x != nil {
+		return x.Closed
+	}
+	return false
+}
+
+func (x *Runway) GetEnds() []*RunwayEnd {
+	if x != nil {
+		return x.Ends
+	}
+	return nil
+}
+
+type Station struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Icao      string                 `protobuf:"bytes,1,opt,name=icao,proto3" json:"icao,omitempty"`
+	Iata      string                 `protobuf:"bytes,2,opt,name=iata,proto3" json:"iata,omitempty"`
+	LocalCode string                 `protobuf:"bytes,3,opt,name=local_code,json=localCode,proto3" json:"local_code,omitempty"`
+	Name      string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Type      string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Latitude  float64                `protobuf:"fixed64,6,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64                `protobuf:"fixed64,7,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// 标高(英尺)
+	Elevation     *int32    `protobuf:"varint,8,opt,name=elevation,proto3,oneof" json:"elevation,omitempty"`
+	Country       string    `protobuf:"bytes,9,opt,name=country,proto3" json:"country,omitempty"`
+	Region        string    `protobuf:"bytes,10,opt,name=region,proto3" json:"region,omitempty"`
+	Municipality  string    `protobuf:"bytes,11,opt,name=municipality,proto3" json:"municipality,omitempty"`
+	Runways       []*Runway `protobuf:"bytes,12,rep,name=runways,proto3" json:"runways,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Station) Reset() {
+	*x = Station{}
+	mi := &file_metar_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Station) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Station) ProtoMessage() {}
+
+func (x *Station) ProtoReflect() protoreflect.Message {
+	mi := &file_metar_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Station.ProtoReflect.Descriptor instead.
+func (*Station) Descriptor() ([]byte, []int) {
+	return file_metar_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Station) GetIcao() string {
+	if x != nil {
+		return x.Icao
+	}
+	return ""
+}
+
+func (x *Station) GetIata() string {
+	if x != nil {
+		return x.Iata
+	}
+	return ""
+}
+
+func (x *Station) GetLocalCode() string {
+	if x != nil {
+		return x.LocalCode
+	}
+	return ""
+}
+
+func (x *Station) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Station) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Station) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Station) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Station) GetElevation() int32 {
+	if x != nil && x.Elevation != nil {
+		return *x.Elevation
+	}
+	return 0
+}
+
+func (x *Station) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Station) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Station) GetMunicipality() string {
+	if x != nil {
+		return x.Municipality
+	}
+	return ""
+}
+
+func (x *Station) GetRunways() []*Runway {
+	if x != nil {
+		return x.Runways
+	}
+	return nil
+}
+
 var File_metar_proto protoreflect.FileDescriptor
 
 const file_metar_proto_rawDesc = "" +
@@ -214,10 +1572,166 @@ const file_metar_proto_rawDesc = "" +
 	"\bTafQuery\x12\x12\n" +
 	"\x04icao\x18\x01 \x03(\tR\x04icao\"\x1c\n" +
 	"\bTafReply\x12\x10\n" +
-	"\x03taf\x18\x01 \x03(\tR\x03taf2\x81\x01\n" +
+	"\x03taf\x18\x01 \x03(\tR\x03taf\"\xeb\x02\n" +
+	"\vQueryResult\x12\x12\n" +
+	"\x04icao\x18\x01 \x01(\tR\x04icao\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\x12\x1a\n" +
+	"\bprovider\x18\x04 \x01(\tR\bprovider\x12\x1d\n" +
+	"\n" +
+	"fetch_time\x18\x05 \x01(\x03R\tfetchTime\x12)\n" +
+	"\x10observation_time\x18\x06 \x01(\x03R\x0fobservationTime\x12\x15\n" +
+	"\x03age\x18\a \x01(\x03H\x00R\x03age\x88\x01\x01\x12\x1b\n" +
+	"\tcache_hit\x18\b \x01(\bR\bcacheHit\x12\x14\n" +
+	"\x05stale\x18\t \x01(\bR\x05stale\x12\x1a\n" +
+	"\boverride\x18\n" +
+	" \x01(\bR\boverride\x12\x14\n" +
+	"\x05query\x18\v \x01(\tR\x05query\x122\n" +
+	"\bfallback\x18\f \x01(\v2\x16.fsd_universe.FallbackR\bfallbackB\x06\n" +
+	"\x04_age\"c\n" +
+	"\bFallback\x12\x1c\n" +
+	"\trequested\x18\x01 \x01(\tR\trequested\x12\x1f\n" +
+	"\vdistance_nm\x18\x02 \x01(\x01R\n" +
+	"distanceNm\x12\x18\n" +
+	"\abearing\x18\x03 \x01(\x01R\abearing\"8\n" +
+	"\fNearestQuery\x12\x12\n" +
+	"\x04icao\x18\x01 \x01(\tR\x04icao\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"A\n" +
+	"\n" +
+	"BatchReply\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.fsd_universe.QueryResultR\aresults\"\xa0\x02\n" +
+	"\x04Wind\x12!\n" +
+	"\tdirection\x18\x01 \x01(\x05H\x00R\tdirection\x88\x01\x01\x12\x1a\n" +
+	"\bvariable\x18\x02 \x01(\bR\bvariable\x12\x19\n" +
+	"\x05speed\x18\x03 \x01(\x05H\x01R\x05speed\x88\x01\x01\x12\x17\n" +
+	"\x04gust\x18\x04 \x01(\x05H\x02R\x04gust\x88\x01\x01\x12\x12\n" +
+	"\x04unit\x18\x05 \x01(\tR\x04unit\x12(\n" +
+	"\rvariable_from\x18\x06 \x01(\x05H\x03R\fvariableFrom\x88\x01\x01\x12$\n" +
+	"\vvariable_to\x18\a \x01(\x05H\x04R\n" +
+	"variableTo\x88\x01\x01B\f\n" +
+	"\n" +
+	"_directionB\b\n" +
+	"\x06_speedB\a\n" +
+	"\x05_gustB\x10\n" +
+	"\x0e_variable_fromB\x0e\n" +
+	"\f_variable_to\"@\n" +
+	"\n" +
+	"Visibility\x12\x16\n" +
+	"\x06meters\x18\x01 \x01(\x01R\x06meters\x12\x1a\n" +
+	"\bmodifier\x18\x02 \x01(\tR\bmodifier\"Y\n" +
+	"\x05Cloud\x12\x14\n" +
+	"\x05cover\x18\x01 \x01(\tR\x05cover\x12\x1b\n" +
+	"\x06height\x18\x02 \x01(\x05H\x00R\x06height\x88\x01\x01\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04typeB\t\n" +
+	"\a_height\"\xc2\x02\n" +
+	"\n" +
+	"Conditions\x12&\n" +
+	"\x04wind\x18\x01 \x01(\v2\x12.fsd_universe.WindR\x04wind\x128\n" +
+	"\n" +
+	"visibility\x18\x02 \x01(\v2\x18.fsd_universe.VisibilityR\n" +
+	"visibility\x12\x14\n" +
+	"\x05cavok\x18\x03 \x01(\bR\x05cavok\x12\x18\n" +
+	"\aweather\x18\x04 \x03(\tR\aweather\x12+\n" +
+	"\x06clouds\x18\x05 \x03(\v2\x13.fsd_universe.CloudR\x06clouds\x124\n" +
+	"\x13vertical_visibility\x18\x06 \x01(\x05H\x00R\x12verticalVisibility\x88\x01\x01\x12'\n" +
+	"\x0fflight_category\x18\a \x01(\tR\x0eflightCategoryB\x16\n" +
+	"\x14_vertical_visibility\"4\n" +
+	"\n" +
+	"TafAtQuery\x12\x12\n" +
+	"\x04icao\x18\x01 \x01(\tR\x04icao\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\x03R\x04time\"\xca\x01\n" +
+	"\n" +
+	"TafAtReply\x12\x12\n" +
+	"\x04icao\x18\x01 \x01(\tR\x04icao\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\x03R\x04time\x12\x10\n" +
+	"\x03raw\x18\x03 \x01(\tR\x03raw\x128\n" +
+	"\n" +
+	"prevailing\x18\x04 \x01(\v2\x18.fsd_universe.ConditionsR\n" +
+	"prevailing\x12.\n" +
+	"\x05worst\x18\x05 \x01(\v2\x18.fsd_universe.ConditionsR\x05worst\x12\x18\n" +
+	"\aperiods\x18\x06 \x03(\tR\aperiods\"\xdb\x01\n" +
+	"\tAreaQuery\x12\x14\n" +
+	"\x05south\x18\x01 \x01(\x01R\x05south\x12\x12\n" +
+	"\x04west\x18\x02 \x01(\x01R\x04west\x12\x14\n" +
+	"\x05north\x18\x03 \x01(\x01R\x05north\x12\x12\n" +
+	"\x04east\x18\x04 \x01(\x01R\x04east\x12\x1f\n" +
+	"\blatitude\x18\x05 \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x06 \x01(\x01H\x01R\tlongitude\x88\x01\x01\x12\x1b\n" +
+	"\tradius_nm\x18\a \x01(\x01R\bradiusNmB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitude\"\\\n" +
+	"\fHistoryQuery\x12\x12\n" +
+	"\x04icao\x18\x01 \x01(\tR\x04icao\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\x94\x01\n" +
+	"\x0eArchivedReport\x12\x12\n" +
+	"\x04icao\x18\x01 \x01(\tR\x04icao\x12\x1f\n" +
+	"\vreport_time\x18\x02 \x01(\x03R\n" +
+	"reportTime\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\x12\x1a\n" +
+	"\bprovider\x18\x04 \x01(\tR\bprovider\x12\x1d\n" +
+	"\n" +
+	"fetch_time\x18\x05 \x01(\x03R\tfetchTime\"F\n" +
+	"\fHistoryReply\x126\n" +
+	"\areports\x18\x01 \x03(\v2\x1c.fsd_universe.ArchivedReportR\areports\"\"\n" +
+	"\fStationQuery\x12\x12\n" +
+	"\x04icao\x18\x01 \x01(\tR\x04icao\"\xdc\x01\n" +
+	"\tRunwayEnd\x12\x14\n" +
+	"\x05ident\x18\x01 \x01(\tR\x05ident\x12\x1d\n" +
+	"\aheading\x18\x02 \x01(\x01H\x00R\aheading\x88\x01\x01\x12\x1f\n" +
+	"\blatitude\x18\x03 \x01(\x01H\x01R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x04 \x01(\x01H\x02R\tlongitude\x88\x01\x01\x12!\n" +
+	"\televation\x18\x05 \x01(\x05H\x03R\televation\x88\x01\x01B\n" +
+	"\n" +
+	"\b_headingB\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitudeB\f\n" +
+	"\n" +
+	"_elevation\"\xce\x01\n" +
+	"\x06Runway\x12\x1b\n" +
+	"\x06length\x18\x01 \x01(\x05H\x00R\x06length\x88\x01\x01\x12\x19\n" +
+	"\x05width\x18\x02 \x01(\x05H\x01R\x05width\x88\x01\x01\x12\x18\n" +
+	"\asurface\x18\x03 \x01(\tR\asurface\x12\x18\n" +
+	"\alighted\x18\x04 \x01(\bR\alighted\x12\x16\n" +
+	"\x06closed\x18\x05 \x01(\bR\x06closed\x12+\n" +
+	"\x04ends\x18\x06 \x03(\v2\x17.fsd_universe.RunwayEndR\x04endsB\t\n" +
+	"\a_lengthB\b\n" +
+	"\x06_width\"\xe9\x02\n" +
+	"\aStation\x12\x12\n" +
+	"\x04icao\x18\x01 \x01(\tR\x04icao\x12\x12\n" +
+	"\x04iata\x18\x02 \x01(\tR\x04iata\x12\x1d\n" +
+	"\n" +
+	"local_code\x18\x03 \x01(\tR\tlocalCode\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x1a\n" +
+	"\blatitude\x18\x06 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\a \x01(\x01R\tlongitude\x12!\n" +
+	"\televation\x18\b \x01(\x05H\x00R\televation\x88\x01\x01\x12\x18\n" +
+	"\acountry\x18\t \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\n" +
+	" \x01(\tR\x06region\x12\"\n" +
+	"\fmunicipality\x18\v \x01(\tR\fmunicipality\x12.\n" +
+	"\arunways\x18\f \x03(\v2\x14.fsd_universe.RunwayR\arunwaysB\f\n" +
+	"\n" +
+	"_elevation2\xb0\x06\n" +
 	"\x05Metar\x12>\n" +
 	"\bGetMetar\x12\x18.fsd_universe.MetarQuery\x1a\x18.fsd_universe.MetarReply\x128\n" +
-	"\x06GetTaf\x12\x16.fsd_universe.TafQuery\x1a\x16.fsd_universe.TafReplyB\x15Z\x13src/interfaces/grpcb\x06proto3"
+	"\x06GetTaf\x12\x16.fsd_universe.TafQuery\x1a\x16.fsd_universe.TafReply\x12>\n" +
+	"\bGetTafAt\x12\x18.fsd_universe.TafAtQuery\x1a\x18.fsd_universe.TafAtReply\x12C\n" +
+	"\rGetMetarBatch\x12\x18.fsd_universe.MetarQuery\x1a\x18.fsd_universe.BatchReply\x12?\n" +
+	"\vGetTafBatch\x12\x16.fsd_universe.TafQuery\x1a\x18.fsd_universe.BatchReply\x12G\n" +
+	"\x0fGetMetarNearest\x12\x1a.fsd_universe.NearestQuery\x1a\x18.fsd_universe.BatchReply\x12E\n" +
+	"\rGetTafNearest\x12\x1a.fsd_universe.NearestQuery\x1a\x18.fsd_universe.BatchReply\x12A\n" +
+	"\fGetMetarArea\x12\x17.fsd_universe.AreaQuery\x1a\x18.fsd_universe.BatchReply\x12?\n" +
+	"\n" +
+	"GetTafArea\x12\x17.fsd_universe.AreaQuery\x1a\x18.fsd_universe.BatchReply\x12I\n" +
+	"\x0fGetMetarHistory\x12\x1a.fsd_universe.HistoryQuery\x1a\x1a.fsd_universe.HistoryReply\x12G\n" +
+	"\rGetTafHistory\x12\x1a.fsd_universe.HistoryQuery\x1a\x1a.fsd_universe.HistoryReply\x12?\n" +
+	"\n" +
+	"GetStation\x12\x1a.fsd_universe.StationQuery\x1a\x15.fsd_universe.StationB\x15Z\x13src/interfaces/grpcb\x06proto3"
 
 var (
 	file_metar_proto_rawDescOnce sync.Once
@@ -231,23 +1745,71 @@ func file_metar_proto_rawDescGZIP() []byte {
 	return file_metar_proto_rawDescData
 }
 
-var file_metar_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_metar_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_metar_proto_goTypes = []any{
-	(*MetarQuery)(nil), // 0: fsd_universe.MetarQuery
-	(*MetarReply)(nil), // 1: fsd_universe.MetarReply
-	(*TafQuery)(nil),   // 2: fsd_universe.TafQuery
-	(*TafReply)(nil),   // 3: fsd_universe.TafReply
+	(*MetarQuery)(nil),     // 0: fsd_universe.MetarQuery
+	(*MetarReply)(nil),     // 1: fsd_universe.MetarReply
+	(*TafQuery)(nil),       // 2: fsd_universe.TafQuery
+	(*TafReply)(nil),       // 3: fsd_universe.TafReply
+	(*QueryResult)(nil),    // 4: fsd_universe.QueryResult
+	(*Fallback)(nil),       // 5: fsd_universe.Fallback
+	(*NearestQuery)(nil),   // 6: fsd_universe.NearestQuery
+	(*BatchReply)(nil),     // 7: fsd_universe.BatchReply
+	(*Wind)(nil),           // 8: fsd_universe.Wind
+	(*Visibility)(nil),     // 9: fsd_universe.Visibility
+	(*Cloud)(nil),          // 10: fsd_universe.Cloud
+	(*Conditions)(nil),     // 11: fsd_universe.Conditions
+	(*TafAtQuery)(nil),     // 12: fsd_universe.TafAtQuery
+	(*TafAtReply)(nil),     // 13: fsd_universe.TafAtReply
+	(*AreaQuery)(nil),      // 14: fsd_universe.AreaQuery
+	(*HistoryQuery)(nil),   // 15: fsd_universe.HistoryQuery
+	(*ArchivedReport)(nil), // 16: fsd_universe.ArchivedReport
+	(*HistoryReply)(nil),   // 17: fsd_universe.HistoryReply
+	(*StationQuery)(nil),   // 18: fsd_universe.StationQuery
+	(*RunwayEnd)(nil),      // 19: fsd_universe.RunwayEnd
+	(*Runway)(nil),         // 20: fsd_universe.Runway
+	(*Station)(nil),        // 21: fsd_universe.Station
 }
 var file_metar_proto_depIdxs = []int32{
-	0, // 0: fsd_universe.Metar.GetMetar:input_type -> fsd_universe.MetarQuery
-	2, // 1: fsd_universe.Metar.GetTaf:input_type -> fsd_universe.TafQuery
-	1, // 2: fsd_universe.Metar.GetMetar:output_type -> fsd_universe.MetarReply
-	3, // 3: fsd_universe.Metar.GetTaf:output_type -> fsd_universe.TafReply
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	5,  // 0: fsd_universe.QueryResult.fallback:type_name -> fsd_universe.Fallback
+	4,  // 1: fsd_universe.BatchReply.results:type_name -> fsd_universe.QueryResult
+	8,  // 2: fsd_universe.Conditions.wind:type_name -> fsd_universe.Wind
+	9,  // 3: fsd_universe.Conditions.visibility:type_name -> fsd_universe.Visibility
+	10, // 4: fsd_universe.Conditions.clouds:type_name -> fsd_universe.Cloud
+	11, // 5: fsd_universe.TafAtReply.prevailing:type_name -> fsd_universe.Conditions
+	11, // 6: fsd_universe.TafAtReply.worst:type_name -> fsd_universe.Conditions
+	16, // 7: fsd_universe.HistoryReply.reports:type_name -> fsd_universe.ArchivedReport
+	19, // 8: fsd_universe.Runway.ends:type_name -> fsd_universe.RunwayEnd
+	20, // 9: fsd_universe.Station.runways:type_name -> fsd_universe.Runway
+	0,  // 10: fsd_universe.Metar.GetMetar:input_type -> fsd_universe.MetarQuery
+	2,  // 11: fsd_universe.Metar.GetTaf:input_type -> fsd_universe.TafQuery
+	12, // 12: fsd_universe.Metar.GetTafAt:input_type -> fsd_universe.TafAtQuery
+	0,  // 13: fsd_universe.Metar.GetMetarBatch:input_type -> fsd_universe.MetarQuery
+	2,  // 14: fsd_universe.Metar.GetTafBatch:input_type -> fsd_universe.TafQuery
+	6,  // 15: fsd_universe.Metar.GetMetarNearest:input_type -> fsd_universe.NearestQuery
+	6,  // 16: fsd_universe.Metar.GetTafNearest:input_type -> fsd_universe.NearestQuery
+	14, // 17: fsd_universe.Metar.GetMetarArea:input_type -> fsd_universe.AreaQuery
+	14, // 18: fsd_universe.Metar.GetTafArea:input_type -> fsd_universe.AreaQuery
+	15, // 19: fsd_universe.Metar.GetMetarHistory:input_type -> fsd_universe.HistoryQuery
+	15, // 20: fsd_universe.Metar.GetTafHistory:input_type -> fsd_universe.HistoryQuery
+	18, // 21: fsd_universe.Metar.GetStation:input_type -> fsd_universe.StationQuery
+	1,  // 22: fsd_universe.Metar.GetMetar:output_type -> fsd_universe.MetarReply
+	3,  // 23: fsd_universe.Metar.GetTaf:output_type -> fsd_universe.TafReply
+	13, // 24: fsd_universe.Metar.GetTafAt:output_type -> fsd_universe.TafAtReply
+	7,  // 25: fsd_universe.Metar.GetMetarBatch:output_type -> fsd_universe.BatchReply
+	7,  // 26: fsd_universe.Metar.GetTafBatch:output_type -> fsd_universe.BatchReply
+	7,  // 27: fsd_universe.Metar.GetMetarNearest:output_type -> fsd_universe.BatchReply
+	7,  // 28: fsd_universe.Metar.GetTafNearest:output_type -> fsd_universe.BatchReply
+	7,  // 29: fsd_universe.Metar.GetMetarArea:output_type -> fsd_universe.BatchReply
+	7,  // 30: fsd_universe.Metar.GetTafArea:output_type -> fsd_universe.BatchReply
+	17, // 31: fsd_universe.Metar.GetMetarHistory:output_type -> fsd_universe.HistoryReply
+	17, // 32: fsd_universe.Metar.GetTafHistory:output_type -> fsd_universe.HistoryReply
+	21, // 33: fsd_universe.Metar.GetStation:output_type -> fsd_universe.Station
+	22, // [22:34] is the sub-list for method output_type
+	10, // [10:22] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_metar_proto_init() }
@@ -255,13 +1817,21 @@ func file_metar_proto_init() {
 	if File_metar_proto != nil {
 		return
 	}
+	file_metar_proto_msgTypes[4].OneofWrappers = []any{}
+	file_metar_proto_msgTypes[8].OneofWrappers = []any{}
+	file_metar_proto_msgTypes[10].OneofWrappers = []any{}
+	file_metar_proto_msgTypes[11].OneofWrappers = []any{}
+	file_metar_proto_msgTypes[14].OneofWrappers = []any{}
+	file_metar_proto_msgTypes[19].OneofWrappers = []any{}
+	file_metar_proto_msgTypes[20].OneofWrappers = []any{}
+	file_metar_proto_msgTypes[21].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_metar_proto_rawDesc), len(file_metar_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,8 +22,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Metar_GetMetar_FullMethodName = "/fsd_universe.Metar/GetMetar"
-	Metar_GetTaf_FullMethodName   = "/fsd_universe.Metar/GetTaf"
+	Metar_GetMetar_FullMethodName        = "/fsd_universe.Metar/GetMetar"
+	Metar_GetTaf_FullMethodName          = "/fsd_universe.Metar/GetTaf"
+	Metar_GetTafAt_FullMethodName        = "/fsd_universe.Metar/GetTafAt"
+	Metar_GetMetarBatch_FullMethodName   = "/fsd_universe.Metar/GetMetarBatch"
+	Metar_GetTafBatch_FullMethodName     = "/fsd_universe.Metar/GetTafBatch"
+	Metar_GetMetarNearest_FullMethodName = "/fsd_universe.Metar/GetMetarNearest"
+	Metar_GetTafNearest_FullMethodName   = "/fsd_universe.Metar/GetTafNearest"
+	Metar_GetMetarArea_FullMethodName    = "/fsd_universe.Metar/GetMetarArea"
+	Metar_GetTafArea_FullMethodName      = "/fsd_universe.Metar/GetTafArea"
+	Metar_GetMetarHistory_FullMethodName = "/fsd_universe.Metar/GetMetarHistory"
+	Metar_GetTafHistory_FullMethodName   = "/fsd_universe.Metar/GetTafHistory"
+	Metar_GetStation_FullMethodName      = "/fsd_universe.Metar/GetStation"
 )
 
 // MetarClient is the client API for Metar service.
@@ -32,6 +42,16 @@ const (
 type MetarClient interface {
 	GetMetar(ctx context.Context, in *MetarQuery, opts ...grpc.CallOption) (*MetarReply, error)
 	GetTaf(ctx context.Context, in *TafQuery, opts ...grpc.CallOption) (*TafReply, error)
+	GetTafAt(ctx context.Context, in *TafAtQuery, opts ...grpc.CallOption) (*TafAtReply, error)
+	GetMetarBatch(ctx context.Context, in *MetarQuery, opts ...grpc.CallOption) (*BatchReply, error)
+	GetTafBatch(ctx context.Context, in *TafQuery, opts ...grpc.CallOption) (*BatchReply, error)
+	GetMetarNearest(ctx context.Context, in *NearestQuery, opts ...grpc.CallOption) (*BatchReply, error)
+	GetTafNearest(ctx context.Context, in *NearestQuery, opts ...grpc.CallOption) (*BatchReply, error)
+	GetMetarArea(ctx context.Context, in *AreaQuery, opts ...grpc.CallOption) (*BatchReply, error)
+	GetTafArea(ctx context.Context, in *AreaQuery, opts ...grpc.CallOption) (*BatchReply, error)
+	GetMetarHistory(ctx context.Context, in *HistoryQuery, opts ...grpc.CallOption) (*HistoryReply, error)
+	GetTafHistory(ctx context.Context, in *HistoryQuery, opts ...grpc.CallOption) (*HistoryReply, error)
+	GetStation(ctx context.Context, in *StationQuery, opts ...grpc.CallOption) (*Station, error)
 }
 
 type metarClient struct {
@@ -62,12 +82,122 @@ func (c *metarClient) GetTaf(ctx context.Context, in *TafQuery, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *metarClient) GetTafAt(ctx context.Context, in *TafAtQuery, opts ...grpc.CallOption) (*TafAtReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TafAtReply)
+	err := c.cc.Invoke(ctx, Metar_GetTafAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metarClient) GetMetarBatch(ctx context.Context, in *MetarQuery, opts ...grpc.CallOption) (*BatchReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchReply)
+	err := c.cc.Invoke(ctx, Metar_GetMetarBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metarClient) GetTafBatch(ctx context.Context, in *TafQuery, opts ...grpc.CallOption) (*BatchReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchReply)
+	err := c.cc.Invoke(ctx, Metar_GetTafBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metarClient) GetMetarNearest(ctx context.Context, in *NearestQuery, opts ...grpc.CallOption) (*BatchReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchReply)
+	err := c.cc.Invoke(ctx, Metar_GetMetarNearest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metarClient) GetTafNearest(ctx context.Context, in *NearestQuery, opts ...grpc.CallOption) (*BatchReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchReply)
+	err := c.cc.Invoke(ctx, Metar_GetTafNearest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metarClient) GetMetarArea(ctx context.Context, in *AreaQuery, opts ...grpc.CallOption) (*BatchReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchReply)
+	err := c.cc.Invoke(ctx, Metar_GetMetarArea_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metarClient) GetTafArea(ctx context.Context, in *AreaQuery, opts ...grpc.CallOption) (*BatchReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchReply)
+	err := c.cc.Invoke(ctx, Metar_GetTafArea_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metarClient) GetMetarHistory(ctx context.Context, in *HistoryQuery, opts ...grpc.CallOption) (*HistoryReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HistoryReply)
+	err := c.cc.Invoke(ctx, Metar_GetMetarHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metarClient) GetTafHistory(ctx context.Context, in *HistoryQuery, opts ...grpc.CallOption) (*HistoryReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HistoryReply)
+	err := c.cc.Invoke(ctx, Metar_GetTafHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *metarClient) GetStation(ctx context.Context, in *StationQuery, opts ...grpc.CallOption) (*Station, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Station)
+	err := c.cc.Invoke(ctx, Metar_GetStation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MetarServer is the server API for Metar service.
 // All implementations must embed UnimplementedMetarServer
 // for forward compatibility.
 type MetarServer interface {
 	GetMetar(context.Context, *MetarQuery) (*MetarReply, error)
 	GetTaf(context.Context, *TafQuery) (*TafReply, error)
+	GetTafAt(context.Context, *TafAtQuery) (*TafAtReply, error)
+	GetMetarBatch(context.Context, *MetarQuery) (*BatchReply, error)
+	GetTafBatch(context.Context, *TafQuery) (*BatchReply, error)
+	GetMetarNearest(context.Context, *NearestQuery) (*BatchReply, error)
+	GetTafNearest(context.Context, *NearestQuery) (*BatchReply, error)
+	GetMetarArea(context.Context, *AreaQuery) (*BatchReply, error)
+	GetTafArea(context.Context, *AreaQuery) (*BatchReply, error)
+	GetMetarHistory(context.Context, *HistoryQuery) (*HistoryReply, error)
+	GetTafHistory(context.Context, *HistoryQuery) (*HistoryReply, error)
+	GetStation(context.Context, *StationQuery) (*Station, error)
 	mustEmbedUnimplementedMetarServer()
 }
 
@@ -84,6 +214,36 @@ func (UnimplementedMetarServer) GetMetar(context.Context, *MetarQuery) (*MetarRe
 func (UnimplementedMetarServer) GetTaf(context.Context, *TafQuery) (*TafReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTaf not implemented")
 }
+func (UnimplementedMetarServer) GetTafAt(context.Context, *TafAtQuery) (*TafAtReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTafAt not implemented")
+}
+func (UnimplementedMetarServer) GetMetarBatch(context.Context, *MetarQuery) (*BatchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetarBatch not implemented")
+}
+func (UnimplementedMetarServer) GetTafBatch(context.Context, *TafQuery) (*BatchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTafBatch not implemented")
+}
+func (UnimplementedMetarServer) GetMetarNearest(context.Context, *NearestQuery) (*BatchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetarNearest not implemented")
+}
+func (UnimplementedMetarServer) GetTafNearest(context.Context, *NearestQuery) (*BatchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTafNearest not implemented")
+}
+func (UnimplementedMetarServer) GetMetarArea(context.Context, *AreaQuery) (*BatchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetarArea not implemented")
+}
+func (UnimplementedMetarServer) GetTafArea(context.Context, *AreaQuery) (*BatchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTafArea not implemented")
+}
+func (UnimplementedMetarServer) GetMetarHistory(context.Context, *HistoryQuery) (*HistoryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetarHistory not implemented")
+}
+func (UnimplementedMetarServer) GetTafHistory(context.Context, *HistoryQuery) (*HistoryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTafHistory not implemented")
+}
+func (UnimplementedMetarServer) GetStation(context.Context, *StationQuery) (*Station, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStation not implemented")
+}
 func (UnimplementedMetarServer) mustEmbedUnimplementedMetarServer() {}
 func (UnimplementedMetarServer) testEmbeddedByValue()               {}
 
@@ -141,6 +301,186 @@ func _Metar_GetTaf_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Metar_GetTafAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TafAtQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetarServer).GetTafAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Metar_GetTafAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetarServer).GetTafAt(ctx, req.(*TafAtQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metar_GetMetarBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetarQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetarServer).GetMetarBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Metar_GetMetarBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetarServer).GetMetarBatch(ctx, req.(*MetarQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metar_GetTafBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TafQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetarServer).GetTafBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Metar_GetTafBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetarServer).GetTafBatch(ctx, req.(*TafQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metar_GetMetarNearest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NearestQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetarServer).GetMetarNearest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Metar_GetMetarNearest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetarServer).GetMetarNearest(ctx, req.(*NearestQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metar_GetTafNearest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NearestQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetarServer).GetTafNearest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Metar_GetTafNearest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetarServer).GetTafNearest(ctx, req.(*NearestQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metar_GetMetarArea_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AreaQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetarServer).GetMetarArea(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Metar_GetMetarArea_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetarServer).GetMetarArea(ctx, req.(*AreaQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metar_GetTafArea_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AreaQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetarServer).GetTafArea(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Metar_GetTafArea_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetarServer).GetTafArea(ctx, req.(*AreaQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metar_GetMetarHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetarServer).GetMetarHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Metar_GetMetarHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetarServer).GetMetarHistory(ctx, req.(*HistoryQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metar_GetTafHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetarServer).GetTafHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Metar_GetTafHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetarServer).GetTafHistory(ctx, req.(*HistoryQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Metar_GetStation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StationQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetarServer).GetStation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Metar_GetStation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetarServer).GetStation(ctx, req.(*StationQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Metar_ServiceDesc is the grpc.ServiceDesc for Metar service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -156,6 +496,46 @@ var Metar_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTaf",
 			Handler:    _Metar_GetTaf_Handler,
 		},
+		{
+			MethodName: "GetTafAt",
+			Handler:    _Metar_GetTafAt_Handler,
+		},
+		{
+			MethodName: "GetMetarBatch",
+			Handler:    _Metar_GetMetarBatch_Handler,
+		},
+		{
+			MethodName: "GetTafBatch",
+			Handler:    _Metar_GetTafBatch_Handler,
+		},
+		{
+			MethodName: "GetMetarNearest",
+			Handler:    _Metar_GetMetarNearest_Handler,
+		},
+		{
+			MethodName: "GetTafNearest",
+			Handler:    _Metar_GetTafNearest_Handler,
+		},
+		{
+			MethodName: "GetMetarArea",
+			Handler:    _Metar_GetMetarArea_Handler,
+		},
+		{
+			MethodName: "GetTafArea",
+			Handler:    _Metar_GetTafArea_Handler,
+		},
+		{
+			MethodName: "GetMetarHistory",
+			Handler:    _Metar_GetMetarHistory_Handler,
+		},
+		{
+			MethodName: "GetTafHistory",
+			Handler:    _Metar_GetTafHistory_Handler,
+		},
+		{
+			MethodName: "GetStation",
+			Handler:    _Metar_GetStation_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "metar.proto",
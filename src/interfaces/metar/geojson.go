@@ -0,0 +1,59 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import "time"
+
+const (
+	GeoJSONFeatureCollection = "FeatureCollection"
+	GeoJSONFeature           = "Feature"
+	GeoJSONPoint             = "Point"
+)
+
+// FeatureCollection GeoJSON要素集合, 每个站点为一个点要素
+type FeatureCollection struct {
+	Type     string     `json:"type"`
+	Features []*Feature `json:"features"`
+}
+
+type Feature struct {
+	Type       string             `json:"type"`
+	ID         string             `json:"id"`
+	Geometry   *Point             `json:"geometry"` // 站点不在机场数据库中时为null
+	Properties *StationConditions `json:"properties"`
+}
+
+type Point struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"` // 经度, 纬度
+}
+
+// StationConditions 站点当前天气, 字段均为单层以便地图库直接用于样式表达式
+type StationConditions struct {
+	ICAO               string     `json:"icao"`
+	Name               string     `json:"name"`
+	Raw                string     `json:"raw"`
+	FlightCategory     string     `json:"flight_category"`
+	WindDirection      *int       `json:"wind_direction"` // 风向(度), 静风或不定风向时为空
+	WindVariable       bool       `json:"wind_variable"`
+	WindSpeed          *int       `json:"wind_speed"`
+	WindGust           *int       `json:"wind_gust"`
+	WindUnit           string     `json:"wind_unit"`
+	Visibility         *float64   `json:"visibility"` // 能见度(米)
+	Ceiling            *int       `json:"ceiling"`    // 云幕高(英尺), 没有云幕时为空
+	ObservationTime    *time.Time `json:"observation_time"`
+	Age                *int64     `json:"age"` // 距观测时间的秒数
+	Stale              bool       `json:"stale"`
+	Override           bool       `json:"override"`
+	FallbackRequested  string     `json:"fallback_requested"`   // 使用附近站点报文时请求的站点
+	FallbackDistanceNM *float64   `json:"fallback_distance_nm"` // 与请求站点的距离(海里)
+}
+
+func NewFeatureCollection() *FeatureCollection {
+	return &FeatureCollection{
+		Type:     GeoJSONFeatureCollection,
+		Features: make([]*Feature, 0),
+	}
+}
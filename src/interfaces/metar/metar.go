@@ -6,19 +6,101 @@ package metar
 
 import (
 	"errors"
+	"time"
 )
 
 var (
 	ErrICAOInvalid    = errors.New("invalid ICAO value")
 	ErrTargetNotFound = errors.New("target not found")
+	ErrUpstreamFailed = errors.New("upstream request failed")
+	// ErrStationUnknown 站点或区域不在机场数据库中, 无法查找附近站点
+	ErrStationUnknown = errors.New("no station data")
+
+	ErrLanguageNotSupported = errors.New("language not supported")
+	ErrScenarioInvalid      = errors.New("invalid scenario")
+)
+
+const (
+	ReportTypeMetar = "metar"
+	ReportTypeTaf   = "taf"
 )
 
+const (
+	QueryStatusOk            = "ok"
+	QueryStatusNotFound      = "not_found"
+	QueryStatusInvalidICAO   = "invalid_icao"
+	QueryStatusUpstreamError = "upstream_error"
+	QueryStatusParseError    = "parse_error"
+)
+
+// QueryResult 单个机场的查询结果与报文元数据
+type QueryResult struct {
+	ICAO            string     `json:"icao"`
+	Query           string     `json:"query"`  // 请求中的机场代码, 可能为IATA代码或FAA LID
+	Status          string     `json:"status"` // ok not_found invalid_icao upstream_error
+	Data            string     `json:"data"`
+	Provider        string     `json:"provider"`         // 提供报文的数据源名称
+	FetchTime       *time.Time `json:"fetch_time"`       // 从数据源获取报文的时间
+	ObservationTime *time.Time `json:"observation_time"` // 报文中的观测时间或发布时间
+	Age             *int64     `json:"age"`              // 距观测时间的秒数
+	CacheHit        bool       `json:"cache_hit"`        // 是否命中缓存
+	Stale           bool       `json:"stale"`            // 数据源全部失败时返回的过期报文
+	Override        bool       `json:"override"`         // 管理员指定的报文
+	Fallback        *Fallback  `json:"fallback"`         // 请求的站点没有报文时使用的附近站点信息, 此时ICAO为附近站点
+}
+
+// BatchResult 批量解析或翻译时单个机场的结果, 查询或解析失败的站点Data为空
+type BatchResult[T any] struct {
+	ICAO     string    `json:"icao"`
+	Query    string    `json:"query"`
+	Status   string    `json:"status"` // ok not_found invalid_icao upstream_error parse_error
+	Fallback *Fallback `json:"fallback"`
+	Data     T         `json:"data"`
+}
+
+// Fallback 附近站点相对请求站点的位置
+type Fallback struct {
+	Requested  string  `json:"requested"`   // 请求的站点ICAO代码
+	DistanceNM float64 `json:"distance_nm"` // 距离(海里)
+	Bearing    float64 `json:"bearing"`     // 相对请求站点的真方位(度)
+}
+
+// QueryStatus 将查询错误转换为查询结果状态
+func QueryStatus(err error) string {
+	switch {
+	case err == nil:
+		return QueryStatusOk
+	case errors.Is(err, ErrICAOInvalid):
+		return QueryStatusInvalidICAO
+	case errors.Is(err, ErrTargetNotFound):
+		return QueryStatusNotFound
+	default:
+		return QueryStatusUpstreamError
+	}
+}
+
 type ManagerInterface interface {
 	Query(icao string) (string, error)
 	BatchQuery(icaos []string) []string
+	QueryResult(icao string) (*QueryResult, error)
+	BatchQueryResult(icaos []string) []*QueryResult
+	// Nearest 按距离从近到远返回最多count个附近有报文的站点
+	Nearest(icao string, count int) ([]*QueryResult, error)
+	Purge(icao string)
+	PurgeAll()
+	RefreshProvider(provider string) int
+}
+
+// FallbackInterface 查找附近有报文的站点
+type FallbackInterface interface {
+	// Auto 请求的站点没有报文时是否自动使用最近的站点
+	Auto() bool
+	// Nearest 使用query依次查询附近的站点, 返回最多count个有报文的站点
+	Nearest(icao string, count int, query func(icao string) (*QueryResult, error)) ([]*QueryResult, error)
 }
 
 type ProviderInterface interface {
+	Name() string
 	Get(icao string) (string, error)
 }
 
@@ -28,4 +110,104 @@ type DecoderInterface interface {
 
 type ParserInterface[T any] interface {
 	Parse(data string) (T, error)
+	// ParseAt 以reference作为参考时间解析报文, 回放归档报文时使用模拟时间推断报文中省略的年月
+	ParseAt(data string, reference time.Time) (T, error)
+}
+
+// EncoderInterface 将结构化的报文编码为报文字符串, 与ParserInterface互逆
+type EncoderInterface[T any] interface {
+	Encode(data T) (string, error)
+}
+
+// RefresherInterface 热门站点提前刷新调度器
+type RefresherInterface interface {
+	Hit(icao string)
+	Schedule(icao string, expireAt time.Time)
+}
+
+// LockerInterface 跨实例的互斥锁, 共享缓存实现该接口以保证同一站点在集群内只请求一次数据源
+type LockerInterface interface {
+	TryLock(key string, ttl time.Duration) (bool, error)
+	Unlock(key string)
+}
+
+// ClearerInterface 按命名空间清除缓存, 持久化与共享缓存实现该接口以清除本实例未写入过的站点
+type ClearerInterface interface {
+	Clear()
+}
+
+// CachePolicyInterface 报文缓存过期策略
+type CachePolicyInterface interface {
+	// TTL 返回报文的缓存时间, routine表示定时报, 特选报与更正报为false
+	TTL(icao string, observationTime time.Time, routine bool) time.Duration
+	NegativeTTL(icao string) time.Duration
+	StaleMaxAge(icao string) time.Duration
+}
+
+// ReportTimeInterface 获取报文中的观测时间或发布时间
+type ReportTimeInterface interface {
+	ReportTime(data string) (time.Time, error)
+}
+
+type ClassifierInterface interface {
+	Classify(conditions *Conditions) string
+}
+
+type TranslatorInterface interface {
+	TranslateMetar(data *Metar, lang string) (string, error)
+	TranslateTaf(data *Taf, lang string) (string, error)
+}
+
+// ArchivedReport 归档的历史报文
+type ArchivedReport struct {
+	ICAO       string    `json:"icao"`
+	ReportTime time.Time `json:"report_time"` // 报文中的观测时间或发布时间
+	Data       string    `json:"data"`
+	Provider   string    `json:"provider"`
+	FetchTime  time.Time `json:"fetch_time"`
+}
+
+const (
+	// DefaultHistoryRange 未指定起始时间时查询的历史范围
+	DefaultHistoryRange = 24 * time.Hour
+	DefaultHistoryLimit = 100
+	MaxHistoryLimit     = 1000
+)
+
+const (
+	DefaultNearestCount = 1
+	MaxNearestCount     = 10
+)
+
+// ArchiveInterface 历史报文归档, 同一站点同一报文时间的报文只保存一次
+type ArchiveInterface interface {
+	Save(report *ArchivedReport) error
+	History(icao string, from time.Time, to time.Time, limit int) ([]*ArchivedReport, error)
+	// At 返回报文时间不晚于at的最新报文, 不存在时返回ErrTargetNotFound
+	At(icao string, at time.Time) (*ArchivedReport, error)
+}
+
+// ReplayInterface 历史回放, 按模拟时间从归档中返回当时的报文
+type ReplayInterface interface {
+	// Now 返回全局模拟时钟的当前时间, 未启用全局回放时返回false
+	Now() (time.Time, bool)
+	MetarManager(at time.Time) ManagerInterface
+	TafManager(at time.Time) ManagerInterface
+}
+
+// ReplayClock 全局模拟时钟的状态, 未启用全局回放时时间为空
+type ReplayClock struct {
+	Enable bool       `json:"enable"`
+	Start  *time.Time `json:"start"` // 模拟时钟开始计时时的模拟时间
+	Speed  float64    `json:"speed"`
+	Now    *time.Time `json:"now"` // 当前模拟时间
+}
+
+// ReplayClockInterface 运行时调整全局模拟时钟, 只作用于本实例
+type ReplayClockInterface interface {
+	Clock() *ReplayClock
+	// SetClock 启用全局回放, 模拟时钟从start开始按speed倍率计时
+	SetClock(start time.Time, speed float64)
+	// ClearClock 停用全局回放, 恢复实时查询
+	ClearClock()
 }
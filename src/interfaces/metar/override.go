@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import "time"
+
+const (
+	OverrideSourceManual   = "manual"
+	OverrideSourceScenario = "scenario"
+)
+
+// Override 管理员指定的报文, 过期前优先于数据源返回
+type Override struct {
+	ICAO     string    `json:"icao"`
+	Data     string    `json:"data"`
+	Source   string    `json:"source"` // manual 或 scenario
+	ExpireAt time.Time `json:"expire_at"`
+}
+
+// OverrideInterface 管理员指定报文的存储, 过期的报文不再返回
+type OverrideInterface interface {
+	Get(icao string) (*Override, bool)
+	Set(override *Override)
+	Delete(icao string)
+	// DeleteSource 删除指定来源的所有报文
+	DeleteSource(source string)
+	List() []*Override
+}
+
+// Scenario 天气场景脚本, 按时间顺序替换指定站点的报文
+type Scenario struct {
+	Name     string          `yaml:"name" json:"name"`
+	Duration string          `yaml:"duration" json:"duration"` // 场景持续时间, 为空时持续到最后一步后1小时
+	Steps    []*ScenarioStep `yaml:"steps" json:"steps"`
+}
+
+// ScenarioStep 场景中的一步, 在场景开始后at时刻生效
+type ScenarioStep struct {
+	At     string `yaml:"at" json:"at"`         // 距场景开始的时间, 如20m
+	Type   string `yaml:"type" json:"type"`     // metar 或 taf, 默认为metar
+	ICAO   string `yaml:"icao" json:"icao"`     // 机场ICAO代码
+	Data   string `yaml:"data" json:"data"`     // 报文
+	Expire string `yaml:"expire" json:"expire"` // 有效时间, 为空时持续到同一站点的下一步或场景结束
+}
+
+// ScenarioStatus 正在运行的场景状态
+type ScenarioStatus struct {
+	Name      string    `json:"name"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Steps     int       `json:"steps"`
+	Applied   int       `json:"applied"` // 已生效的步数
+}
+
+type ScenarioRunnerInterface interface {
+	Load(scenario *Scenario) error
+	Stop()
+	// Status 返回正在运行的场景状态, 没有场景时返回空
+	Status() *ScenarioStatus
+}
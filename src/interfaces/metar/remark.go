@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import "time"
+
+const (
+	WeatherEventBegin = "B"
+	WeatherEventEnd   = "E"
+)
+
+// PressureTendency 3小时气压倾向(5appp)
+type PressureTendency struct {
+	Character int     `json:"character"` // 气压倾向特征代码0-8
+	Change    float64 `json:"change"`    // 气压变化量(百帕)
+}
+
+// PeakWind 峰值风(PK WND)
+type PeakWind struct {
+	Direction int       `json:"direction"`
+	Speed     int       `json:"speed"`
+	Time      time.Time `json:"time"`
+}
+
+// WindShift 风向突变(WSHFT)
+type WindShift struct {
+	Time           time.Time `json:"time"`
+	FrontalPassage bool      `json:"frontal_passage"` // 锋面过境(FROPA)
+}
+
+// WeatherEvent 天气现象开始或结束时间, 如 RAB15E30
+type WeatherEvent struct {
+	Phenomenon string    `json:"phenomenon"`
+	Event      string    `json:"event"` // B开始 E结束
+	Time       time.Time `json:"time"`
+}
+
+// Remarks 北美METAR备注组(RMK)
+type Remarks struct {
+	Raw                string            `json:"raw"`
+	StationType        string            `json:"station_type"`       // AO1 或 AO2
+	SeaLevelPressure   *float64          `json:"sea_level_pressure"` // 海平面气压(百帕)
+	PreciseTemperature *float64          `json:"precise_temperature"`
+	PreciseDewPoint    *float64          `json:"precise_dew_point"`
+	MaxTemperature6h   *float64          `json:"max_temperature_6h"`
+	MinTemperature6h   *float64          `json:"min_temperature_6h"`
+	MaxTemperature24h  *float64          `json:"max_temperature_24h"`
+	MinTemperature24h  *float64          `json:"min_temperature_24h"`
+	PressureTendency   *PressureTendency `json:"pressure_tendency"`
+	PeakWind           *PeakWind         `json:"peak_wind"`
+	WindShift          *WindShift        `json:"wind_shift"`
+	WeatherEvents      []*WeatherEvent   `json:"weather_events"`
+	SensorOutages      []string          `json:"sensor_outages"` // 传感器不可用, 如 TSNO PWINO SLPNO
+	Maintenance        bool              `json:"maintenance"`    // 需要维护($)
+	Unparsed           []string          `json:"unparsed"`
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrReportInvalid  = errors.New("invalid report")
+	ErrTimeOutOfRange = errors.New("time out of report validity")
+)
+
+const (
+	WindUnitKnot           = "KT"
+	WindUnitMeterPerSecond = "MPS"
+	WindUnitKilometerHour  = "KMH"
+
+	VisibilityUnitMeter       = "m"
+	VisibilityUnitStatuteMile = "SM"
+
+	PressureUnitHectopascal = "hPa"
+	PressureUnitInchHg      = "inHg"
+
+	// MeterPerStatuteMile 每法定英里的米数
+	MeterPerStatuteMile = 1609.344
+)
+
+// Wind 风组
+type Wind struct {
+	Direction    *int   `json:"direction"`     // 风向(度), 静风或不定风向时为空
+	Variable     bool   `json:"variable"`      // 是否为不定风向(VRB)
+	Speed        *int   `json:"speed"`         // 风速
+	Gust         *int   `json:"gust"`          // 阵风
+	Unit         string `json:"unit"`          // 风速单位
+	VariableFrom *int   `json:"variable_from"` // 风向变化范围起点
+	VariableTo   *int   `json:"variable_to"`   // 风向变化范围终点
+}
+
+// Knots 将风速换算为节
+func (w *Wind) Knots(value int) float64 {
+	switch w.Unit {
+	case WindUnitMeterPerSecond:
+		return float64(value) * 1.943844
+	case WindUnitKilometerHour:
+		return float64(value) / 1.852
+	default:
+		return float64(value)
+	}
+}
+
+// DirectionalVisibility 方向最低能见度
+type DirectionalVisibility struct {
+	Distance  int    `json:"distance"`  // 距离(米)
+	Direction string `json:"direction"` // 方向
+}
+
+// Visibility 主导能见度
+type Visibility struct {
+	Value    float64                `json:"value"`     // 原始单位下的数值
+	Unit     string                 `json:"unit"`      // 单位
+	Modifier string                 `json:"modifier"`  // M表示小于, P表示大于
+	Meters   float64                `json:"meters"`    // 换算为米后的数值
+	NoDirect bool                   `json:"no_direct"` // 无方向变化(NDV)
+	Minimum  *DirectionalVisibility `json:"minimum"`   // 方向最低能见度
+}
+
+// Weather 天气现象
+type Weather struct {
+	Raw        string   `json:"raw"`
+	Intensity  string   `json:"intensity"`  // 强度或邻近, "-" "+" "VC"
+	Descriptor string   `json:"descriptor"` // 特征, 如 SH TS FZ
+	Phenomena  []string `json:"phenomena"`  // 现象, 如 RA SN BR
+}
+
+// Cloud 云组
+type Cloud struct {
+	Cover  string `json:"cover"`  // 云量, FEW SCT BKN OVC 或 SKC CLR NSC NCD
+	Height *int   `json:"height"` // 云底高(英尺)
+	Type   string `json:"type"`   // 云状, CB 或 TCU
+}
+
+// Pressure 修正海压
+type Pressure struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// Hectopascals 将气压换算为百帕
+func (p *Pressure) Hectopascals() float64 {
+	if p.Unit == PressureUnitInchHg {
+		return p.Value * 33.8639
+	}
+	return p.Value
+}
+
+// Conditions METAR与TAF共用的天气状况
+type Conditions struct {
+	Wind               *Wind       `json:"wind"`
+	Visibility         *Visibility `json:"visibility"`
+	Cavok              bool        `json:"cavok"`
+	Weather            []*Weather  `json:"weather"`
+	NoSignificantWx    bool        `json:"nsw"` // 无重要天气(NSW)
+	Clouds             []*Cloud    `json:"clouds"`
+	VerticalVisibility *int        `json:"vertical_visibility"` // 垂直能见度(英尺)
+	FlightCategory     string      `json:"flight_category"`     // 飞行类别, 由服务层根据配置计算
+}
+
+// Metar 解析后的METAR报文
+type Metar struct {
+	Raw        string    `json:"raw"`
+	Type       string    `json:"type"` // METAR 或 SPECI
+	Station    string    `json:"station"`
+	Time       time.Time `json:"time"`
+	Auto       bool      `json:"auto"`
+	Correction bool      `json:"correction"`
+	Nil        bool      `json:"nil"`
+	Conditions
+	RunwayVisualRanges []*RunwayVisualRange `json:"runway_visual_ranges"`
+	RunwayStates       []*RunwayState       `json:"runway_states"`
+	Temperature        *int                 `json:"temperature"`
+	DewPoint           *int                 `json:"dew_point"`
+	Pressure           *Pressure            `json:"pressure"`
+	RecentWeather      []*Weather           `json:"recent_weather"`
+	Trends             []*ForecastPeriod    `json:"trends"` // 趋势预报, NOSIG BECMG TEMPO
+	Remarks            *Remarks             `json:"remarks"`
+	Unparsed           []string             `json:"unparsed"` // 无法识别的报文组
+}
+
+// Ceiling 返回云幕高(英尺), 即最低的BKN/OVC云层或垂直能见度, 没有云幕时返回空
+func (c *Conditions) Ceiling() *int {
+	ceiling := c.VerticalVisibility
+	for _, cloud := range c.Clouds {
+		if cloud.Height == nil || (cloud.Cover != "BKN" && cloud.Cover != "OVC") {
+			continue
+		}
+		if ceiling == nil || *cloud.Height < *ceiling {
+			ceiling = cloud.Height
+		}
+	}
+	return ceiling
+}
+
+// RunwayVisualRange 跑道视程组, 如 R36L/P1500U R18/0600V1000N
+type RunwayVisualRange struct {
+	Raw              string `json:"raw"`
+	Runway           string `json:"runway"`
+	Modifier         string `json:"modifier"` // M表示小于, P表示大于
+	Value            int    `json:"value"`
+	VariableModifier string `json:"variable_modifier"`
+	VariableValue    *int   `json:"variable_value"` // 变化范围上限
+	Unit             string `json:"unit"`           // m 或 FT
+	Tendency         string `json:"tendency"`       // U上升 D下降 N无变化
+}
+
+// RunwayState 跑道状态组, 支持8位MOTNE格式、R跑道号/6位格式、CLRD与RWYCC
+type RunwayState struct {
+	Raw            string   `json:"raw"`
+	Runway         string   `json:"runway"`          // 跑道号, 88表示所有跑道, 99表示重复上一组
+	Deposit        string   `json:"deposit"`         // 道面沉积物类型代码
+	Extent         string   `json:"extent"`          // 污染范围代码
+	Depth          *int     `json:"depth"`           // 沉积物厚度(毫米)
+	Friction       *float64 `json:"friction"`        // 摩擦系数
+	BrakingAction  string   `json:"braking_action"`  // 刹车效应
+	Cleared        bool     `json:"cleared"`         // 污染已清除(CLRD)
+	Closed         bool     `json:"closed"`          // 跑道不可用
+	ConditionCodes []int    `json:"condition_codes"` // 跑道三等分的跑道状况代码(RWYCC)
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import (
+	"regexp"
+	"strings"
+)
+
+var icaoPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{3}$`)
+
+// Station 机场数据, 字段与OurAirports数据集对应
+type Station struct {
+	ICAO         string    `json:"icao"`
+	IATA         string    `json:"iata"`
+	LocalCode    string    `json:"local_code"` // 本地代码, 如美国FAA LID
+	Name         string    `json:"name"`
+	Type         string    `json:"type"` // large_airport medium_airport small_airport heliport 等
+	Latitude     float64   `json:"latitude"`
+	Longitude    float64   `json:"longitude"`
+	Elevation    *int      `json:"elevation"` // 标高(英尺), 未知时为空
+	Country      string    `json:"country"`   // ISO 3166-1 国家代码
+	Region       string    `json:"region"`    // ISO 3166-2 地区代码
+	Municipality string    `json:"municipality"`
+	Runways      []*Runway `json:"runways"`
+}
+
+// Runway 跑道数据, 两端分别记录
+type Runway struct {
+	Length  *int         `json:"length"` // 长度(英尺)
+	Width   *int         `json:"width"`  // 宽度(英尺)
+	Surface string       `json:"surface"`
+	Lighted bool         `json:"lighted"`
+	Closed  bool         `json:"closed"`
+	Ends    []*RunwayEnd `json:"ends"`
+}
+
+// RunwayEnd 跑道的一端
+type RunwayEnd struct {
+	Ident     string   `json:"ident"`
+	Heading   *float64 `json:"heading"` // 真航向(度)
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	Elevation *int     `json:"elevation"` // 跑道入口标高(英尺)
+}
+
+// NearbyStation 附近的机场与距离
+type NearbyStation struct {
+	Station  *Station `json:"station"`
+	Distance float64  `json:"distance"` // 距离(公里), 未取整
+	Bearing  float64  `json:"bearing"`  // 相对中心机场的真方位(度), 未取整
+}
+
+// NauticalMile 一海里对应的公里数
+const NauticalMile = 1.852
+
+// MaxAreaStations 区域查询最多查询的站点数, 超出时优先查询类型更大的机场
+const MaxAreaStations = 200
+
+// Area 矩形或圆形的地理区域
+type Area struct {
+	// 矩形区域的边界(度), 西边界大于东边界时跨越180度经线
+	South float64
+	West  float64
+	North float64
+	East  float64
+	// 圆形区域的中心(度)与半径(公里), 半径大于0时使用圆形区域
+	Latitude  float64
+	Longitude float64
+	Radius    float64
+}
+
+// Valid 检查区域的经纬度范围
+func (a *Area) Valid() bool {
+	if a.Radius > 0 {
+		return validLatitude(a.Latitude) && validLongitude(a.Longitude)
+	}
+	return validLatitude(a.South) && validLatitude(a.North) && a.South < a.North &&
+		validLongitude(a.West) && validLongitude(a.East) && a.West != a.East
+}
+
+func validLatitude(latitude float64) bool {
+	return latitude >= -90 && latitude <= 90
+}
+
+func validLongitude(longitude float64) bool {
+	return longitude >= -180 && longitude <= 180
+}
+
+// StationDatabaseInterface 机场数据库
+type StationDatabaseInterface interface {
+	// Get 根据ICAO代码查询机场
+	Get(icao string) (*Station, bool)
+	// Resolve 将大写的ICAO代码、IATA代码或美国FAA LID解析为ICAO代码
+	Resolve(code string) (string, error)
+	// Nearby 按距离从近到远返回半径(公里)内的其他机场, 最多返回limit个, 机场不在数据库中时返回空
+	Nearby(icao string, radius float64, limit int) []*NearbyStation
+	// Area 返回区域内的机场, 类型更大的机场在前, 最多返回limit个
+	Area(area *Area, limit int) []*Station
+	// Len 返回数据库中的机场数量
+	Len() int
+}
+
+// ValidICAO 检查大写的ICAO代码格式
+func ValidICAO(icao string) bool {
+	return icaoPattern.MatchString(icao)
+}
+
+// ResolveICAO 统一机场代码的大小写并解析为ICAO代码, 未提供机场数据库时只检查ICAO代码格式
+func ResolveICAO(stations StationDatabaseInterface, code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if stations != nil {
+		return stations.Resolve(code)
+	}
+	if !ValidICAO(code) {
+		return "", ErrICAOInvalid
+	}
+	return code, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import "time"
+
+const (
+	PeriodTypeBase          = "BASE"
+	PeriodTypeFrom          = "FM"
+	PeriodTypeBecoming      = "BECMG"
+	PeriodTypeTemporary     = "TEMPO"
+	PeriodTypeProbability   = "PROB"
+	PeriodTypeNoSignificant = "NOSIG"
+
+	TemperatureTypeMax = "TX"
+	TemperatureTypeMin = "TN"
+)
+
+// ForecastPeriod TAF中的一个预报时段, 包括基本预报与变化组
+type ForecastPeriod struct {
+	Raw         string    `json:"raw"`
+	Type        string    `json:"type"`        // BASE FM BECMG TEMPO PROB
+	Probability int       `json:"probability"` // PROB30 PROB40 的概率, 其他为0
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	Conditions
+	Unparsed []string `json:"unparsed"` // 无法识别的报文组
+}
+
+// TemperatureForecast 最高最低温度预报组, 如 TX15/1706Z
+type TemperatureForecast struct {
+	Type  string    `json:"type"` // TX 或 TN
+	Value int       `json:"value"`
+	Time  time.Time `json:"time"`
+}
+
+// Taf 解析后的TAF报文
+type Taf struct {
+	Raw          string                 `json:"raw"`
+	Station      string                 `json:"station"`
+	IssueTime    time.Time              `json:"issue_time"`
+	ValidFrom    time.Time              `json:"valid_from"`
+	ValidTo      time.Time              `json:"valid_to"`
+	Amendment    bool                   `json:"amendment"`
+	Correction   bool                   `json:"correction"`
+	Cancelled    bool                   `json:"cancelled"`
+	Nil          bool                   `json:"nil"`
+	Periods      []*ForecastPeriod      `json:"periods"` // 第一个为基本预报, 其余为按报文顺序排列的变化组
+	Temperatures []*TemperatureForecast `json:"temperatures"`
+	Remarks      string                 `json:"remarks"`
+}
+
+// TafConditions TAF在某一时刻的预报天气
+type TafConditions struct {
+	Station    string            `json:"station"`
+	Time       time.Time         `json:"time"`
+	Raw        string            `json:"raw"`
+	Prevailing *Conditions       `json:"prevailing"` // 基本预报叠加已生效的FM与BECMG组
+	Worst      *Conditions       `json:"worst"`      // 在主导天气上叠加生效中的TEMPO PROB组与正在转变的BECMG组后的最差情况
+	Periods    []*ForecastPeriod `json:"periods"`    // 该时刻适用的预报时段
+}
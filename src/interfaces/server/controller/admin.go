@@ -0,0 +1,19 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import "github.com/labstack/echo/v4"
+
+type AdminInterface interface {
+	ListOverride(ctx echo.Context) error
+	SetOverride(ctx echo.Context) error
+	DeleteOverride(ctx echo.Context) error
+	LoadScenario(ctx echo.Context) error
+	ScenarioStatus(ctx echo.Context) error
+	StopScenario(ctx echo.Context) error
+	ReplayClock(ctx echo.Context) error
+	SetReplayClock(ctx echo.Context) error
+	ClearReplayClock(ctx echo.Context) error
+}
@@ -0,0 +1,12 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import "github.com/labstack/echo/v4"
+
+type EncoderInterface interface {
+	EncodeMetar(ctx echo.Context) error
+	EncodeTaf(ctx echo.Context) error
+}
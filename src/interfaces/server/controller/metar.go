@@ -8,5 +8,13 @@ import "github.com/labstack/echo/v4"
 
 type MetarInterface interface {
 	QueryMetar(ctx echo.Context) error
+	BatchQueryMetar(ctx echo.Context) error
+	NearestMetar(ctx echo.Context) error
+	AreaMetar(ctx echo.Context) error
+	GeoJSONMetar(ctx echo.Context) error
 	QueryTaf(ctx echo.Context) error
+	BatchQueryTaf(ctx echo.Context) error
+	QueryTafAt(ctx echo.Context) error
+	NearestTaf(ctx echo.Context) error
+	AreaTaf(ctx echo.Context) error
 }
@@ -5,11 +5,81 @@
 package dto
 
 type QueryMetar struct {
-	ICAO string `query:"icao" valid:"required"`
-	Raw  bool   `query:"raw"`
+	ICAO   string `query:"icao" valid:"required"`
+	Raw    bool   `query:"raw"`
+	Decode bool   `query:"decode"`
+	Lang   string `query:"lang"`
+	Meta   bool   `query:"meta"`
 }
 
 type QueryTaf struct {
+	ICAO   string `query:"icao" valid:"required"`
+	Raw    bool   `query:"raw"`
+	Decode bool   `query:"decode"`
+	Lang   string `query:"lang"`
+	Meta   bool   `query:"meta"`
+}
+
+type QueryBatch struct {
+	ICAO string `query:"icao" valid:"required"`
+}
+
+type QueryTafAt struct {
+	ICAO string `query:"icao" valid:"required"`
+	Time string `query:"time"`
+}
+
+type QueryNearest struct {
+	ICAO  string `query:"icao" valid:"required"`
+	Count int    `query:"count"`
+}
+
+// QueryArea bbox为"西,南,东,北"(度), 未指定bbox时使用lat lon与radius_nm(海里)指定的圆形区域
+type QueryArea struct {
+	BBox      string   `query:"bbox"`
+	Latitude  *float64 `query:"lat"`
+	Longitude *float64 `query:"lon"`
+	RadiusNM  float64  `query:"radius_nm"`
+}
+
+// QueryGeoJSON 指定icao时返回这些站点, 否则与QueryArea一致返回区域内的站点
+type QueryGeoJSON struct {
+	ICAO      string   `query:"icao"`
+	BBox      string   `query:"bbox"`
+	Latitude  *float64 `query:"lat"`
+	Longitude *float64 `query:"lon"`
+	RadiusNM  float64  `query:"radius_nm"`
+}
+
+type QueryHistory struct {
+	ICAO  string `query:"icao" valid:"required"`
+	From  string `query:"from"`
+	To    string `query:"to"`
+	Limit int    `query:"limit"`
+}
+
+type QueryStation struct {
 	ICAO string `query:"icao" valid:"required"`
-	Raw  bool   `query:"raw"`
+}
+
+type SetOverride struct {
+	Type   string `json:"type"`
+	ICAO   string `json:"icao" valid:"required"`
+	Data   string `json:"data" valid:"required"`
+	Expire string `json:"expire"`
+}
+
+type DeleteOverride struct {
+	Type string `query:"type"`
+	ICAO string `query:"icao" valid:"required"`
+}
+
+type QueryOverride struct {
+	Type string `query:"type"`
+}
+
+// SetReplayClock start为RFC3339格式的模拟时间, speed为空时为1
+type SetReplayClock struct {
+	Start string  `json:"start" valid:"required"`
+	Speed float64 `json:"speed"`
 }
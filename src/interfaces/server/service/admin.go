@@ -0,0 +1,24 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"metar-service/src/interfaces/metar"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+)
+
+type AdminInterface interface {
+	ListOverride(reportType string) *dto.ApiResponse[[]*metar.Override]
+	SetOverride(reportType string, icao string, data string, expire time.Duration) *dto.ApiResponse[*metar.Override]
+	DeleteOverride(reportType string, icao string) *dto.ApiResponse[bool]
+	LoadScenario(data []byte) *dto.ApiResponse[*metar.ScenarioStatus]
+	ScenarioStatus() *dto.ApiResponse[*metar.ScenarioStatus]
+	StopScenario() *dto.ApiResponse[bool]
+	ReplayClock() *dto.ApiResponse[*metar.ReplayClock]
+	SetReplayClock(start time.Time, speed float64) *dto.ApiResponse[*metar.ReplayClock]
+	ClearReplayClock() *dto.ApiResponse[bool]
+}
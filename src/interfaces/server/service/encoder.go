@@ -0,0 +1,16 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"metar-service/src/interfaces/metar"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+)
+
+type EncoderInterface interface {
+	EncodeMetar(data *metar.Metar) *dto.ApiResponse[string]
+	EncodeTaf(data *metar.Taf) *dto.ApiResponse[string]
+}
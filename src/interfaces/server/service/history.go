@@ -0,0 +1,17 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"metar-service/src/interfaces/metar"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+)
+
+type HistoryInterface interface {
+	QueryMetarHistory(icao string, from time.Time, to time.Time, limit int) *dto.ApiResponse[[]*metar.ArchivedReport]
+	QueryTafHistory(icao string, from time.Time, to time.Time, limit int) *dto.ApiResponse[[]*metar.ArchivedReport]
+}
@@ -5,12 +5,36 @@
 package service
 
 import (
+	"metar-service/src/interfaces/metar"
+	"time"
+
 	"half-nothing.cn/service-core/interfaces/http/dto"
 )
 
 type MetarInterface interface {
 	QueryMetar(icao string) *dto.ApiResponse[[]string]
 	BatchQueryMetar(icaos []string) *dto.ApiResponse[[]string]
+	QueryMetarResult(icao string) *dto.ApiResponse[[]*metar.QueryResult]
+	BatchQueryMetarResult(icaos []string) *dto.ApiResponse[[]*metar.QueryResult]
+	NearestMetar(icao string, count int) *dto.ApiResponse[[]*metar.QueryResult]
+	AreaMetar(area *metar.Area) *dto.ApiResponse[[]*metar.QueryResult]
+	GeoJSONMetar(icaos []string) *dto.ApiResponse[*metar.FeatureCollection]
+	AreaGeoJSONMetar(area *metar.Area) *dto.ApiResponse[*metar.FeatureCollection]
+	ParseMetar(icao string) *dto.ApiResponse[[]*metar.Metar]
+	BatchParseMetar(icaos []string) *dto.ApiResponse[[]*metar.BatchResult[*metar.Metar]]
+	TranslateMetar(icao string, lang string) *dto.ApiResponse[[]string]
+	BatchTranslateMetar(icaos []string, lang string) *dto.ApiResponse[[]*metar.BatchResult[string]]
 	QueryTaf(icao string) *dto.ApiResponse[[]string]
 	BatchQueryTaf(icaos []string) *dto.ApiResponse[[]string]
+	QueryTafResult(icao string) *dto.ApiResponse[[]*metar.QueryResult]
+	BatchQueryTafResult(icaos []string) *dto.ApiResponse[[]*metar.QueryResult]
+	NearestTaf(icao string, count int) *dto.ApiResponse[[]*metar.QueryResult]
+	AreaTaf(area *metar.Area) *dto.ApiResponse[[]*metar.QueryResult]
+	ParseTaf(icao string) *dto.ApiResponse[[]*metar.Taf]
+	BatchParseTaf(icaos []string) *dto.ApiResponse[[]*metar.BatchResult[*metar.Taf]]
+	TranslateTaf(icao string, lang string) *dto.ApiResponse[[]string]
+	BatchTranslateTaf(icaos []string, lang string) *dto.ApiResponse[[]*metar.BatchResult[string]]
+	QueryTafAt(icao string, at time.Time) *dto.ApiResponse[*metar.TafConditions]
+	ReplayTime() (time.Time, bool)
+	Replay(at time.Time) (MetarInterface, *dto.ApiStatus)
 }
@@ -0,0 +1,15 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"metar-service/src/interfaces/metar"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+)
+
+type StationInterface interface {
+	QueryStation(icao string) *dto.ApiResponse[*metar.Station]
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package category
+package category
+
+import (
+	"metar-service/src/interfaces/config"
+	"metar-service/src/interfaces/metar"
+	"strings"
+)
+
+const (
+	FlightCategoryVFR  = "VFR"
+	FlightCategoryMVFR = "MVFR"
+	FlightCategoryIFR  = "IFR"
+	FlightCategoryLIFR = "LIFR"
+)
+
+// faaRules FAA飞行类别标准, 按从差到好的顺序排列
+// MVFR为云幕高不高于3000英尺或能见度不大于5英里, 由于云高以百英尺为单位报告,
+// 能见度换算为米后取整, 因此使用3100英尺与8048米作为严格小于的阈值
+var faaRules = []*config.FlightCategoryRule{
+	{Name: FlightCategoryLIFR, Ceiling: 500, Visibility: 1609},
+	{Name: FlightCategoryIFR, Ceiling: 1000, Visibility: 4828},
+	{Name: FlightCategoryMVFR, Ceiling: 3100, Visibility: 8048},
+}
+
+type Classifier struct {
+	rules    []*config.FlightCategoryRule
+	fallback string
+}
+
+func NewClassifier(c *config.FlightCategoryConfig) *Classifier {
+	if strings.ToLower(c.Standard) == config.CategoryStandardCustom.Value {
+		return &Classifier{rules: c.Rules, fallback: c.Default}
+	}
+	return &Classifier{rules: faaRules, fallback: FlightCategoryVFR}
+}
+
+// Classify 根据云幕高与能见度计算飞行类别, 两者均未知时返回空字符串
+func (c *Classifier) Classify(conditions *metar.Conditions) string {
+	ceiling := conditions.Ceiling()
+	visibility, visibilityKnown := 0.0, false
+	if conditions.Cavok {
+		visibility, visibilityKnown = 10000, true
+	} else if conditions.Visibility != nil {
+		visibility, visibilityKnown = conditions.Visibility.Meters, true
+	}
+	// 报告了云组(包括NSC SKC等)才能确定没有云幕
+	cloudKnown := conditions.Cavok || ceiling != nil || len(conditions.Clouds) > 0
+
+	if !visibilityKnown && !cloudKnown {
+		return ""
+	}
+
+	for _, rule := range c.rules {
+		if rule.Ceiling > 0 && ceiling != nil && *ceiling < rule.Ceiling {
+			return rule.Name
+		}
+		if rule.Visibility > 0 && visibilityKnown && visibility < rule.Visibility {
+			return rule.Name
+		}
+	}
+	return c.fallback
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package category
+package category
+
+import (
+	"metar-service/src/interfaces/config"
+	"metar-service/src/interfaces/metar"
+	"testing"
+)
+
+func conditions(visibility float64, clouds ...*metar.Cloud) *metar.Conditions {
+	result := &metar.Conditions{Clouds: clouds}
+	if visibility >= 0 {
+		result.Visibility = &metar.Visibility{Meters: visibility}
+	}
+	return result
+}
+
+func cloud(cover string, height int) *metar.Cloud {
+	return &metar.Cloud{Cover: cover, Height: &height}
+}
+
+func TestClassifierFaa(t *testing.T) {
+	classifier := NewClassifier(&config.FlightCategoryConfig{Standard: "faa"})
+	tests := []struct {
+		name       string
+		conditions *metar.Conditions
+		want       string
+	}{
+		{"clear", conditions(10000, &metar.Cloud{Cover: "NSC"}), FlightCategoryVFR},
+		{"cavok", &metar.Conditions{Cavok: true}, FlightCategoryVFR},
+		{"scattered clouds are not a ceiling", conditions(10000, cloud("SCT", 400)), FlightCategoryVFR},
+		{"ceiling 3100", conditions(10000, cloud("BKN", 3100)), FlightCategoryVFR},
+		{"ceiling 3000", conditions(10000, cloud("BKN", 3000)), FlightCategoryMVFR},
+		{"visibility 5 miles", conditions(8047), FlightCategoryMVFR},
+		{"visibility 6 miles", conditions(9656), FlightCategoryVFR},
+		{"ceiling 1000", conditions(10000, cloud("OVC", 1000)), FlightCategoryMVFR},
+		{"ceiling 900", conditions(10000, cloud("OVC", 900)), FlightCategoryIFR},
+		{"visibility 3 miles", conditions(4828), FlightCategoryMVFR},
+		{"visibility 2 miles", conditions(3219), FlightCategoryIFR},
+		{"ceiling 500", conditions(10000, cloud("BKN", 500)), FlightCategoryIFR},
+		{"ceiling 400", conditions(10000, cloud("BKN", 400)), FlightCategoryLIFR},
+		{"visibility below 1 mile", conditions(1500), FlightCategoryLIFR},
+		{"worse of ceiling and visibility", conditions(9999, cloud("OVC", 200)), FlightCategoryLIFR},
+		{"vertical visibility", &metar.Conditions{Visibility: &metar.Visibility{Meters: 5000}, VerticalVisibility: intPtr(100)}, FlightCategoryLIFR},
+		{"visibility only", conditions(10000), FlightCategoryVFR},
+		{"clouds only", conditions(-1, cloud("BKN", 800)), FlightCategoryIFR},
+		{"unknown", conditions(-1), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifier.Classify(tt.conditions); got != tt.want {
+				t.Errorf("Classify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifierCustom(t *testing.T) {
+	classifier := NewClassifier(&config.FlightCategoryConfig{
+		Standard: "custom",
+		Default:  "GREEN",
+		Rules: []*config.FlightCategoryRule{
+			{Name: "RED", Ceiling: 200, Visibility: 800},
+			{Name: "AMBER", Visibility: 5000},
+		},
+	})
+	tests := []struct {
+		name       string
+		conditions *metar.Conditions
+		want       string
+	}{
+		{"default", conditions(8000, cloud("BKN", 300)), "GREEN"},
+		{"visibility rule", conditions(3000, cloud("BKN", 300)), "AMBER"},
+		{"rule without ceiling threshold", conditions(8000, cloud("BKN", 200)), "GREEN"},
+		{"first matching rule", conditions(600), "RED"},
+		{"ceiling rule", conditions(8000, cloud("OVC", 100)), "RED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifier.Classify(tt.conditions); got != tt.want {
+				t.Errorf("Classify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package encoder
+package encoder
+
+import (
+	"fmt"
+	"math"
+	"metar-service/src/interfaces/metar"
+	"metar-service/src/metar/parser"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	stationPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{3}$`)
+	brakingCodes   = map[string]int{
+		"POOR":        91,
+		"MEDIUM/POOR": 92,
+		"MEDIUM":      93,
+		"MEDIUM/GOOD": 94,
+		"GOOD":        95,
+		"UNRELIABLE":  99,
+	}
+	// mileFractions 法定英里能见度中使用的分数, 按分母从小到大尝试
+	mileFractions = []int{2, 4, 8, 16}
+)
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", metar.ErrReportInvalid, fmt.Sprintf(format, args...))
+}
+
+// encodeStation 校验并返回大写的站点代码
+func encodeStation(station string) (string, error) {
+	station = strings.ToUpper(station)
+	if !stationPattern.MatchString(station) {
+		return "", invalid("station %s is invalid", station)
+	}
+	return station, nil
+}
+
+// encodeDayTime 编码 DDHHMMZ 格式的时间组
+func encodeDayTime(t time.Time) string {
+	t = t.UTC()
+	return fmt.Sprintf("%02d%02d%02dZ", t.Day(), t.Hour(), t.Minute())
+}
+
+// encodeDayHour 编码 DDHH 格式的时间, end为true时整日结束使用前一日的24时
+func encodeDayHour(t time.Time, end bool) string {
+	t = t.UTC()
+	if end && t.Hour() == 0 {
+		return fmt.Sprintf("%02d24", t.Add(-time.Hour).Day())
+	}
+	return fmt.Sprintf("%02d%02d", t.Day(), t.Hour())
+}
+
+// encodeValidity 编码 DDHH/DDHH 格式的有效时段
+func encodeValidity(from, to time.Time) string {
+	return encodeDayHour(from, false) + "/" + encodeDayHour(to, true)
+}
+
+// encodeSignedInt 编码以M表示负数的两位整数, 为空时返回//
+func encodeSignedInt(value *int) string {
+	if value == nil {
+		return "//"
+	}
+	if *value < 0 {
+		return fmt.Sprintf("M%02d", -*value)
+	}
+	return fmt.Sprintf("%02d", *value)
+}
+
+// encodeSpeed 编码风速, 100及以上使用三位数字
+func encodeSpeed(value int) string {
+	if value >= 100 {
+		return strconv.Itoa(value)
+	}
+	return fmt.Sprintf("%02d", value)
+}
+
+// encodeWind 编码风组与风向变化组, 如 27015G25KT 240V300
+func encodeWind(wind *metar.Wind) ([]string, error) {
+	unit := wind.Unit
+	if unit == "" {
+		unit = metar.WindUnitKnot
+	}
+	if unit != metar.WindUnitKnot && unit != metar.WindUnitMeterPerSecond && unit != metar.WindUnitKilometerHour {
+		return nil, invalid("wind unit %s is invalid", wind.Unit)
+	}
+
+	var builder strings.Builder
+	switch {
+	case wind.Variable:
+		builder.WriteString("VRB")
+	case wind.Direction == nil && wind.Speed != nil && *wind.Speed == 0:
+		// 静风没有风向, 编码为 00000KT
+		builder.WriteString("000")
+	case wind.Direction != nil:
+		if *wind.Direction < 0 || *wind.Direction > 360 {
+			return nil, invalid("wind direction %d is invalid", *wind.Direction)
+		}
+		builder.WriteString(fmt.Sprintf("%03d", *wind.Direction))
+	default:
+		builder.WriteString("///")
+	}
+	if wind.Speed != nil {
+		builder.WriteString(encodeSpeed(*wind.Speed))
+	} else {
+		builder.WriteString("//")
+	}
+	if wind.Gust != nil {
+		builder.WriteString("G" + encodeSpeed(*wind.Gust))
+	}
+	builder.WriteString(unit)
+
+	groups := []string{builder.String()}
+	if wind.VariableFrom != nil && wind.VariableTo != nil {
+		groups = append(groups, fmt.Sprintf("%03dV%03d", *wind.VariableFrom, *wind.VariableTo))
+	}
+	return groups, nil
+}
+
+// encodeMiles 编码法定英里数值, 非整数时使用最接近的分数, 如 1 1/2 3/4
+func encodeMiles(value float64) string {
+	whole := math.Floor(value)
+	fraction := value - whole
+	if fraction < 1e-6 {
+		return strconv.Itoa(int(whole))
+	}
+	for _, denominator := range mileFractions {
+		numerator := math.Round(fraction * float64(denominator))
+		if math.Abs(numerator/float64(denominator)-fraction) < 1e-6 {
+			if whole == 0 {
+				return fmt.Sprintf("%d/%d", int(numerator), denominator)
+			}
+			return fmt.Sprintf("%d %d/%d", int(whole), int(numerator), denominator)
+		}
+	}
+	return strconv.Itoa(int(math.Round(value)))
+}
+
+// encodeVisibility 编码主导能见度与方向最低能见度
+func encodeVisibility(visibility *metar.Visibility) []string {
+	groups := make([]string, 0, 2)
+	if visibility.Unit == metar.VisibilityUnitStatuteMile {
+		groups = append(groups, visibility.Modifier+encodeMiles(visibility.Value)+"SM")
+	} else {
+		value := int(math.Round(visibility.Value))
+		if value == 0 && visibility.Meters > 0 {
+			value = int(math.Round(visibility.Meters))
+		}
+		if value >= 10000 || visibility.Modifier == "P" {
+			value = 9999
+		}
+		group := fmt.Sprintf("%04d", value)
+		if visibility.NoDirect {
+			group += "NDV"
+		}
+		groups = append(groups, group)
+	}
+	if minimum := visibility.Minimum; minimum != nil {
+		groups = append(groups, fmt.Sprintf("%04d%s", minimum.Distance, minimum.Direction))
+	}
+	return groups
+}
+
+// encodeWeather 编码不含近时天气标识RE的天气现象组, 没有结构化内容时使用原始报文组
+func encodeWeather(weather *metar.Weather) (string, error) {
+	group := weather.Intensity + weather.Descriptor + strings.Join(weather.Phenomena, "")
+	if weather.Descriptor == "" && len(weather.Phenomena) == 0 {
+		group = strings.TrimPrefix(weather.Raw, "RE")
+	}
+	if !parser.IsWeatherGroup(group) {
+		return "", invalid("weather %s is invalid", group)
+	}
+	return group, nil
+}
+
+// encodeCloud 编码云组, 云高以英尺表示并换算为百英尺
+func encodeCloud(cloud *metar.Cloud) (string, error) {
+	group := cloud.Cover
+	switch {
+	case cloud.Height != nil:
+		group = fmt.Sprintf("%s%03d%s", cloud.Cover, *cloud.Height/100, cloud.Type)
+	case cloud.Cover == "FEW" || cloud.Cover == "SCT" || cloud.Cover == "BKN" || cloud.Cover == "OVC" || cloud.Cover == "///":
+		group = cloud.Cover + "///" + cloud.Type
+	}
+	if !parser.IsCloudGroup(group) {
+		return "", invalid("cloud %s is invalid", group)
+	}
+	return group, nil
+}
+
+// encodeConditions 按风、能见度、天气现象、云的顺序编码天气状况
+func encodeConditions(conditions *metar.Conditions) ([]string, error) {
+	groups := make([]string, 0)
+	if conditions.Wind != nil {
+		wind, err := encodeWind(conditions.Wind)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, wind...)
+	}
+	if conditions.Cavok {
+		return append(groups, "CAVOK"), nil
+	}
+	if conditions.Visibility != nil {
+		groups = append(groups, encodeVisibility(conditions.Visibility)...)
+	}
+	for _, weather := range conditions.Weather {
+		group, err := encodeWeather(weather)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	if conditions.NoSignificantWx {
+		groups = append(groups, "NSW")
+	}
+	for _, cloud := range conditions.Clouds {
+		group, err := encodeCloud(cloud)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	if conditions.VerticalVisibility != nil {
+		groups = append(groups, fmt.Sprintf("VV%03d", *conditions.VerticalVisibility/100))
+	}
+	return groups, nil
+}
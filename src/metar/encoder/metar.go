@@ -0,0 +1,204 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package encoder
+package encoder
+
+import (
+	"fmt"
+	"math"
+	"metar-service/src/interfaces/metar"
+	"metar-service/src/metar/parser"
+	"strings"
+	"time"
+)
+
+// MetarEncoder 将结构化的观测编码为METAR报文
+type MetarEncoder struct{}
+
+func NewMetarEncoder() *MetarEncoder {
+	return &MetarEncoder{}
+}
+
+// Encode 按报头、风、能见度、跑道视程、天气现象、云、温度、气压、近时天气、跑道状态、趋势、备注的顺序编码
+func (e *MetarEncoder) Encode(data *metar.Metar) (string, error) {
+	station, err := encodeStation(data.Station)
+	if err != nil {
+		return "", err
+	}
+	if data.Time.IsZero() {
+		return "", invalid("observation time is required")
+	}
+
+	reportType := strings.ToUpper(data.Type)
+	if reportType == "" {
+		reportType = "METAR"
+	}
+	if reportType != "METAR" && reportType != "SPECI" {
+		return "", invalid("report type %s is invalid", data.Type)
+	}
+	groups := []string{reportType}
+	if data.Correction {
+		groups = append(groups, "COR")
+	}
+	groups = append(groups, station, encodeDayTime(data.Time))
+	if data.Auto {
+		groups = append(groups, "AUTO")
+	}
+	if data.Nil {
+		return strings.Join(append(groups, "NIL"), " "), nil
+	}
+
+	conditions, err := encodeConditions(&metar.Conditions{
+		Wind:       data.Wind,
+		Visibility: data.Visibility,
+		Cavok:      data.Cavok,
+	})
+	if err != nil {
+		return "", err
+	}
+	groups = append(groups, conditions...)
+	if !data.Cavok {
+		for _, rvr := range data.RunwayVisualRanges {
+			groups = append(groups, encodeRunwayVisualRange(rvr))
+		}
+		// 风与能见度已编码, 只编码天气现象与云
+		conditions, err = encodeConditions(&metar.Conditions{
+			Weather:            data.Weather,
+			Clouds:             data.Clouds,
+			VerticalVisibility: data.VerticalVisibility,
+		})
+		if err != nil {
+			return "", err
+		}
+		groups = append(groups, conditions...)
+	}
+
+	if data.Temperature != nil || data.DewPoint != nil {
+		groups = append(groups, encodeSignedInt(data.Temperature)+"/"+encodeSignedInt(data.DewPoint))
+	}
+	if data.Pressure != nil {
+		groups = append(groups, encodePressure(data.Pressure))
+	}
+	for _, weather := range data.RecentWeather {
+		group, err := encodeWeather(weather)
+		if err != nil {
+			return "", err
+		}
+		groups = append(groups, "RE"+group)
+	}
+	for _, state := range data.RunwayStates {
+		groups = append(groups, encodeRunwayState(state))
+	}
+
+	for _, trend := range data.Trends {
+		trendGroups, err := encodeTrend(trend, data.Time)
+		if err != nil {
+			return "", err
+		}
+		groups = append(groups, trendGroups...)
+	}
+
+	if data.Remarks != nil {
+		if data.Remarks.Raw != "" {
+			groups = append(groups, "RMK", data.Remarks.Raw)
+		} else if data.Remarks.Maintenance {
+			groups = append(groups, "RMK", "$")
+		}
+	}
+
+	return strings.Join(groups, " "), nil
+}
+
+func encodePressure(pressure *metar.Pressure) string {
+	if pressure.Unit == metar.PressureUnitInchHg {
+		return fmt.Sprintf("A%04d", int(math.Round(pressure.Value*100)))
+	}
+	return fmt.Sprintf("Q%04d", int(math.Round(pressure.Value)))
+}
+
+// encodeRunwayVisualRange 编码跑道视程组, 如 R36L/P1500U R18/0600V1000FT
+func encodeRunwayVisualRange(rvr *metar.RunwayVisualRange) string {
+	group := fmt.Sprintf("R%s/%s%04d", rvr.Runway, rvr.Modifier, rvr.Value)
+	if rvr.VariableValue != nil {
+		group += fmt.Sprintf("V%s%04d", rvr.VariableModifier, *rvr.VariableValue)
+	}
+	if rvr.Unit == "FT" {
+		group += "FT"
+	}
+	return group + rvr.Tendency
+}
+
+// encodeRunwayState 编码跑道状态组, 使用 R跑道号/6位 格式
+func encodeRunwayState(state *metar.RunwayState) string {
+	if state.Runway == "88" && state.Closed && state.Deposit == "" && state.Depth == nil {
+		return "R/SNOCLO"
+	}
+	if len(state.ConditionCodes) == 3 {
+		return fmt.Sprintf("R%s/%d/%d/%d", state.Runway, state.ConditionCodes[0], state.ConditionCodes[1], state.ConditionCodes[2])
+	}
+	if state.Cleared {
+		return fmt.Sprintf("R%s/CLRD%s", state.Runway, encodeRunwayFriction(state))
+	}
+
+	deposit, extent := state.Deposit, state.Extent
+	if deposit == "" {
+		deposit = "/"
+	}
+	if extent == "" {
+		extent = "/"
+	}
+	depth := "//"
+	switch {
+	case state.Closed:
+		depth = "99"
+	case state.Depth == nil:
+	case *state.Depth <= 90:
+		depth = fmt.Sprintf("%02d", *state.Depth)
+	default:
+		// 超过90毫米时以50毫米为单位, 92至98依次表示10厘米至40厘米
+		depth = fmt.Sprintf("%02d", min(90+*state.Depth/50, 98))
+	}
+	return fmt.Sprintf("R%s/%s%s%s%s", state.Runway, deposit, extent, depth, encodeRunwayFriction(state))
+}
+
+func encodeRunwayFriction(state *metar.RunwayState) string {
+	if state.Friction != nil {
+		return fmt.Sprintf("%02d", int(math.Round(*state.Friction*100)))
+	}
+	if code, ok := brakingCodes[state.BrakingAction]; ok {
+		return fmt.Sprintf("%02d", code)
+	}
+	return "//"
+}
+
+// encodeTrend 编码趋势预报, 起止时间与默认有效期一致时省略时间标识
+func encodeTrend(trend *metar.ForecastPeriod, observationTime time.Time) ([]string, error) {
+	switch trend.Type {
+	case metar.PeriodTypeNoSignificant:
+		return []string{metar.PeriodTypeNoSignificant}, nil
+	case metar.PeriodTypeBecoming, metar.PeriodTypeTemporary:
+	default:
+		return nil, invalid("trend type %s is invalid", trend.Type)
+	}
+
+	groups := []string{trend.Type}
+	from, to := trend.From.UTC(), trend.To.UTC()
+	switch {
+	case !from.IsZero() && from.Equal(to):
+		groups = append(groups, fmt.Sprintf("AT%02d%02d", from.Hour(), from.Minute()))
+	default:
+		if !from.IsZero() && !from.Equal(observationTime) {
+			groups = append(groups, fmt.Sprintf("FM%02d%02d", from.Hour(), from.Minute()))
+		}
+		if !to.IsZero() && !to.Equal(observationTime.Add(parser.TrendValidity)) {
+			groups = append(groups, fmt.Sprintf("TL%02d%02d", to.Hour(), to.Minute()))
+		}
+	}
+
+	conditions, err := encodeConditions(&trend.Conditions)
+	if err != nil {
+		return nil, err
+	}
+	return append(groups, conditions...), nil
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package encoder
+package encoder
+
+import (
+	"errors"
+	"metar-service/src/interfaces/metar"
+	"metar-service/src/metar/parser"
+	"testing"
+	"time"
+)
+
+var reference = time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+func TestMetarRoundTrip(t *testing.T) {
+	tests := []string{
+		"METAR ZBAA 151130Z 36005MPS 320V040 9999 FEW030 SCT100 12/M05 Q1021 NOSIG",
+		"SPECI KJFK 151151Z AUTO 27015G25KT 1 1/2SM -SHRA BR BKN008 OVC015CB 08/07 A2992 RMK AO2 SLP132 T00830067",
+		"METAR COR EGLL 150950Z VRB02KT CAVOK M01/M03 Q1030",
+		"METAR LFPG 150630Z 00000KT 0200 0100N R27L/0350N R27R/M0050V0150D FG VV001 05/05 Q1018 RESHRA R27L/451293",
+		"METAR UUEE 150900Z 18003MPS 0800 R06L/0550U FG -SN OVC002 M03/M03 Q1010 R88/CLRD95 BECMG FM0930 TL1030 3000 BR TEMPO AT1000 0500 FG",
+		"METAR KDEN 151153Z 00000KT 1/4SM FG VV002 M02/M02 A3010",
+		"METAR KORD 151151Z 28105G130KT 3/4SM R10L/1800V2400FT +TSRA BKN010CB 20/18 A2970",
+		"METAR RJTT 151100Z 36010KT 9999 NSC 15/05 Q1015 R34L/5/5/4 TEMPO 5000 -RA",
+		"METAR ZSSS 151100Z NIL",
+	}
+	parse := parser.NewMetarParser()
+	encode := NewMetarEncoder()
+	for _, data := range tests {
+		t.Run(data, func(t *testing.T) {
+			parsed, err := parse.ParseAt(data, reference)
+			if err != nil {
+				t.Fatalf("ParseAt() error = %v", err)
+			}
+			if len(parsed.Unparsed) > 0 {
+				t.Fatalf("ParseAt() unparsed = %v", parsed.Unparsed)
+			}
+			encoded, err := encode.Encode(parsed)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if encoded != data {
+				t.Fatalf("Encode() = %q, want %q", encoded, data)
+			}
+			reparsed, err := parse.ParseAt(encoded, reference)
+			if err != nil {
+				t.Fatalf("ParseAt() of encoded report error = %v", err)
+			}
+			if again, _ := encode.Encode(reparsed); again != encoded {
+				t.Errorf("Encode() after round trip = %q, want %q", again, encoded)
+			}
+		})
+	}
+}
+
+func TestMetarEncodeNormalizes(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"ZBAA 151130Z 36005MPS 9999 NSC 12/M05 Q1021=", "METAR ZBAA 151130Z 36005MPS 9999 NSC 12/M05 Q1021"},
+		{"metar zbaa 151130z 36005mps 9999 nsc 12/m05 q1021", "METAR ZBAA 151130Z 36005MPS 9999 NSC 12/M05 Q1021"},
+		{"METAR KBOS 151154Z 09012KT 10SM OVC010 05/04 A3001 SNOCLO", "METAR KBOS 151154Z 09012KT 10SM OVC010 05/04 A3001 R/SNOCLO"},
+	}
+	for _, tt := range tests {
+		parsed, err := parser.NewMetarParser().ParseAt(tt.data, reference)
+		if err != nil {
+			t.Fatalf("ParseAt(%q) error = %v", tt.data, err)
+		}
+		if got, err := NewMetarEncoder().Encode(parsed); err != nil || got != tt.want {
+			t.Errorf("Encode(%q) = %q, %v, want %q", tt.data, got, err, tt.want)
+		}
+	}
+}
+
+func TestMetarEncodeStructured(t *testing.T) {
+	direction, speed, temperature, dewPoint := 250, 12, -3, -8
+	depth := 150
+	data := &metar.Metar{
+		Station: "zbaa",
+		Time:    time.Date(2025, time.March, 1, 6, 0, 0, 0, time.UTC),
+		Conditions: metar.Conditions{
+			Wind:       &metar.Wind{Direction: &direction, Speed: &speed},
+			Visibility: &metar.Visibility{Meters: 12000, Unit: metar.VisibilityUnitMeter},
+			Weather:    []*metar.Weather{{Intensity: "-", Descriptor: "SH", Phenomena: []string{"SN"}}},
+			Clouds:     []*metar.Cloud{{Cover: "BKN", Height: intPtr(2500)}},
+		},
+		Temperature:  &temperature,
+		DewPoint:     &dewPoint,
+		Pressure:     &metar.Pressure{Value: 1019.6, Unit: metar.PressureUnitHectopascal},
+		RunwayStates: []*metar.RunwayState{{Runway: "01", Deposit: "5", Extent: "9", Depth: &depth, BrakingAction: "MEDIUM"}},
+		Remarks:      &metar.Remarks{Maintenance: true},
+	}
+	want := "METAR ZBAA 010600Z 25012KT 9999 -SHSN BKN025 M03/M08 Q1020 R01/599393 RMK $"
+	if got, err := NewMetarEncoder().Encode(data); err != nil || got != want {
+		t.Errorf("Encode() = %q, %v, want %q", got, err, want)
+	}
+}
+
+func TestMetarEncodeParsesBack(t *testing.T) {
+	direction, speed, calm, temperature, dewPoint := 90, 8, 0, 21, 14
+	tests := []struct {
+		name string
+		data *metar.Metar
+		want string
+	}{
+		{
+			name: "structured",
+			data: &metar.Metar{
+				Station: "ZSSS",
+				Time:    time.Date(2025, time.March, 15, 6, 0, 0, 0, time.UTC),
+				Conditions: metar.Conditions{
+					Wind:       &metar.Wind{Direction: &direction, Speed: &speed, Unit: metar.WindUnitMeterPerSecond},
+					Visibility: &metar.Visibility{Value: 4000, Unit: metar.VisibilityUnitMeter},
+					Weather:    []*metar.Weather{{Intensity: "+", Descriptor: "TS", Phenomena: []string{"RA", "GR"}}},
+					Clouds:     []*metar.Cloud{{Cover: "SCT", Height: intPtr(1500), Type: "CB"}, {Cover: "OVC", Height: intPtr(3000)}},
+				},
+				Temperature:   &temperature,
+				DewPoint:      &dewPoint,
+				Pressure:      &metar.Pressure{Value: 1008, Unit: metar.PressureUnitHectopascal},
+				RecentWeather: []*metar.Weather{{Descriptor: "SH", Phenomena: []string{"RA"}}},
+				Remarks:       &metar.Remarks{Maintenance: true},
+			},
+			want: "METAR ZSSS 150600Z 09008MPS 4000 +TSRAGR SCT015CB OVC030 21/14 Q1008 RESHRA RMK $",
+		},
+		{
+			name: "calm wind",
+			data: &metar.Metar{
+				Station: "ZBAA",
+				Time:    time.Date(2025, time.March, 15, 6, 0, 0, 0, time.UTC),
+				Conditions: metar.Conditions{
+					Wind:  &metar.Wind{Speed: &calm},
+					Cavok: true,
+				},
+			},
+			want: "METAR ZBAA 150600Z 00000KT CAVOK",
+		},
+	}
+	parse := parser.NewMetarParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := NewMetarEncoder().Encode(tt.data)
+			if err != nil || encoded != tt.want {
+				t.Fatalf("Encode() = %q, %v, want %q", encoded, err, tt.want)
+			}
+			parsed, err := parse.ParseAt(encoded, reference)
+			if err != nil {
+				t.Fatalf("ParseAt() error = %v", err)
+			}
+			if len(parsed.Unparsed) > 0 {
+				t.Fatalf("ParseAt() unparsed = %v", parsed.Unparsed)
+			}
+			if !parsed.Time.Equal(tt.data.Time) || parsed.Station != tt.data.Station {
+				t.Errorf("ParseAt() station = %s %s, want %s %s", parsed.Station, parsed.Time, tt.data.Station, tt.data.Time)
+			}
+			if wind := parsed.Wind; wind == nil || *wind.Speed != *tt.data.Wind.Speed {
+				t.Errorf("ParseAt() wind = %+v, want speed %d", wind, *tt.data.Wind.Speed)
+			}
+			if len(parsed.Weather) != len(tt.data.Weather) || len(parsed.Clouds) != len(tt.data.Clouds) || len(parsed.RecentWeather) != len(tt.data.RecentWeather) {
+				t.Errorf("ParseAt() weather = %d, clouds = %d, recent weather = %d", len(parsed.Weather), len(parsed.Clouds), len(parsed.RecentWeather))
+			}
+			if (parsed.Remarks != nil && parsed.Remarks.Maintenance) != (tt.data.Remarks != nil && tt.data.Remarks.Maintenance) {
+				t.Errorf("ParseAt() remarks = %+v, want maintenance %v", parsed.Remarks, tt.data.Remarks)
+			}
+			if again, err := NewMetarEncoder().Encode(parsed); err != nil || again != encoded {
+				t.Errorf("Encode() of parsed report = %q, %v, want %q", again, err, encoded)
+			}
+		})
+	}
+}
+
+func TestMetarEncodeInvalid(t *testing.T) {
+	direction := 400
+	tests := []struct {
+		name string
+		data *metar.Metar
+	}{
+		{"missing station", &metar.Metar{Time: reference}},
+		{"invalid station", &metar.Metar{Station: "ZB1", Time: reference}},
+		{"missing time", &metar.Metar{Station: "ZBAA"}},
+		{"invalid type", &metar.Metar{Station: "ZBAA", Time: reference, Type: "TAF"}},
+		{"invalid wind", &metar.Metar{Station: "ZBAA", Time: reference, Conditions: metar.Conditions{Wind: &metar.Wind{Direction: &direction}}}},
+		{"invalid wind unit", &metar.Metar{Station: "ZBAA", Time: reference, Conditions: metar.Conditions{Wind: &metar.Wind{Unit: "MPH"}}}},
+		{"invalid trend", &metar.Metar{Station: "ZBAA", Time: reference, Trends: []*metar.ForecastPeriod{{Type: metar.PeriodTypeFrom}}}},
+		{"invalid weather", &metar.Metar{Station: "ZBAA", Time: reference, Conditions: metar.Conditions{Weather: []*metar.Weather{{Phenomena: []string{"XX"}}}}}},
+		{"invalid recent weather", &metar.Metar{Station: "ZBAA", Time: reference, RecentWeather: []*metar.Weather{{Descriptor: "SH", Phenomena: []string{"XX"}}}}},
+		{"invalid cloud cover", &metar.Metar{Station: "ZBAA", Time: reference, Conditions: metar.Conditions{Clouds: []*metar.Cloud{{Cover: "LOTS", Height: intPtr(3000)}}}}},
+		{"invalid cloud type", &metar.Metar{Station: "ZBAA", Time: reference, Conditions: metar.Conditions{Clouds: []*metar.Cloud{{Cover: "BKN", Height: intPtr(3000), Type: "AC"}}}}},
+		{"invalid trend weather", &metar.Metar{Station: "ZBAA", Time: reference, Trends: []*metar.ForecastPeriod{{Type: metar.PeriodTypeTemporary, Conditions: metar.Conditions{Weather: []*metar.Weather{{Raw: "XX"}}}}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMetarEncoder().Encode(tt.data); !errors.Is(err, metar.ErrReportInvalid) {
+				t.Errorf("Encode() error = %v, want %v", err, metar.ErrReportInvalid)
+			}
+		})
+	}
+}
+
+func TestEncodeMiles(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{10, "10"},
+		{0.25, "1/4"},
+		{1.5, "1 1/2"},
+		{2.75, "2 3/4"},
+		{0.0625, "1/16"},
+		{1.3, "1"},
+	}
+	for _, tt := range tests {
+		if got := encodeMiles(tt.value); got != tt.want {
+			t.Errorf("encodeMiles(%g) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
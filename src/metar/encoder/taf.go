@@ -0,0 +1,122 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package encoder
+package encoder
+
+import (
+	"fmt"
+	"metar-service/src/interfaces/metar"
+	"strings"
+)
+
+// TafEncoder 将结构化的预报编码为TAF报文
+type TafEncoder struct{}
+
+func NewTafEncoder() *TafEncoder {
+	return &TafEncoder{}
+}
+
+// Encode 按报头、有效时段、基本预报、温度预报、变化组、备注的顺序编码
+func (e *TafEncoder) Encode(data *metar.Taf) (string, error) {
+	station, err := encodeStation(data.Station)
+	if err != nil {
+		return "", err
+	}
+	if data.IssueTime.IsZero() {
+		return "", invalid("issue time is required")
+	}
+
+	groups := []string{"TAF"}
+	if data.Amendment {
+		groups = append(groups, "AMD")
+	}
+	if data.Correction {
+		groups = append(groups, "COR")
+	}
+	groups = append(groups, station, encodeDayTime(data.IssueTime))
+	if data.Nil {
+		return strings.Join(append(groups, "NIL"), " "), nil
+	}
+
+	if data.ValidFrom.IsZero() || data.ValidTo.IsZero() || !data.ValidTo.After(data.ValidFrom) {
+		return "", invalid("validity period is invalid")
+	}
+	groups = append(groups, encodeValidity(data.ValidFrom, data.ValidTo))
+	if data.Cancelled {
+		return strings.Join(append(groups, "CNL"), " "), nil
+	}
+
+	if len(data.Periods) == 0 || data.Periods[0].Type != metar.PeriodTypeBase {
+		return "", invalid("base forecast is required")
+	}
+	base, err := encodeConditions(&data.Periods[0].Conditions)
+	if err != nil {
+		return "", err
+	}
+	groups = append(groups, base...)
+
+	for _, temperature := range data.Temperatures {
+		if temperature.Type != metar.TemperatureTypeMax && temperature.Type != metar.TemperatureTypeMin {
+			return "", invalid("temperature type %s is invalid", temperature.Type)
+		}
+		groups = append(groups, fmt.Sprintf("%s%s/%sZ", temperature.Type, encodeSignedInt(&temperature.Value), encodeDayHour(temperature.Time, false)))
+	}
+
+	for _, period := range data.Periods[1:] {
+		header, err := encodeChangeHeader(period)
+		if err != nil {
+			return "", err
+		}
+		conditions, err := encodeConditions(&period.Conditions)
+		if err != nil {
+			return "", err
+		}
+		groups = append(groups, header...)
+		groups = append(groups, conditions...)
+	}
+
+	if data.Remarks != "" {
+		groups = append(groups, "RMK", data.Remarks)
+	}
+
+	return strings.Join(groups, " "), nil
+}
+
+// encodeChangeHeader 编码变化组的起始部分, 如 FM171200 BECMG 1712/1714 PROB30 TEMPO 1712/1718
+func encodeChangeHeader(period *metar.ForecastPeriod) ([]string, error) {
+	if period.From.IsZero() {
+		return nil, invalid("%s period need a start time", period.Type)
+	}
+	if period.Type == metar.PeriodTypeFrom {
+		from := period.From.UTC()
+		return []string{fmt.Sprintf("FM%02d%02d%02d", from.Day(), from.Hour(), from.Minute())}, nil
+	}
+
+	header := make([]string, 0, 3)
+	if period.Probability > 0 {
+		if period.Probability != 30 && period.Probability != 40 {
+			return nil, invalid("probability %d is invalid", period.Probability)
+		}
+		header = append(header, fmt.Sprintf("PROB%d", period.Probability))
+	}
+	switch period.Type {
+	case metar.PeriodTypeBecoming:
+		if period.Probability > 0 {
+			return nil, invalid("BECMG period cannot have a probability")
+		}
+		header = append(header, period.Type)
+	case metar.PeriodTypeTemporary:
+		header = append(header, period.Type)
+	case metar.PeriodTypeProbability:
+		if period.Probability == 0 {
+			return nil, invalid("PROB period need a probability")
+		}
+	default:
+		return nil, invalid("period type %s is invalid", period.Type)
+	}
+	if period.To.IsZero() || !period.To.After(period.From) {
+		return nil, invalid("%s period need an end time after the start time", period.Type)
+	}
+	return append(header, encodeValidity(period.From, period.To)), nil
+}
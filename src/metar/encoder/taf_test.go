@@ -0,0 +1,103 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package encoder
+package encoder
+
+import (
+	"errors"
+	"metar-service/src/interfaces/metar"
+	"metar-service/src/metar/parser"
+	"testing"
+	"time"
+)
+
+func TestTafRoundTrip(t *testing.T) {
+	tests := []string{
+		"TAF ZBAA 151100Z 1512/1618 36005MPS 9999 FEW030 TX15/1506Z TNM02/1522Z BECMG 1520/1522 18004MPS " +
+			"TEMPO 1600/1604 3000 -SHRA PROB30 TEMPO 1608/1612 TSRA FEW030CB",
+		"TAF AMD KJFK 151140Z 1512/1618 27010KT P6SM SCT050 FM151800 30015G25KT 5SM -RA BKN020 FM160600 VRB03KT P6SM SKC",
+		"TAF EGLL 311700Z 3118/0124 22012KT 9999 BKN035 PROB40 0106/0110 4000 RA",
+		"TAF CYYZ 151140Z 1512/1612 24012KT P6SM BKN030 BECMG 1520/1522 VRB03KT NSW RMK NXT FCST BY 151800Z",
+		"TAF ZBAA 151100Z 1512/1618 36005MPS CAVOK TEMPO 1512/1516 4000 BR",
+		"TAF COR ZGGG 151100Z 1512/1612 16004MPS 6000 BR SCT012 BKN030 BECMG 1600/1602 2000 +RA BKN005 OVC010",
+		"TAF AMD ZSSS 151300Z 1512/1618 CNL",
+		"TAF ZSSS 151100Z NIL",
+	}
+	parse := parser.NewTafParser()
+	encode := NewTafEncoder()
+	for _, data := range tests {
+		t.Run(data, func(t *testing.T) {
+			parsed, err := parse.ParseAt(data, reference)
+			if err != nil {
+				t.Fatalf("ParseAt() error = %v", err)
+			}
+			for _, period := range parsed.Periods {
+				if len(period.Unparsed) > 0 {
+					t.Fatalf("ParseAt() unparsed = %v", period.Unparsed)
+				}
+			}
+			encoded, err := encode.Encode(parsed)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if encoded != data {
+				t.Fatalf("Encode() = %q, want %q", encoded, data)
+			}
+			reparsed, err := parse.ParseAt(encoded, reference)
+			if err != nil {
+				t.Fatalf("ParseAt() of encoded report error = %v", err)
+			}
+			if !reparsed.ValidFrom.Equal(parsed.ValidFrom) || !reparsed.ValidTo.Equal(parsed.ValidTo) {
+				t.Errorf("validity after round trip = %s/%s, want %s/%s", reparsed.ValidFrom, reparsed.ValidTo, parsed.ValidFrom, parsed.ValidTo)
+			}
+			if len(reparsed.Periods) != len(parsed.Periods) {
+				t.Fatalf("periods after round trip = %d, want %d", len(reparsed.Periods), len(parsed.Periods))
+			}
+			for i, period := range reparsed.Periods {
+				if !period.From.Equal(parsed.Periods[i].From) || !period.To.Equal(parsed.Periods[i].To) {
+					t.Errorf("period %d after round trip = %s/%s, want %s/%s", i, period.From, period.To, parsed.Periods[i].From, parsed.Periods[i].To)
+				}
+			}
+		})
+	}
+}
+
+func TestTafEncodeInvalid(t *testing.T) {
+	from, to := reference, reference.Add(24*time.Hour)
+	base := &metar.ForecastPeriod{Type: metar.PeriodTypeBase, From: from, To: to}
+	tests := []struct {
+		name string
+		data *metar.Taf
+	}{
+		{"missing station", &metar.Taf{IssueTime: from}},
+		{"missing issue time", &metar.Taf{Station: "ZBAA"}},
+		{"missing validity", &metar.Taf{Station: "ZBAA", IssueTime: from}},
+		{"reversed validity", &metar.Taf{Station: "ZBAA", IssueTime: from, ValidFrom: to, ValidTo: from}},
+		{"missing base", &metar.Taf{Station: "ZBAA", IssueTime: from, ValidFrom: from, ValidTo: to}},
+		{"invalid probability", &metar.Taf{Station: "ZBAA", IssueTime: from, ValidFrom: from, ValidTo: to, Periods: []*metar.ForecastPeriod{
+			base, {Type: metar.PeriodTypeProbability, Probability: 50, From: from, To: to},
+		}}},
+		{"becoming with probability", &metar.Taf{Station: "ZBAA", IssueTime: from, ValidFrom: from, ValidTo: to, Periods: []*metar.ForecastPeriod{
+			base, {Type: metar.PeriodTypeBecoming, Probability: 30, From: from, To: to},
+		}}},
+		{"change without end", &metar.Taf{Station: "ZBAA", IssueTime: from, ValidFrom: from, ValidTo: to, Periods: []*metar.ForecastPeriod{
+			base, {Type: metar.PeriodTypeTemporary, From: from},
+		}}},
+		{"invalid temperature", &metar.Taf{Station: "ZBAA", IssueTime: from, ValidFrom: from, ValidTo: to, Periods: []*metar.ForecastPeriod{base},
+			Temperatures: []*metar.TemperatureForecast{{Type: "TT", Time: from}}}},
+		{"invalid cloud cover", &metar.Taf{Station: "ZBAA", IssueTime: from, ValidFrom: from, ValidTo: to, Periods: []*metar.ForecastPeriod{
+			{Type: metar.PeriodTypeBase, From: from, To: to, Conditions: metar.Conditions{Clouds: []*metar.Cloud{{Cover: "LOTS", Height: intPtr(3000)}}}},
+		}}},
+		{"invalid weather", &metar.Taf{Station: "ZBAA", IssueTime: from, ValidFrom: from, ValidTo: to, Periods: []*metar.ForecastPeriod{
+			base, {Type: metar.PeriodTypeTemporary, From: from, To: to, Conditions: metar.Conditions{Weather: []*metar.Weather{{Phenomena: []string{"XX"}}}}},
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTafEncoder().Encode(tt.data); !errors.Is(err, metar.ErrReportInvalid) {
+				t.Errorf("Encode() error = %v, want %v", err, metar.ErrReportInvalid)
+			}
+		})
+	}
+}
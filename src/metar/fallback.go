@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import (
+	"errors"
+	"math"
+	"metar-service/src/interfaces/config"
+	"metar-service/src/interfaces/metar"
+)
+
+// Fallback 按距离从近到远查询半径内的站点, 用于没有报文的小机场
+type Fallback struct {
+	config   *config.FallbackConfig
+	stations metar.StationDatabaseInterface
+}
+
+func NewFallback(
+	c *config.FallbackConfig,
+	stations metar.StationDatabaseInterface,
+) *Fallback {
+	return &Fallback{
+		config:   c,
+		stations: stations,
+	}
+}
+
+func (f *Fallback) Auto() bool {
+	return f.config.Enable
+}
+
+// Nearest 最多查询candidates个附近的站点, 附近站点都没有报文时返回ErrTargetNotFound, 存在数据源失败时返回ErrUpstreamFailed
+// 请求的站点不在机场数据库中时无法确定位置, 返回ErrStationUnknown
+func (f *Fallback) Nearest(
+	icao string,
+	count int,
+	query func(icao string) (*metar.QueryResult, error),
+) ([]*metar.QueryResult, error) {
+	if _, ok := f.stations.Get(icao); !ok {
+		return nil, metar.ErrStationUnknown
+	}
+	results := make([]*metar.QueryResult, 0, count)
+	upstreamFailed := false
+	for _, nearby := range f.stations.Nearby(icao, f.config.RadiusNM*metar.NauticalMile, f.config.Candidates) {
+		result, err := query(nearby.Station.ICAO)
+		if err != nil {
+			if !errors.Is(err, metar.ErrTargetNotFound) {
+				upstreamFailed = true
+			}
+			continue
+		}
+		result.Fallback = &metar.Fallback{
+			Requested:  icao,
+			DistanceNM: round(nearby.Distance / metar.NauticalMile),
+			Bearing:    round(nearby.Bearing),
+		}
+		results = append(results, result)
+		if len(results) >= count {
+			break
+		}
+	}
+	if len(results) > 0 {
+		return results, nil
+	}
+	if upstreamFailed {
+		return nil, metar.ErrUpstreamFailed
+	}
+	return nil, metar.ErrTargetNotFound
+}
+
+// round 保留一位小数, 距离换算为海里后再取整, 避免两次取整的误差
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import (
+	"errors"
+	"metar-service/src/interfaces/config"
+	"metar-service/src/interfaces/metar"
+	"testing"
+)
+
+// stations 只包含ZSSS及其附近站点的机场数据库
+type stations struct {
+	metar.StationDatabaseInterface
+}
+
+func (stations) Get(icao string) (*metar.Station, bool) {
+	if icao == "ZSSS" {
+		return &metar.Station{ICAO: icao}, true
+	}
+	return nil, false
+}
+
+func (stations) Resolve(code string) (string, error) {
+	if !metar.ValidICAO(code) {
+		return "", metar.ErrICAOInvalid
+	}
+	return code, nil
+}
+
+func (stations) Nearby(string, float64, int) []*metar.NearbyStation {
+	return []*metar.NearbyStation{
+		// 45.26288公里为24.44海里, 先按公里取整为45.3公里会得到24.5海里
+		{Station: &metar.Station{ICAO: "ZSPD"}, Distance: 45.26288, Bearing: 97.64},
+		{Station: &metar.Station{ICAO: "ZSNB"}, Distance: 150, Bearing: 170},
+	}
+}
+
+func TestFallbackNearest(t *testing.T) {
+	tests := []struct {
+		name    string
+		icao    string
+		reports map[string]error
+		want    string
+		err     error
+	}{
+		{"nearest with report", "ZSSS", map[string]error{"ZSPD": nil, "ZSNB": nil}, "ZSPD", nil},
+		{"skip station without report", "ZSSS", map[string]error{"ZSPD": metar.ErrTargetNotFound, "ZSNB": nil}, "ZSNB", nil},
+		{"no nearby report", "ZSSS", map[string]error{"ZSPD": metar.ErrTargetNotFound, "ZSNB": metar.ErrTargetNotFound}, "", metar.ErrTargetNotFound},
+		{"upstream failed", "ZSSS", map[string]error{"ZSPD": metar.ErrUpstreamFailed, "ZSNB": metar.ErrTargetNotFound}, "", metar.ErrUpstreamFailed},
+		{"station not in database", "ZZZZ", map[string]error{"ZSPD": nil}, "", metar.ErrStationUnknown},
+	}
+	fallback := NewFallback(&config.FallbackConfig{RadiusNM: 100, Candidates: 5}, stations{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := fallback.Nearest(tt.icao, 1, func(icao string) (*metar.QueryResult, error) {
+				if err := tt.reports[icao]; err != nil {
+					return nil, err
+				}
+				return &metar.QueryResult{ICAO: icao, Status: metar.QueryStatusOk}, nil
+			})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Nearest() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if len(results) != 1 || results[0].ICAO != tt.want {
+				t.Fatalf("Nearest() = %v, want %s", results, tt.want)
+			}
+			if results[0].Fallback == nil || results[0].Fallback.Requested != tt.icao {
+				t.Fatalf("fallback = %+v, want requested %s", results[0].Fallback, tt.icao)
+			}
+			if fallback := results[0].Fallback; tt.want == "ZSPD" && (fallback.DistanceNM != 24.4 || fallback.Bearing != 97.6) {
+				t.Errorf("distance = %gnm, bearing = %g, want 24.4nm, 97.6", fallback.DistanceNM, fallback.Bearing)
+			}
+		})
+	}
+}
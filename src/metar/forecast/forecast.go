@@ -0,0 +1,156 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package forecast
+package forecast
+
+import (
+	"metar-service/src/interfaces/metar"
+	"time"
+)
+
+// ConditionsAt 计算TAF在指定时刻的主导天气与最差天气
+func ConditionsAt(taf *metar.Taf, at time.Time) (*metar.TafConditions, error) {
+	if taf.Nil || taf.Cancelled || len(taf.Periods) == 0 {
+		return nil, metar.ErrTargetNotFound
+	}
+	at = at.UTC()
+	if at.Before(taf.ValidFrom) || !at.Before(taf.ValidTo) {
+		return nil, metar.ErrTimeOutOfRange
+	}
+
+	base := taf.Periods[0]
+	prevailing := copyConditions(&base.Conditions)
+	periods := []*metar.ForecastPeriod{base}
+	// 先计算主导天气, 再在其上叠加临时变化, 避免临时变化被之后的BECMG组覆盖
+	transient := make([]*metar.ForecastPeriod, 0)
+
+	for _, period := range taf.Periods[1:] {
+		if period.From.After(at) {
+			continue
+		}
+		switch period.Type {
+		case metar.PeriodTypeFrom:
+			if !at.Before(period.To) {
+				continue
+			}
+			// FM组表示天气完全转变, 之前的所有预报不再适用
+			prevailing = copyConditions(&period.Conditions)
+			periods = []*metar.ForecastPeriod{period}
+			transient = transient[:0]
+		case metar.PeriodTypeBecoming:
+			if !at.Before(period.To) {
+				overlay(prevailing, &period.Conditions)
+				periods = append(periods, period)
+				continue
+			}
+			transient = append(transient, period)
+		default:
+			if !at.Before(period.To) {
+				continue
+			}
+			transient = append(transient, period)
+		}
+	}
+
+	worst := copyConditions(prevailing)
+	for _, period := range transient {
+		worsen(worst, &period.Conditions)
+		periods = append(periods, period)
+	}
+
+	return &metar.TafConditions{
+		Station:    taf.Station,
+		Time:       at,
+		Raw:        taf.Raw,
+		Prevailing: prevailing,
+		Worst:      worst,
+		Periods:    periods,
+	}, nil
+}
+
+// Apply 返回在base上叠加变化组后的天气, 不修改参数
+func Apply(base *metar.Conditions, change *metar.Conditions) *metar.Conditions {
+	result := copyConditions(base)
+	overlay(result, change)
+	return result
+}
+
+func copyConditions(conditions *metar.Conditions) *metar.Conditions {
+	result := *conditions
+	result.Weather = append([]*metar.Weather(nil), conditions.Weather...)
+	result.Clouds = append([]*metar.Cloud(nil), conditions.Clouds...)
+	return &result
+}
+
+// overlay 将变化组中出现的要素覆盖到目标天气上, 未出现的要素保持不变
+func overlay(target *metar.Conditions, change *metar.Conditions) {
+	if change.Wind != nil {
+		target.Wind = change.Wind
+	}
+	if change.Cavok {
+		target.Cavok = true
+		target.Visibility = nil
+		target.Weather = nil
+		target.Clouds = nil
+		target.VerticalVisibility = nil
+		return
+	}
+	if change.Visibility != nil {
+		target.Visibility = change.Visibility
+		target.Cavok = false
+	}
+	if change.NoSignificantWx {
+		target.Weather = nil
+	}
+	if len(change.Weather) > 0 {
+		target.Weather = append([]*metar.Weather(nil), change.Weather...)
+		target.Cavok = false
+	}
+	if len(change.Clouds) > 0 || change.VerticalVisibility != nil {
+		target.Clouds = append([]*metar.Cloud(nil), change.Clouds...)
+		target.VerticalVisibility = change.VerticalVisibility
+		target.Cavok = false
+	}
+}
+
+// worsen 逐要素比较, 保留目标天气与变化组中较差的一方
+func worsen(target *metar.Conditions, change *metar.Conditions) {
+	if change.Wind != nil && (target.Wind == nil || maxWindKnots(change.Wind) > maxWindKnots(target.Wind)) {
+		target.Wind = change.Wind
+	}
+	if change.Visibility != nil && visibilityMeters(change) < visibilityMeters(target) {
+		target.Visibility = change.Visibility
+		target.Cavok = false
+	}
+	if len(change.Weather) > 0 {
+		target.Weather = append(target.Weather, change.Weather...)
+		target.Cavok = false
+	}
+	if ceiling := change.Ceiling(); ceiling != nil {
+		if current := target.Ceiling(); current == nil || *ceiling < *current {
+			target.Clouds = append([]*metar.Cloud(nil), change.Clouds...)
+			target.VerticalVisibility = change.VerticalVisibility
+			target.Cavok = false
+		}
+	}
+}
+
+func maxWindKnots(wind *metar.Wind) float64 {
+	speed := 0
+	if wind.Speed != nil {
+		speed = *wind.Speed
+	}
+	if wind.Gust != nil && *wind.Gust > speed {
+		speed = *wind.Gust
+	}
+	return wind.Knots(speed)
+}
+
+func visibilityMeters(conditions *metar.Conditions) float64 {
+	if conditions.Visibility != nil {
+		return conditions.Visibility.Meters
+	}
+	// CAVOK或未报告能见度时视为10公里以上
+	return 10000
+}
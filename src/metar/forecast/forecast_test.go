@@ -0,0 +1,160 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package forecast
+package forecast
+
+import (
+	"errors"
+	"metar-service/src/interfaces/metar"
+	"metar-service/src/metar/parser"
+	"testing"
+	"time"
+)
+
+var reference = time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+func utc(day int, hour int) time.Time {
+	return time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC)
+}
+
+func parseTaf(t *testing.T, data string) *metar.Taf {
+	t.Helper()
+	taf, err := parser.NewTafParser().ParseAt(data, reference)
+	if err != nil {
+		t.Fatalf("ParseAt() error = %v", err)
+	}
+	return taf
+}
+
+func ceiling(conditions *metar.Conditions) int {
+	if value := conditions.Ceiling(); value != nil {
+		return *value
+	}
+	return -1
+}
+
+func TestConditionsAt(t *testing.T) {
+	taf := parseTaf(t, "TAF ZBAA 151100Z 1512/1618 36005MPS 9999 FEW030 BECMG 1520/1522 18004MPS 5000 BR "+
+		"TEMPO 1600/1604 1500 +SHRA BKN008 FM161000 27010MPS CAVOK")
+	tests := []struct {
+		name            string
+		at              time.Time
+		prevailingWind  int
+		prevailingVis   float64
+		prevailingCavok bool
+		worstVis        float64
+		worstCeiling    int
+		worstWeather    int
+		periods         []string
+	}{
+		{"base", utc(15, 14), 360, 10000, false, 10000, -1, 0, []string{metar.PeriodTypeBase}},
+		{"becoming in progress", utc(15, 21), 360, 10000, false, 5000, -1, 1, []string{metar.PeriodTypeBase, metar.PeriodTypeBecoming}},
+		{"becoming finished", utc(15, 23), 180, 5000, false, 5000, -1, 1, []string{metar.PeriodTypeBase, metar.PeriodTypeBecoming}},
+		{"temporary", utc(16, 2), 180, 5000, false, 1500, 800, 2, []string{metar.PeriodTypeBase, metar.PeriodTypeBecoming, metar.PeriodTypeTemporary}},
+		{"temporary end is exclusive", utc(16, 4), 180, 5000, false, 5000, -1, 1, []string{metar.PeriodTypeBase, metar.PeriodTypeBecoming}},
+		{"from replaces earlier periods", utc(16, 12), 270, 10000, true, 10000, -1, 0, []string{metar.PeriodTypeFrom}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ConditionsAt(taf, tt.at)
+			if err != nil {
+				t.Fatalf("ConditionsAt() error = %v", err)
+			}
+			if got := *result.Prevailing.Wind.Direction; got != tt.prevailingWind {
+				t.Errorf("prevailing wind = %d, want %d", got, tt.prevailingWind)
+			}
+			if got := visibilityMeters(result.Prevailing); got != tt.prevailingVis {
+				t.Errorf("prevailing visibility = %g, want %g", got, tt.prevailingVis)
+			}
+			if result.Prevailing.Cavok != tt.prevailingCavok {
+				t.Errorf("prevailing cavok = %v, want %v", result.Prevailing.Cavok, tt.prevailingCavok)
+			}
+			if got := visibilityMeters(result.Worst); got != tt.worstVis {
+				t.Errorf("worst visibility = %g, want %g", got, tt.worstVis)
+			}
+			if got := ceiling(result.Worst); got != tt.worstCeiling {
+				t.Errorf("worst ceiling = %d, want %d", got, tt.worstCeiling)
+			}
+			if got := len(result.Worst.Weather); got != tt.worstWeather {
+				t.Errorf("worst weather = %d, want %d", got, tt.worstWeather)
+			}
+			if len(result.Periods) != len(tt.periods) {
+				t.Fatalf("periods = %d, want %d", len(result.Periods), len(tt.periods))
+			}
+			for i, period := range result.Periods {
+				if period.Type != tt.periods[i] {
+					t.Errorf("period %d = %s, want %s", i, period.Type, tt.periods[i])
+				}
+			}
+		})
+	}
+}
+
+func TestConditionsAtDoesNotModifyTaf(t *testing.T) {
+	taf := parseTaf(t, "TAF ZBAA 151100Z 1512/1618 36005MPS 9999 FEW030 BECMG 1520/1522 2000 BR BKN005")
+	if _, err := ConditionsAt(taf, utc(16, 0)); err != nil {
+		t.Fatalf("ConditionsAt() error = %v", err)
+	}
+	base := taf.Periods[0]
+	if base.Visibility.Meters != 10000 || len(base.Clouds) != 1 || len(base.Weather) != 0 {
+		t.Errorf("base period modified: visibility %g, clouds %d, weather %d", base.Visibility.Meters, len(base.Clouds), len(base.Weather))
+	}
+}
+
+func TestConditionsAtErrors(t *testing.T) {
+	taf := parseTaf(t, "TAF ZBAA 151100Z 1512/1618 36005MPS 9999 FEW030")
+	tests := []struct {
+		name string
+		taf  *metar.Taf
+		at   time.Time
+		want error
+	}{
+		{"before validity", taf, utc(15, 11), metar.ErrTimeOutOfRange},
+		{"validity end is exclusive", taf, utc(16, 18), metar.ErrTimeOutOfRange},
+		{"nil", parseTaf(t, "TAF ZBAA 151100Z NIL"), utc(15, 14), metar.ErrTargetNotFound},
+		{"cancelled", parseTaf(t, "TAF AMD ZBAA 151300Z 1512/1618 CNL"), utc(15, 14), metar.ErrTargetNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ConditionsAt(tt.taf, tt.at); !errors.Is(err, tt.want) {
+				t.Errorf("ConditionsAt() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	base := parseTaf(t, "TAF ZBAA 151100Z 1512/1618 36005MPS 3000 BR BKN010").Periods[0]
+	tests := []struct {
+		name       string
+		change     string
+		visibility float64
+		weather    int
+		ceiling    int
+		cavok      bool
+	}{
+		{"wind only", "18010MPS", 3000, 1, 1000, false},
+		{"no significant weather", "NSW", 3000, 0, 1000, false},
+		{"cavok clears everything", "CAVOK", 10000, 0, -1, true},
+		{"clouds replace clouds", "SCT020", 3000, 1, -1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			change := parseTaf(t, "TAF ZBAA 151100Z 1512/1618 "+tt.change).Periods[0]
+			result := Apply(&base.Conditions, &change.Conditions)
+			if got := visibilityMeters(result); got != tt.visibility {
+				t.Errorf("visibility = %g, want %g", got, tt.visibility)
+			}
+			if len(result.Weather) != tt.weather {
+				t.Errorf("weather = %d, want %d", len(result.Weather), tt.weather)
+			}
+			if got := ceiling(result); got != tt.ceiling {
+				t.Errorf("ceiling = %d, want %d", got, tt.ceiling)
+			}
+			if result.Cavok != tt.cavok {
+				t.Errorf("cavok = %v, want %v", result.Cavok, tt.cavok)
+			}
+		})
+	}
+}
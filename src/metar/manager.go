@@ -5,6 +5,8 @@
 package metar
 
 import (
+	"encoding/json"
+	"errors"
 	"metar-service/src/interfaces/config"
 	"metar-service/src/interfaces/global"
 	"metar-service/src/interfaces/metar"
@@ -18,22 +20,65 @@ import (
 	"half-nothing.cn/service-core/utils"
 )
 
+// cacheRecord 缓存中保存的报文与来源信息
+type cacheRecord struct {
+	Data            string    `json:"data"`
+	Provider        string    `json:"provider"`
+	FetchTime       time.Time `json:"fetch_time"`
+	ObservationTime time.Time `json:"observation_time"`
+	ExpireAt        time.Time `json:"expire_at"`
+}
+
+const (
+	// fetchLockTTL 跨实例请求锁的有效期, 持有锁的实例异常退出时锁自动释放
+	fetchLockTTL = 15 * time.Second
+	// fetchWaitTimeout 等待其他实例获取报文的最长时间, 超时后自行请求
+	fetchWaitTimeout = 10 * time.Second
+	fetchWaitStep    = 100 * time.Millisecond
+)
+
+// errPeerFetching 其他实例正在请求该站点的报文
+var errPeerFetching = errors.New("peer instance is fetching")
+
 type Manager struct {
 	logger       logger.Interface
 	providers    []metar.ProviderInterface
 	cache        cache.Interface[string, *string]
+	reportTime   metar.ReportTimeInterface
+	cachePolicy  metar.CachePolicyInterface
+	refresher    metar.RefresherInterface
+	locker       metar.LockerInterface
+	clearer      metar.ClearerInterface
+	archive      metar.ArchiveInterface
+	override     metar.OverrideInterface
+	stations     metar.StationDatabaseInterface
+	fallback     metar.FallbackInterface
 	requestGroup singleflight.Group
+	keys         sync.Map // 本实例写入过缓存的站点, 用于清除全部缓存
 }
 
 func NewManager(
 	lg logger.Interface,
 	providerConfigs []*config.ProviderConfig,
 	cache cache.Interface[string, *string],
+	reportTime metar.ReportTimeInterface,
+	cachePolicy metar.CachePolicyInterface,
 ) *Manager {
 	manager := &Manager{
-		logger:    logger.NewLoggerAdapter(lg, "source-manager"),
-		providers: make([]metar.ProviderInterface, 0),
-		cache:     cache,
+		logger:      logger.NewLoggerAdapter(lg, "source-manager"),
+		providers:   make([]metar.ProviderInterface, 0),
+		cache:       cache,
+		reportTime:  reportTime,
+		cachePolicy: cachePolicy,
+	}
+
+	// 共享缓存提供跨实例的互斥锁, 保证同一站点在集群内只请求一次数据源
+	if locker, ok := cache.(metar.LockerInterface); ok {
+		manager.locker = locker
+	}
+	// 持久化与共享缓存中可能有其他实例或重启前写入的站点, 清除全部缓存时需要清除整个命名空间
+	if clearer, ok := cache.(metar.ClearerInterface); ok {
+		manager.clearer = clearer
 	}
 
 	utils.ForEach(providerConfigs, func(index int, providerConfig *config.ProviderConfig) {
@@ -42,56 +87,273 @@ func NewManager(
 
 	return manager
 }
+
 func (m *Manager) Query(icao string) (string, error) {
-	if icao == "" || len(icao) != 4 {
-		return "", metar.ErrICAOInvalid
+	result, err := m.QueryResult(icao)
+	if err != nil {
+		return "", err
+	}
+	return result.Data, nil
+}
+
+// QueryResult 查询报文并附带来源、获取时间、观测时间与缓存命中等元数据
+// IATA代码与FAA LID解析为ICAO代码后再查询, 缓存与数据源均使用ICAO代码
+// 开启自动回退时, 站点没有报文则返回最近的有报文站点
+func (m *Manager) QueryResult(code string) (*metar.QueryResult, error) {
+	icao, err := metar.ResolveICAO(m.stations, code)
+	if err != nil {
+		return nil, err
+	}
+	result, err := m.queryResult(icao)
+	if errors.Is(err, metar.ErrTargetNotFound) && m.fallback != nil && m.fallback.Auto() {
+		if results, fallbackErr := m.fallback.Nearest(icao, 1, m.queryResult); fallbackErr == nil {
+			result, err = results[0], nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Query = code
+	return result, nil
+}
+
+// Nearest 查询附近有报文的站点, 不包括请求的站点本身
+func (m *Manager) Nearest(code string, count int) ([]*metar.QueryResult, error) {
+	icao, err := metar.ResolveICAO(m.stations, code)
+	if err != nil {
+		return nil, err
+	}
+	if m.fallback == nil {
+		return nil, metar.ErrTargetNotFound
+	}
+	results, err := m.fallback.Nearest(icao, count, m.queryResult)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		result.Query = code
+	}
+	return results, nil
+}
+
+func (m *Manager) queryResult(icao string) (*metar.QueryResult, error) {
+	// 管理员指定的报文优先于缓存与数据源
+	if m.override != nil {
+		if override, ok := m.override.Get(icao); ok {
+			result := m.newQueryResult(icao, &cacheRecord{Data: override.Data, Provider: override.Source}, false)
+			result.Override = true
+			return result, nil
+		}
+	}
+
+	if m.refresher != nil {
+		m.refresher.Hit(icao)
 	}
 
 	if data, ok := m.cache.Get(icao); ok {
-		if data != nil {
-			return *data, nil
+		if data == nil {
+			return nil, metar.ErrTargetNotFound
 		}
-		return "", metar.ErrTargetNotFound
+		return m.newQueryResult(icao, decodeCacheRecord(*data), true), nil
 	}
 
+	record, err := m.fetch(icao, true)
+	if errors.Is(err, metar.ErrUpstreamFailed) {
+		if stale := m.staleRecord(icao); stale != nil {
+			m.logger.Warnf("All providers fail for %s, serving stale report fetched at %s", icao, stale.FetchTime.Format(time.RFC3339))
+			result := m.newQueryResult(icao, stale, false)
+			result.Stale = true
+			return result, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return m.newQueryResult(icao, record, false), nil
+}
+
+// staleRecord 获取最近一次成功获取的报文, 报文年龄超过上限时返回空
+func (m *Manager) staleRecord(icao string) *cacheRecord {
+	maxAge := m.cachePolicy.StaleMaxAge(icao)
+	if maxAge <= 0 {
+		return nil
+	}
+	data, ok := m.cache.Get(staleCacheKey(icao))
+	if !ok || data == nil {
+		return nil
+	}
+	record := decodeCacheRecord(*data)
+	reportTime := record.ObservationTime
+	if reportTime.IsZero() {
+		reportTime = record.FetchTime
+	}
+	if reportTime.IsZero() || time.Since(reportTime) > maxAge {
+		return nil
+	}
+	return record
+}
+
+// Refresh 绕过缓存从数据源重新获取报文, 获取失败时保留原有缓存
+func (m *Manager) Refresh(icao string) error {
+	// 使用共享缓存时其他实例可能已经刷新过, 此时只需按新的过期时间重新安排
+	if data, ok := m.cache.Get(icao); ok && data != nil {
+		record := decodeCacheRecord(*data)
+		if !record.ExpireAt.IsZero() && time.Now().Before(record.FetchTime.Add(record.ExpireAt.Sub(record.FetchTime)/2)) {
+			if m.refresher != nil {
+				m.refresher.Schedule(icao, record.ExpireAt)
+			}
+			return nil
+		}
+	}
+	_, err := m.fetch(icao, false)
+	return err
+}
+
+// SetRefresher 设置提前刷新调度器
+func (m *Manager) SetRefresher(refresher metar.RefresherInterface) {
+	m.refresher = refresher
+}
+
+// SetArchive 设置历史报文归档, 从数据源获取的报文都会写入归档
+func (m *Manager) SetArchive(archive metar.ArchiveInterface) {
+	m.archive = archive
+}
+
+// SetOverride 设置管理员指定报文的存储
+func (m *Manager) SetOverride(override metar.OverrideInterface) {
+	m.override = override
+}
+
+// SetStations 设置用于校验与解析机场代码的机场数据库
+func (m *Manager) SetStations(stations metar.StationDatabaseInterface) {
+	m.stations = stations
+}
+
+// SetFallback 设置附近站点查询
+func (m *Manager) SetFallback(fallback metar.FallbackInterface) {
+	m.fallback = fallback
+}
+
+// fetch 获取报文, 本实例内通过singleflight合并请求, 使用共享缓存时再通过跨实例锁合并请求
+// cacheNotFound为true时缓存未找到的结果, 同时表示可以直接使用其他实例获取的结果
+func (m *Manager) fetch(icao string, cacheNotFound bool) (*cacheRecord, error) {
 	result, err, _ := m.requestGroup.Do(icao, func() (interface{}, error) {
-		for _, provider := range m.providers {
-			data, err := provider.Get(icao)
-			if err != nil {
-				continue
+		if m.locker == nil {
+			return m.fetchProviders(icao, cacheNotFound)
+		}
+		acquired, err := m.locker.TryLock(icao, fetchLockTTL)
+		if err != nil {
+			m.logger.Errorf("Fail to acquire fetch lock for %s: %v", icao, err)
+		}
+		if err == nil && !acquired {
+			if !cacheNotFound {
+				// 刷新时其他实例正在获取, 由其写入共享缓存即可
+				return nil, errPeerFetching
+			}
+			var record *cacheRecord
+			record, acquired, err = m.waitForPeer(icao)
+			if record != nil || err != nil {
+				return record, err
 			}
-			m.setCache(icao, &data)
-			return data, nil
 		}
-		m.setCache(icao, nil)
-		return "", metar.ErrTargetNotFound
+		if acquired {
+			defer m.locker.Unlock(icao)
+		}
+		return m.fetchProviders(icao, cacheNotFound)
 	})
-
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	return result.(*cacheRecord), nil
+}
 
-	// 将结果按行分割，去除空行，再重新组合成单行字符串
-	lines := strings.Split(result.(string), "\n")
-	nonEmptyLines := utils.Filter(lines, func(line string) bool {
-		return strings.TrimSpace(line) != ""
-	})
-	// 对每一行进行trim处理后再连接
-	utils.Map(nonEmptyLines, func(line string) string {
-		return strings.TrimSpace(line)
-	})
-	data := strings.Join(nonEmptyLines, " ")
+// waitForPeer 等待持有锁的实例将报文写入共享缓存
+// 该实例释放锁后仍未写入(如请求失败)时由本实例获取锁, 等待超时则不加锁直接请求
+func (m *Manager) waitForPeer(icao string) (*cacheRecord, bool, error) {
+	deadline := time.Now().Add(fetchWaitTimeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(fetchWaitStep)
+		if data, ok := m.cache.Get(icao); ok {
+			if data == nil {
+				return nil, false, metar.ErrTargetNotFound
+			}
+			return decodeCacheRecord(*data), false, nil
+		}
+		if acquired, err := m.locker.TryLock(icao, fetchLockTTL); err == nil && acquired {
+			return nil, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// fetchProviders 依次从数据源获取报文并写入缓存, cacheNotFound为true时缓存未找到的结果
+func (m *Manager) fetchProviders(icao string, cacheNotFound bool) (*cacheRecord, error) {
+	upstreamFailed := false
+	for _, provider := range m.providers {
+		data, err := provider.Get(icao)
+		if err != nil {
+			if !errors.Is(err, metar.ErrTargetNotFound) {
+				upstreamFailed = true
+			}
+			continue
+		}
+		record := &cacheRecord{
+			Data:            data,
+			Provider:        provider.Name(),
+			FetchTime:       time.Now(),
+			ObservationTime: m.observationTime(data),
+		}
+		m.setCache(icao, record)
+		m.saveArchive(icao, record)
+		return record, nil
+	}
+	// 上游请求失败时不缓存, 避免将暂时性故障当作不存在
+	if upstreamFailed {
+		return nil, metar.ErrUpstreamFailed
+	}
+	if cacheNotFound {
+		m.setCache(icao, nil)
+	}
+	return nil, metar.ErrTargetNotFound
+}
 
-	return data, nil
+// saveArchive 异步归档报文, 无法解析报文时间的报文无法去重, 不归档
+func (m *Manager) saveArchive(icao string, record *cacheRecord) {
+	if m.archive == nil || record.ObservationTime.IsZero() {
+		return
+	}
+	report := &metar.ArchivedReport{
+		ICAO:       strings.ToUpper(icao),
+		ReportTime: record.ObservationTime,
+		Data:       normalize(record.Data),
+		Provider:   record.Provider,
+		FetchTime:  record.FetchTime,
+	}
+	go func() {
+		if err := m.archive.Save(report); err != nil {
+			m.logger.Errorf("Fail to archive report of %s: %v", icao, err)
+		}
+	}()
 }
 
 func (m *Manager) BatchQuery(icaos []string) []string {
-	wg := sync.WaitGroup{}
-	lock := sync.Mutex{}
 	data := make([]string, 0, len(icaos))
+	for _, result := range m.BatchQueryResult(icaos) {
+		if result.Status == metar.QueryStatusOk {
+			data = append(data, result.Data)
+		}
+	}
+	return data
+}
+
+// BatchQueryResult 批量查询, 按请求顺序返回每个机场的查询结果
+func (m *Manager) BatchQueryResult(icaos []string) []*metar.QueryResult {
+	wg := sync.WaitGroup{}
+	results := make([]*metar.QueryResult, len(icaos))
 	limiter := make(chan struct{}, *global.QueryThread)
 
-	for _, icao := range icaos {
+	for index, icao := range icaos {
 		wg.Add(1)
 		limiter <- struct{}{}
 		go func() {
@@ -99,28 +361,148 @@ func (m *Manager) BatchQuery(icaos []string) []string {
 				<-limiter
 				wg.Done()
 			}()
-			d, err := m.Query(icao)
+			result, err := m.QueryResult(icao)
 			if err != nil {
-				return
+				result = &metar.QueryResult{ICAO: icao, Query: icao}
 			}
-			lock.Lock()
-			data = append(data, d)
-			lock.Unlock()
+			result.Status = metar.QueryStatus(err)
+			// 每个协程只写入自己的下标, 无需加锁
+			results[index] = result
 		}()
 	}
 	wg.Wait()
 
-	return data
+	return results
+}
+
+// Purge 清除站点的缓存, 包括用于数据源失败时返回的过期报文
+func (m *Manager) Purge(icao string) {
+	m.cache.Del(icao)
+	m.cache.Del(staleCacheKey(icao))
+	m.keys.Delete(icao)
+}
+
+// PurgeAll 清除全部缓存, 缓存后端支持时清除整个命名空间, 否则清除本实例写入过的站点
+func (m *Manager) PurgeAll() {
+	if m.clearer != nil {
+		m.clearer.Clear()
+		m.keys.Clear()
+		return
+	}
+	m.keys.Range(func(key, _ any) bool {
+		m.Purge(key.(string))
+		return true
+	})
+}
+
+// RefreshProvider 清除由指定数据源提供的报文并重新获取, 返回重新获取的站点数
+func (m *Manager) RefreshProvider(provider string) int {
+	icaos := make([]string, 0)
+	m.keys.Range(func(key, _ any) bool {
+		icao := key.(string)
+		if data, ok := m.cache.Get(icao); ok && data != nil && decodeCacheRecord(*data).Provider == provider {
+			m.cache.Del(icao)
+			icaos = append(icaos, icao)
+		}
+		return true
+	})
+	m.BatchQueryResult(icaos)
+	return len(icaos)
+}
+
+func (m *Manager) newQueryResult(icao string, record *cacheRecord, cacheHit bool) *metar.QueryResult {
+	result := &metar.QueryResult{
+		ICAO:     icao,
+		Status:   metar.QueryStatusOk,
+		Data:     normalize(record.Data),
+		Provider: record.Provider,
+		CacheHit: cacheHit,
+	}
+	if !record.FetchTime.IsZero() {
+		fetchTime := record.FetchTime
+		result.FetchTime = &fetchTime
+	}
+	observationTime := record.ObservationTime
+	if observationTime.IsZero() {
+		observationTime = m.observationTime(record.Data)
+	}
+	if !observationTime.IsZero() {
+		age := int64(time.Since(observationTime) / time.Second)
+		result.ObservationTime = &observationTime
+		result.Age = &age
+	}
+	return result
+}
+
+// observationTime 解析报文的观测时间, 无法解析时返回零值
+func (m *Manager) observationTime(data string) time.Time {
+	if m.reportTime == nil {
+		return time.Time{}
+	}
+	observationTime, err := m.reportTime.ReportTime(normalize(data))
+	if err != nil {
+		return time.Time{}
+	}
+	return observationTime
+}
+
+// routineReport 判断报文是否为定时报, 特选报(SPECI)、更正报(COR CCA)与修订报(AMD)标识位于报头
+func routineReport(data string) bool {
+	tokens := strings.Fields(data)
+	for _, token := range tokens[:min(len(tokens), 4)] {
+		if token == "SPECI" || token == "COR" || token == "AMD" || (len(token) == 3 && strings.HasPrefix(token, "CC")) {
+			return false
+		}
+	}
+	return true
 }
 
-func (m *Manager) setCache(icao string, metar *string) {
-	currentTime := time.Now()
-	minute := currentTime.Minute()
-	var addMinutes int
-	if minute < 30 {
-		addMinutes = 30 - minute
-	} else {
-		addMinutes = 60 - minute
+// normalize 将报文按行分割，去除空行，再重新组合成单行字符串
+func normalize(data string) string {
+	lines := strings.Split(data, "\n")
+	nonEmptyLines := utils.Filter(lines, func(line string) bool {
+		return strings.TrimSpace(line) != ""
+	})
+	// 对每一行进行trim处理后再连接
+	utils.Map(nonEmptyLines, func(line string) string {
+		return strings.TrimSpace(line)
+	})
+	return strings.Join(nonEmptyLines, " ")
+}
+
+func staleCacheKey(icao string) string {
+	return "stale:" + icao
+}
+
+// decodeCacheRecord 解析缓存中的记录, 兼容只保存了报文本身的旧缓存
+func decodeCacheRecord(data string) *cacheRecord {
+	record := &cacheRecord{}
+	if err := json.Unmarshal([]byte(data), record); err != nil || record.Data == "" {
+		return &cacheRecord{Data: data}
+	}
+	return record
+}
+
+func (m *Manager) setCache(icao string, record *cacheRecord) {
+	m.keys.Store(icao, struct{}{})
+	if record == nil {
+		m.cache.SetWithTTL(icao, nil, m.cachePolicy.NegativeTTL(icao))
+		return
+	}
+	ttl := m.cachePolicy.TTL(icao, record.ObservationTime, routineReport(record.Data))
+	record.ExpireAt = time.Now().Add(ttl)
+	if m.refresher != nil {
+		m.refresher.Schedule(icao, record.ExpireAt)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		m.logger.Errorf("Fail to encode cache record for %s: %v", icao, err)
+		return
+	}
+	value := string(data)
+	m.cache.SetWithTTL(icao, &value, ttl)
+	// 额外保存一份最近成功获取的报文, 用于数据源全部失败时返回
+	if maxAge := m.cachePolicy.StaleMaxAge(icao); maxAge > 0 {
+		m.cache.SetWithTTL(staleCacheKey(icao), &value, maxAge)
 	}
-	m.cache.SetWithTTL(icao, metar, time.Duration(addMinutes)*time.Minute)
 }
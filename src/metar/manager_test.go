@@ -0,0 +1,152 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import (
+	"encoding/json"
+	"errors"
+	"metar-service/src/interfaces/config"
+	"metar-service/src/interfaces/metar"
+	"sync"
+	"testing"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/cache"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// nopLogger 丢弃测试中的日志
+type nopLogger struct {
+	logger.Interface
+}
+
+func (nopLogger) Warnf(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+
+// memoryCache 不会自动过期的缓存, 测试通过删除键模拟过期
+type memoryCache struct {
+	cache.Interface[string, *string]
+	lock sync.Mutex
+	data map[string]*string
+}
+
+func (c *memoryCache) Get(key string) (*string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	value, ok := c.data[key]
+	return value, ok
+}
+
+func (c *memoryCache) SetWithTTL(key string, value *string, _ time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.data[key] = value
+}
+
+func (c *memoryCache) Del(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.data, key)
+}
+
+// provider 按failed返回上游错误或固定报文
+type provider struct {
+	failed bool
+}
+
+func (*provider) Name() string { return "test" }
+
+func (p *provider) Get(icao string) (string, error) {
+	if p.failed {
+		return "", errors.New("connection refused")
+	}
+	return "METAR " + icao + " 151100Z 36005MPS CAVOK 12/M05 Q1021 NOSIG", nil
+}
+
+// cachePolicy 固定缓存时间的过期策略
+type cachePolicy struct {
+	staleMaxAge time.Duration
+}
+
+func (cachePolicy) TTL(string, time.Time, bool) time.Duration { return time.Minute }
+func (cachePolicy) NegativeTTL(string) time.Duration          { return time.Minute }
+func (p cachePolicy) StaleMaxAge(string) time.Duration        { return p.staleMaxAge }
+
+func newTestManager(staleMaxAge time.Duration) (*Manager, *memoryCache, *provider) {
+	c := &memoryCache{data: make(map[string]*string)}
+	p := &provider{}
+	return &Manager{
+		logger:      nopLogger{},
+		providers:   []metar.ProviderInterface{p},
+		cache:       c,
+		cachePolicy: cachePolicy{staleMaxAge: staleMaxAge},
+	}, c, p
+}
+
+func TestManagerServesStaleReport(t *testing.T) {
+	manager, c, p := newTestManager(time.Hour)
+	fresh, err := manager.QueryResult("ZBAA")
+	if err != nil || fresh.Stale {
+		t.Fatalf("QueryResult() = %+v, %v, want fresh report", fresh, err)
+	}
+
+	// 缓存过期后所有数据源失败, 返回最近一次成功获取的报文
+	c.Del("ZBAA")
+	p.failed = true
+	result, err := manager.QueryResult("ZBAA")
+	if err != nil {
+		t.Fatalf("QueryResult() error = %v, want stale report", err)
+	}
+	if !result.Stale || result.CacheHit || result.Data != fresh.Data {
+		t.Errorf("QueryResult() = %+v, want stale %q", result, fresh.Data)
+	}
+	if _, ok := c.Get("ZBAA"); ok {
+		t.Error("upstream failure was cached")
+	}
+}
+
+func TestManagerUpstreamFailedWithoutStale(t *testing.T) {
+	tests := []struct {
+		name        string
+		staleMaxAge time.Duration
+		stale       *cacheRecord
+	}{
+		{"no stale report", time.Hour, nil},
+		{"stale report disabled", 0, &cacheRecord{Data: "METAR ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021", FetchTime: time.Now()}},
+		{"stale report too old", time.Hour, &cacheRecord{Data: "METAR ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021", FetchTime: time.Now().Add(-2 * time.Hour)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager, c, p := newTestManager(tt.staleMaxAge)
+			p.failed = true
+			if tt.stale != nil {
+				data, _ := json.Marshal(tt.stale)
+				value := string(data)
+				c.SetWithTTL(staleCacheKey("ZBAA"), &value, time.Hour)
+			}
+			if result, err := manager.QueryResult("ZBAA"); !errors.Is(err, metar.ErrUpstreamFailed) {
+				t.Errorf("QueryResult() = %+v, %v, want %v", result, err, metar.ErrUpstreamFailed)
+			}
+		})
+	}
+}
+
+func TestManagerNearestUnknownStation(t *testing.T) {
+	manager, _, _ := newTestManager(0)
+	manager.SetStations(stations{})
+	manager.SetFallback(NewFallback(&config.FallbackConfig{RadiusNM: 100, Candidates: 5}, stations{}))
+
+	// 请求的站点不在机场数据库中时无法查找附近站点, 与附近站点都没有报文区分
+	if _, err := manager.Nearest("zzzz", 1); !errors.Is(err, metar.ErrStationUnknown) {
+		t.Errorf("Nearest(zzzz) error = %v, want %v", err, metar.ErrStationUnknown)
+	}
+	if _, err := manager.Nearest("Z1", 1); !errors.Is(err, metar.ErrICAOInvalid) {
+		t.Errorf("Nearest(Z1) error = %v, want %v", err, metar.ErrICAOInvalid)
+	}
+	results, err := manager.Nearest("zsss", 1)
+	if err != nil || len(results) != 1 || results[0].ICAO != "ZSPD" || results[0].Query != "zsss" {
+		t.Errorf("Nearest(zsss) = %v, %v, want ZSPD", results, err)
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package parser
+package parser
+
+import (
+	"math"
+	"metar-service/src/interfaces/metar"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	dayTimePattern      = regexp.MustCompile(`^(\d{2})(\d{2})(\d{2})Z$`)
+	windPattern         = regexp.MustCompile(`^(VRB|\d{3}|///)(\d{2,3}|//)(?:G(\d{2,3}))?(KT|MPS|KMH)$`)
+	windVariablePattern = regexp.MustCompile(`^(\d{3})V(\d{3})$`)
+	visibilityPattern   = regexp.MustCompile(`^(\d{4})(NDV)?$`)
+	directionalPattern  = regexp.MustCompile(`^(\d{4})(N|NE|E|SE|S|SW|W|NW)$`)
+	statuteMilePattern  = regexp.MustCompile(`^([MP])?(\d{1,2}|\d{1,2}/\d{1,2})SM$`)
+	wholeMilePattern    = regexp.MustCompile(`^\d$`)
+	fractionMilePattern = regexp.MustCompile(`^\d/\d{1,2}SM$`)
+	weatherPattern      = regexp.MustCompile(`^(-|\+|VC)?(MI|BC|PR|DR|BL|SH|TS|FZ)?((?:DZ|RA|SN|SG|IC|PL|GR|GS|UP|BR|FG|FU|VA|DU|SA|HZ|PY|PO|SQ|FC|SS|DS)*)$`)
+	cloudPattern        = regexp.MustCompile(`^(FEW|SCT|BKN|OVC|///)(\d{3}|///)(CB|TCU|///)?$`)
+	verticalVisPattern  = regexp.MustCompile(`^VV(\d{3}|///)$`)
+	weatherCodePattern  = regexp.MustCompile(`DZ|RA|SN|SG|IC|PL|GR|GS|UP|BR|FG|FU|VA|DU|SA|HZ|PY|PO|SQ|FC|SS|DS`)
+	clearSkyCodes       = []string{"SKC", "CLR", "NSC", "NCD"}
+)
+
+// tokenize 将报文切分为报文组, 并去除结尾的"="
+func tokenize(data string) []string {
+	data = strings.ToUpper(strings.TrimSpace(data))
+	data = strings.TrimSuffix(data, "=")
+	return strings.Fields(data)
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+// parseSignedInt 解析以M表示负数的整数, 如 M05
+func parseSignedInt(value string) (*int, bool) {
+	if value == "" || strings.Trim(value, "/") == "" {
+		return nil, false
+	}
+	negative := strings.HasPrefix(value, "M")
+	number, err := strconv.Atoi(strings.TrimPrefix(value, "M"))
+	if err != nil {
+		return nil, false
+	}
+	if negative {
+		number = -number
+	}
+	return &number, true
+}
+
+// resolveDayTime 根据参考时间推断日时分对应的完整UTC时间
+// 报文只包含日期, 因此在参考时间的上月、当月与下月中选取最接近参考时间的一个
+func resolveDayTime(reference time.Time, day, hour, minute int) (time.Time, bool) {
+	if day < 1 || day > 31 || hour > 24 || minute > 59 {
+		return time.Time{}, false
+	}
+	reference = reference.UTC()
+	var result time.Time
+	found := false
+	for offset := -1; offset <= 1; offset++ {
+		monthStart := time.Date(reference.Year(), reference.Month()+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+		candidate := time.Date(monthStart.Year(), monthStart.Month(), day, 0, 0, 0, 0, time.UTC)
+		if candidate.Month() != monthStart.Month() {
+			continue
+		}
+		candidate = candidate.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+		if !found || absDuration(candidate.Sub(reference)) < absDuration(result.Sub(reference)) {
+			result = candidate
+			found = true
+		}
+	}
+	return result, found
+}
+
+func absDuration(duration time.Duration) time.Duration {
+	if duration < 0 {
+		return -duration
+	}
+	return duration
+}
+
+// parseDayTime 解析 DDHHMMZ 格式的时间组
+func parseDayTime(token string, reference time.Time) (time.Time, bool) {
+	match := dayTimePattern.FindStringSubmatch(token)
+	if match == nil {
+		return time.Time{}, false
+	}
+	day, _ := strconv.Atoi(match[1])
+	hour, _ := strconv.Atoi(match[2])
+	minute, _ := strconv.Atoi(match[3])
+	return resolveDayTime(reference, day, hour, minute)
+}
+
+// parseWind 解析风组, 如 27015G25KT VRB02MPS
+func parseWind(token string) (*metar.Wind, bool) {
+	match := windPattern.FindStringSubmatch(token)
+	if match == nil {
+		return nil, false
+	}
+	wind := &metar.Wind{Unit: match[4]}
+	switch match[1] {
+	case "VRB":
+		wind.Variable = true
+	case "///":
+	default:
+		direction, _ := strconv.Atoi(match[1])
+		wind.Direction = &direction
+	}
+	if speed, err := strconv.Atoi(match[2]); err == nil {
+		wind.Speed = &speed
+	}
+	if match[3] != "" {
+		gust, _ := strconv.Atoi(match[3])
+		wind.Gust = &gust
+	}
+	return wind, true
+}
+
+// parseWindVariable 解析风向变化组, 如 240V300
+func parseWindVariable(token string, wind *metar.Wind) bool {
+	if wind == nil {
+		return false
+	}
+	match := windVariablePattern.FindStringSubmatch(token)
+	if match == nil {
+		return false
+	}
+	from, _ := strconv.Atoi(match[1])
+	to, _ := strconv.Atoi(match[2])
+	wind.VariableFrom = &from
+	wind.VariableTo = &to
+	return true
+}
+
+// parseVisibility 解析主导能见度, 支持米制与法定英里
+// 返回值中的int表示本次消耗的报文组数量
+func parseVisibility(tokens []string) (*metar.Visibility, int) {
+	token := tokens[0]
+	if match := visibilityPattern.FindStringSubmatch(token); match != nil {
+		distance, _ := strconv.Atoi(match[1])
+		visibility := &metar.Visibility{
+			Value:    float64(distance),
+			Unit:     metar.VisibilityUnitMeter,
+			Meters:   float64(distance),
+			NoDirect: match[2] != "",
+		}
+		if distance == 9999 {
+			visibility.Modifier = "P"
+			visibility.Meters = 10000
+		}
+		return visibility, 1
+	}
+	// 形如 1 1/2SM 的能见度由两个报文组构成
+	if len(tokens) > 1 && wholeMilePattern.MatchString(token) && fractionMilePattern.MatchString(tokens[1]) {
+		whole, _ := strconv.Atoi(token)
+		fraction, ok := parseFraction(strings.TrimSuffix(tokens[1], "SM"))
+		if !ok {
+			return nil, 0
+		}
+		return newStatuteMileVisibility(float64(whole)+fraction, ""), 2
+	}
+	if match := statuteMilePattern.FindStringSubmatch(token); match != nil {
+		value, ok := parseFraction(match[2])
+		if !ok {
+			return nil, 0
+		}
+		return newStatuteMileVisibility(value, match[1]), 1
+	}
+	return nil, 0
+}
+
+func newStatuteMileVisibility(value float64, modifier string) *metar.Visibility {
+	return &metar.Visibility{
+		Value:    value,
+		Unit:     metar.VisibilityUnitStatuteMile,
+		Modifier: modifier,
+		Meters:   math.Round(value * metar.MeterPerStatuteMile),
+	}
+}
+
+// parseFraction 解析整数或分数, 如 3 1/4
+func parseFraction(value string) (float64, bool) {
+	numerator, denominator, found := strings.Cut(value, "/")
+	top, err := strconv.Atoi(numerator)
+	if err != nil {
+		return 0, false
+	}
+	if !found {
+		return float64(top), true
+	}
+	bottom, err := strconv.Atoi(denominator)
+	if err != nil || bottom == 0 {
+		return 0, false
+	}
+	return float64(top) / float64(bottom), true
+}
+
+// parseDirectionalVisibility 解析方向最低能见度, 如 1500SW
+func parseDirectionalVisibility(token string) (*metar.DirectionalVisibility, bool) {
+	match := directionalPattern.FindStringSubmatch(token)
+	if match == nil {
+		return nil, false
+	}
+	distance, _ := strconv.Atoi(match[1])
+	return &metar.DirectionalVisibility{Distance: distance, Direction: match[2]}, true
+}
+
+// parseWeather 解析天气现象组, 如 -SHRA +TSRAGR VCFG
+func parseWeather(token string) (*metar.Weather, bool) {
+	match := weatherPattern.FindStringSubmatch(token)
+	if match == nil || (match[2] == "" && match[3] == "") {
+		return nil, false
+	}
+	// 只有强度没有现象的组无意义, 但VCSH VCTS这类只有特征的组是合法的
+	if match[3] == "" && match[1] != "VC" && match[2] != "TS" {
+		return nil, false
+	}
+	weather := &metar.Weather{
+		Raw:        token,
+		Intensity:  match[1],
+		Descriptor: match[2],
+		Phenomena:  weatherCodePattern.FindAllString(match[3], -1),
+	}
+	if weather.Phenomena == nil {
+		weather.Phenomena = make([]string, 0)
+	}
+	return weather, true
+}
+
+// IsWeatherGroup 判断报文组是否为可解析的天气现象组
+func IsWeatherGroup(token string) bool {
+	_, ok := parseWeather(token)
+	return ok
+}
+
+// parseCloud 解析云组, 如 BKN020CB SCT040 NSC
+func parseCloud(token string) (*metar.Cloud, bool) {
+	for _, code := range clearSkyCodes {
+		if token == code {
+			return &metar.Cloud{Cover: code}, true
+		}
+	}
+	match := cloudPattern.FindStringSubmatch(token)
+	if match == nil {
+		return nil, false
+	}
+	cloud := &metar.Cloud{Cover: match[1]}
+	if height, err := strconv.Atoi(match[2]); err == nil {
+		cloud.Height = intPtr(height * 100)
+	}
+	if match[3] != "///" {
+		cloud.Type = match[3]
+	}
+	return cloud, true
+}
+
+// IsCloudGroup 判断报文组是否为可解析的云组
+func IsCloudGroup(token string) bool {
+	_, ok := parseCloud(token)
+	return ok
+}
+
+// parseVerticalVisibility 解析垂直能见度, 如 VV002
+func parseVerticalVisibility(token string) (*int, bool) {
+	match := verticalVisPattern.FindStringSubmatch(token)
+	if match == nil {
+		return nil, false
+	}
+	if height, err := strconv.Atoi(match[1]); err == nil {
+		return intPtr(height * 100), true
+	}
+	return nil, true
+}
+
+// parseConditions 尝试将报文组解析为天气状况的一部分
+// 返回值表示本次消耗的报文组数量, 为0表示无法识别
+func parseConditions(tokens []string, conditions *metar.Conditions) int {
+	token := tokens[0]
+	if wind, ok := parseWind(token); ok && conditions.Wind == nil {
+		conditions.Wind = wind
+		return 1
+	}
+	if parseWindVariable(token, conditions.Wind) {
+		return 1
+	}
+	if token == "CAVOK" {
+		conditions.Cavok = true
+		return 1
+	}
+	if conditions.Visibility == nil && len(conditions.Clouds) == 0 {
+		if visibility, consumed := parseVisibility(tokens); consumed > 0 {
+			conditions.Visibility = visibility
+			return consumed
+		}
+	}
+	if conditions.Visibility != nil && conditions.Visibility.Minimum == nil {
+		if minimum, ok := parseDirectionalVisibility(token); ok {
+			conditions.Visibility.Minimum = minimum
+			return 1
+		}
+	}
+	if token == "NSW" {
+		conditions.NoSignificantWx = true
+		return 1
+	}
+	if weather, ok := parseWeather(token); ok {
+		conditions.Weather = append(conditions.Weather, weather)
+		return 1
+	}
+	if cloud, ok := parseCloud(token); ok {
+		conditions.Clouds = append(conditions.Clouds, cloud)
+		return 1
+	}
+	if height, ok := parseVerticalVisibility(token); ok {
+		conditions.VerticalVisibility = height
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package parser
+package parser
+
+import (
+	"metar-service/src/interfaces/metar"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	stationPattern     = regexp.MustCompile(`^[A-Z][A-Z0-9]{3}$`)
+	temperaturePattern = regexp.MustCompile(`^(M?\d{2}|//)/(M?\d{2}|//)?$`)
+	pressurePattern    = regexp.MustCompile(`^([QA])(\d{4}|////)$`)
+	trendKeywords      = []string{"NOSIG", "BECMG", "TEMPO"}
+)
+
+type MetarParser struct{}
+
+func NewMetarParser() *MetarParser {
+	return &MetarParser{}
+}
+
+func (p *MetarParser) Parse(data string) (*metar.Metar, error) {
+	return p.ParseAt(data, time.Now())
+}
+
+// ReportTime 返回报文的观测时间
+func (p *MetarParser) ReportTime(data string) (time.Time, error) {
+	result, err := p.Parse(data)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if result.Time.IsZero() {
+		return time.Time{}, metar.ErrReportInvalid
+	}
+	return result.Time, nil
+}
+
+// ParseAt 以reference作为参考时间解析报文, 用于推断报文中省略的年月
+func (p *MetarParser) ParseAt(data string, reference time.Time) (*metar.Metar, error) {
+	tokens := tokenize(data)
+	result := &metar.Metar{
+		Raw:  strings.Join(tokens, " "),
+		Type: "METAR",
+	}
+
+	index := 0
+	if index < len(tokens) && (tokens[index] == "METAR" || tokens[index] == "SPECI") {
+		result.Type = tokens[index]
+		index++
+	}
+	if index < len(tokens) && tokens[index] == "COR" {
+		result.Correction = true
+		index++
+	}
+	if index >= len(tokens) || !stationPattern.MatchString(tokens[index]) {
+		return nil, metar.ErrReportInvalid
+	}
+	result.Station = tokens[index]
+	index++
+
+	if index < len(tokens) {
+		if observationTime, ok := parseDayTime(tokens[index], reference); ok {
+			result.Time = observationTime
+			index++
+		}
+	}
+
+	// 报文修饰组
+modifiers:
+	for ; index < len(tokens); index++ {
+		switch tokens[index] {
+		case "AUTO":
+			result.Auto = true
+		case "COR", "CCA", "CCB", "CCC":
+			result.Correction = true
+		case "NIL":
+			result.Nil = true
+			return result, nil
+		default:
+			break modifiers
+		}
+	}
+
+	for index < len(tokens) {
+		token := tokens[index]
+
+		if token == "RMK" {
+			result.Remarks = parseRemarks(tokens[index+1:], result.Time)
+			break
+		}
+		// 没有备注组时维护标识会直接出现在报文末尾
+		if token == "$" {
+			if result.Remarks == nil {
+				result.Remarks = &metar.Remarks{}
+			}
+			result.Remarks.Maintenance = true
+			index++
+			continue
+		}
+		if isTrendKeyword(token) {
+			trendEnd := len(tokens)
+			for i := index; i < len(tokens); i++ {
+				if tokens[i] == "RMK" {
+					trendEnd = i
+					break
+				}
+			}
+			result.Trends = parseTrends(tokens[index:trendEnd], result.Time)
+			index = trendEnd
+			continue
+		}
+
+		if rvr, ok := parseRunwayVisualRange(token); ok {
+			result.RunwayVisualRanges = append(result.RunwayVisualRanges, rvr)
+			index++
+			continue
+		}
+
+		if state, ok := parseRunwayState(token); ok {
+			result.RunwayStates = append(result.RunwayStates, state)
+			index++
+			continue
+		}
+
+		if consumed := parseConditions(tokens[index:], &result.Conditions); consumed > 0 {
+			index += consumed
+			continue
+		}
+
+		if match := temperaturePattern.FindStringSubmatch(token); match != nil && result.Temperature == nil {
+			result.Temperature, _ = parseSignedInt(match[1])
+			result.DewPoint, _ = parseSignedInt(match[2])
+			index++
+			continue
+		}
+
+		if match := pressurePattern.FindStringSubmatch(token); match != nil {
+			if value, err := strconv.Atoi(match[2]); err == nil {
+				if match[1] == "Q" {
+					result.Pressure = &metar.Pressure{Value: float64(value), Unit: metar.PressureUnitHectopascal}
+				} else {
+					result.Pressure = &metar.Pressure{Value: float64(value) / 100, Unit: metar.PressureUnitInchHg}
+				}
+			}
+			index++
+			continue
+		}
+
+		if strings.HasPrefix(token, "RE") {
+			if weather, ok := parseWeather(strings.TrimPrefix(token, "RE")); ok {
+				weather.Raw = token
+				result.RecentWeather = append(result.RecentWeather, weather)
+				index++
+				continue
+			}
+		}
+
+		result.Unparsed = append(result.Unparsed, token)
+		index++
+	}
+
+	return result, nil
+}
+
+func isTrendKeyword(token string) bool {
+	for _, keyword := range trendKeywords {
+		if token == keyword {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package parser
+package parser
+
+import (
+	"errors"
+	"metar-service/src/interfaces/metar"
+	"testing"
+	"time"
+)
+
+var reference = time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+func intValue(value *int) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func TestMetarParserParseAt(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		check func(t *testing.T, result *metar.Metar)
+	}{
+		{
+			name: "metric report",
+			data: "METAR ZBAA 151130Z 36005MPS 320V040 9999 FEW030 SCT100 12/M05 Q1021 NOSIG=",
+			check: func(t *testing.T, result *metar.Metar) {
+				expectEqual(t, "type", result.Type, "METAR")
+				expectEqual(t, "station", result.Station, "ZBAA")
+				expectEqual(t, "time", result.Time, time.Date(2025, time.March, 15, 11, 30, 0, 0, time.UTC))
+				expectEqual(t, "wind direction", intValue(result.Wind.Direction), 360)
+				expectEqual(t, "wind speed", intValue(result.Wind.Speed), 5)
+				expectEqual(t, "wind unit", result.Wind.Unit, metar.WindUnitMeterPerSecond)
+				expectEqual(t, "wind variable from", intValue(result.Wind.VariableFrom), 320)
+				expectEqual(t, "wind variable to", intValue(result.Wind.VariableTo), 40)
+				expectEqual(t, "visibility", result.Visibility.Meters, 10000.0)
+				expectEqual(t, "visibility modifier", result.Visibility.Modifier, "P")
+				expectEqual(t, "clouds", len(result.Clouds), 2)
+				expectEqual(t, "cloud height", intValue(result.Clouds[1].Height), 10000)
+				expectEqual(t, "temperature", intValue(result.Temperature), 12)
+				expectEqual(t, "dew point", intValue(result.DewPoint), -5)
+				expectEqual(t, "pressure", *result.Pressure, metar.Pressure{Value: 1021, Unit: metar.PressureUnitHectopascal})
+				expectEqual(t, "trends", len(result.Trends), 1)
+				expectEqual(t, "unparsed", len(result.Unparsed), 0)
+			},
+		},
+		{
+			name: "north american report",
+			data: "SPECI KJFK 151151Z AUTO 27015G25KT 1 1/2SM -SHRA BR BKN008 OVC015CB 08/07 A2992",
+			check: func(t *testing.T, result *metar.Metar) {
+				expectEqual(t, "type", result.Type, "SPECI")
+				expectEqual(t, "auto", result.Auto, true)
+				expectEqual(t, "gust", intValue(result.Wind.Gust), 25)
+				expectEqual(t, "visibility", result.Visibility.Value, 1.5)
+				expectEqual(t, "visibility unit", result.Visibility.Unit, metar.VisibilityUnitStatuteMile)
+				expectEqual(t, "visibility meters", result.Visibility.Meters, 2414.0)
+				expectEqual(t, "weather", len(result.Weather), 2)
+				expectEqual(t, "weather intensity", result.Weather[0].Intensity, "-")
+				expectEqual(t, "weather descriptor", result.Weather[0].Descriptor, "SH")
+				expectEqual(t, "weather phenomena", result.Weather[0].Phenomena[0], "RA")
+				expectEqual(t, "cloud type", result.Clouds[1].Type, "CB")
+				expectEqual(t, "ceiling", intValue(result.Ceiling()), 800)
+				expectEqual(t, "pressure", *result.Pressure, metar.Pressure{Value: 29.92, Unit: metar.PressureUnitInchHg})
+			},
+		},
+		{
+			name: "cavok and correction",
+			data: "METAR COR EGLL 150950Z VRB02KT CAVOK M01/M03 Q1030",
+			check: func(t *testing.T, result *metar.Metar) {
+				expectEqual(t, "correction", result.Correction, true)
+				expectEqual(t, "variable wind", result.Wind.Variable, true)
+				expectEqual(t, "wind direction", intValue(result.Wind.Direction), nil)
+				expectEqual(t, "cavok", result.Cavok, true)
+				expectEqual(t, "temperature", intValue(result.Temperature), -1)
+			},
+		},
+		{
+			name: "vertical visibility and directional minimum",
+			data: "LFPG 150630Z 00000KT 0200 0100N R27L/0350N FG VV001 05/05 Q1018 RESHRA",
+			check: func(t *testing.T, result *metar.Metar) {
+				expectEqual(t, "type", result.Type, "METAR")
+				expectEqual(t, "visibility", result.Visibility.Meters, 200.0)
+				expectEqual(t, "minimum", *result.Visibility.Minimum, metar.DirectionalVisibility{Distance: 100, Direction: "N"})
+				expectEqual(t, "rvr", len(result.RunwayVisualRanges), 1)
+				expectEqual(t, "vertical visibility", intValue(result.VerticalVisibility), 100)
+				expectEqual(t, "ceiling", intValue(result.Ceiling()), 100)
+				expectEqual(t, "recent weather", result.RecentWeather[0].Raw, "RESHRA")
+			},
+		},
+		{
+			name: "nil report",
+			data: "METAR ZSSS 151100Z NIL=",
+			check: func(t *testing.T, result *metar.Metar) {
+				expectEqual(t, "nil", result.Nil, true)
+				expectEqual(t, "wind", result.Wind == nil, true)
+			},
+		},
+		{
+			name: "unknown groups",
+			data: "METAR ZBAA 151130Z 36005MPS 9999 NSC 12/M05 Q1021 WS R01",
+			check: func(t *testing.T, result *metar.Metar) {
+				expectEqual(t, "unparsed", len(result.Unparsed), 2)
+			},
+		},
+	}
+	parser := NewMetarParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parser.ParseAt(tt.data, reference)
+			if err != nil {
+				t.Fatalf("ParseAt() error = %v", err)
+			}
+			tt.check(t, result)
+		})
+	}
+}
+
+func TestMetarParserInvalid(t *testing.T) {
+	parser := NewMetarParser()
+	for _, data := range []string{"", "METAR", "METAR 1234 151130Z", "hello world"} {
+		if _, err := parser.ParseAt(data, reference); !errors.Is(err, metar.ErrReportInvalid) {
+			t.Errorf("ParseAt(%q) error = %v, want %v", data, err, metar.ErrReportInvalid)
+		}
+	}
+	if _, err := parser.ReportTime("METAR ZBAA 36005MPS CAVOK"); !errors.Is(err, metar.ErrReportInvalid) {
+		t.Errorf("ReportTime() without time error = %v, want %v", err, metar.ErrReportInvalid)
+	}
+}
+
+func TestResolveDayTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference time.Time
+		day       int
+		hour      int
+		want      time.Time
+		ok        bool
+	}{
+		{"same month", reference, 14, 6, time.Date(2025, time.March, 14, 6, 0, 0, 0, time.UTC), true},
+		{"next month", time.Date(2025, time.January, 31, 22, 0, 0, 0, time.UTC), 1, 6, time.Date(2025, time.February, 1, 6, 0, 0, 0, time.UTC), true},
+		{"previous year", time.Date(2025, time.January, 1, 1, 0, 0, 0, time.UTC), 31, 23, time.Date(2024, time.December, 31, 23, 0, 0, 0, time.UTC), true},
+		{"skip short month", time.Date(2025, time.March, 1, 1, 0, 0, 0, time.UTC), 30, 12, time.Date(2025, time.March, 30, 12, 0, 0, 0, time.UTC), true},
+		{"hour 24", reference, 15, 24, time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC), true},
+		{"invalid day", reference, 32, 0, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := resolveDayTime(tt.reference, tt.day, tt.hour, 0)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("resolveDayTime() = %s, %v, want %s, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func expectEqual[T comparable](t *testing.T, name string, got T, want T) {
+	t.Helper()
+	if got != want {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
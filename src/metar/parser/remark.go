@@ -0,0 +1,198 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package parser
+package parser
+
+import (
+	"metar-service/src/interfaces/metar"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	seaLevelPressurePattern = regexp.MustCompile(`^SLP(\d{3})$`)
+	preciseTempPattern      = regexp.MustCompile(`^T([01])(\d{3})(?:([01])(\d{3}))?$`)
+	sixHourMaxPattern       = regexp.MustCompile(`^1([01])(\d{3})$`)
+	sixHourMinPattern       = regexp.MustCompile(`^2([01])(\d{3})$`)
+	dayExtremePattern       = regexp.MustCompile(`^4([01])(\d{3})([01])(\d{3})$`)
+	pressureTendencyPattern = regexp.MustCompile(`^5([0-8])(\d{3})$`)
+	peakWindPattern         = regexp.MustCompile(`^(\d{3})(\d{2,3})/(\d{2})?(\d{2})$`)
+	remarkTimePattern       = regexp.MustCompile(`^(\d{2})?(\d{2})$`)
+	weatherEventPattern     = regexp.MustCompile(`^(?:(?:[A-Z]{2,4}?)(?:[BE]\d{2}(?:\d{2})?)+)+$`)
+	weatherEventPartPattern = regexp.MustCompile(`([A-Z]{2,4}?)((?:[BE]\d{2}(?:\d{2})?)+)`)
+	eventTimePattern        = regexp.MustCompile(`([BE])(\d{2}(?:\d{2})?)`)
+	sensorOutageCodes       = []string{"RVRNO", "PWINO", "PNO", "FZRANO", "TSNO", "VISNO", "CHINO", "SLPNO"}
+)
+
+// parseRemarks 解析备注组, 目前支持北美METAR中常见的备注
+func parseRemarks(tokens []string, observationTime time.Time) *metar.Remarks {
+	remarks := &metar.Remarks{Raw: strings.Join(tokens, " ")}
+
+	for index := 0; index < len(tokens); index++ {
+		token := tokens[index]
+
+		switch token {
+		case "AO1", "AO2", "A01", "A02":
+			remarks.StationType = strings.ReplaceAll(token, "0", "O")
+			continue
+		case "$":
+			remarks.Maintenance = true
+			continue
+		case "PK":
+			if index+2 < len(tokens) && tokens[index+1] == "WND" {
+				if peakWind, ok := parsePeakWind(tokens[index+2], observationTime); ok {
+					remarks.PeakWind = peakWind
+					index += 2
+					continue
+				}
+			}
+		case "WSHFT":
+			if index+1 < len(tokens) {
+				if shiftTime, ok := parseRemarkTime(tokens[index+1], observationTime); ok {
+					remarks.WindShift = &metar.WindShift{Time: shiftTime}
+					index++
+					if index+1 < len(tokens) && tokens[index+1] == "FROPA" {
+						remarks.WindShift.FrontalPassage = true
+						index++
+					}
+					continue
+				}
+			}
+		}
+
+		if isSensorOutage(token) {
+			remarks.SensorOutages = append(remarks.SensorOutages, token)
+			continue
+		}
+		if match := seaLevelPressurePattern.FindStringSubmatch(token); match != nil {
+			value, _ := strconv.Atoi(match[1])
+			// SLP只报告百帕数的后三位(精确到0.1), 500以上为900百帕段, 否则为1000百帕段
+			pressure := float64(value) / 10
+			if value >= 500 {
+				pressure += 900
+			} else {
+				pressure += 1000
+			}
+			remarks.SeaLevelPressure = &pressure
+			continue
+		}
+		if match := preciseTempPattern.FindStringSubmatch(token); match != nil {
+			remarks.PreciseTemperature = parseTenths(match[1], match[2])
+			if match[3] != "" {
+				remarks.PreciseDewPoint = parseTenths(match[3], match[4])
+			}
+			continue
+		}
+		if match := sixHourMaxPattern.FindStringSubmatch(token); match != nil {
+			remarks.MaxTemperature6h = parseTenths(match[1], match[2])
+			continue
+		}
+		if match := sixHourMinPattern.FindStringSubmatch(token); match != nil {
+			remarks.MinTemperature6h = parseTenths(match[1], match[2])
+			continue
+		}
+		if match := dayExtremePattern.FindStringSubmatch(token); match != nil {
+			remarks.MaxTemperature24h = parseTenths(match[1], match[2])
+			remarks.MinTemperature24h = parseTenths(match[3], match[4])
+			continue
+		}
+		if match := pressureTendencyPattern.FindStringSubmatch(token); match != nil {
+			character, _ := strconv.Atoi(match[1])
+			change, _ := strconv.Atoi(match[2])
+			remarks.PressureTendency = &metar.PressureTendency{Character: character, Change: float64(change) / 10}
+			continue
+		}
+		if events, ok := parseWeatherEvents(token, observationTime); ok {
+			remarks.WeatherEvents = append(remarks.WeatherEvents, events...)
+			continue
+		}
+
+		remarks.Unparsed = append(remarks.Unparsed, token)
+	}
+
+	return remarks
+}
+
+// parseTenths 解析以符号位开头的十分位数值, 如 1017 表示 -1.7
+func parseTenths(sign string, digits string) *float64 {
+	value, _ := strconv.Atoi(digits)
+	result := float64(value) / 10
+	if sign == "1" {
+		result = -result
+	}
+	return &result
+}
+
+// parseRemarkTime 解析备注中的时间, 只有分钟时使用观测时间的小时
+// 结果不会晚于观测时间
+func parseRemarkTime(token string, observationTime time.Time) (time.Time, bool) {
+	match := remarkTimePattern.FindStringSubmatch(token)
+	if match == nil || observationTime.IsZero() {
+		return time.Time{}, false
+	}
+	minute, _ := strconv.Atoi(match[2])
+	if minute > 59 {
+		return time.Time{}, false
+	}
+	if match[1] == "" {
+		result := observationTime.Truncate(time.Hour).Add(time.Duration(minute) * time.Minute)
+		if result.After(observationTime) {
+			result = result.Add(-time.Hour)
+		}
+		return result, true
+	}
+	hour, _ := strconv.Atoi(match[1])
+	if hour > 23 {
+		return time.Time{}, false
+	}
+	result := observationTime.Truncate(24 * time.Hour).Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	if result.After(observationTime) {
+		result = result.Add(-24 * time.Hour)
+	}
+	return result, true
+}
+
+// parsePeakWind 解析峰值风, 如 28045/1955 或 28045/55
+func parsePeakWind(token string, observationTime time.Time) (*metar.PeakWind, bool) {
+	match := peakWindPattern.FindStringSubmatch(token)
+	if match == nil {
+		return nil, false
+	}
+	peakTime, ok := parseRemarkTime(match[3]+match[4], observationTime)
+	if !ok {
+		return nil, false
+	}
+	direction, _ := strconv.Atoi(match[1])
+	speed, _ := strconv.Atoi(match[2])
+	return &metar.PeakWind{Direction: direction, Speed: speed, Time: peakTime}, true
+}
+
+// parseWeatherEvents 解析天气现象开始结束时间, 如 RAB15E30SNB30 FZRAB1159E1240
+func parseWeatherEvents(token string, observationTime time.Time) ([]*metar.WeatherEvent, bool) {
+	if !weatherEventPattern.MatchString(token) {
+		return nil, false
+	}
+	events := make([]*metar.WeatherEvent, 0)
+	for _, part := range weatherEventPartPattern.FindAllStringSubmatch(token, -1) {
+		for _, event := range eventTimePattern.FindAllStringSubmatch(part[2], -1) {
+			eventTime, ok := parseRemarkTime(event[2], observationTime)
+			if !ok {
+				return nil, false
+			}
+			events = append(events, &metar.WeatherEvent{Phenomenon: part[1], Event: event[1], Time: eventTime})
+		}
+	}
+	return events, true
+}
+
+func isSensorOutage(token string) bool {
+	for _, code := range sensorOutageCodes {
+		if token == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package parser
+package parser
+
+import (
+	"metar-service/src/interfaces/metar"
+	"testing"
+	"time"
+)
+
+func TestMetarRemarks(t *testing.T) {
+	result, err := NewMetarParser().ParseAt("METAR KORD 151251Z 28015G28KT 10SM -RA BKN035 08/03 A2985 "+
+		"RMK AO2 PK WND 29032/1228 WSHFT 1215 FROPA RAB05E20SNB20 SLP108 P0001 T00830033 10094 21056 401120056 52012 TSNO PWINO $", reference)
+	if err != nil {
+		t.Fatalf("ParseAt() error = %v", err)
+	}
+	remarks := result.Remarks
+	if remarks == nil {
+		t.Fatal("remarks = nil")
+	}
+	at := func(hour int, minute int) time.Time {
+		return time.Date(2025, time.March, 15, hour, minute, 0, 0, time.UTC)
+	}
+	expectEqual(t, "station type", remarks.StationType, "AO2")
+	expectEqual(t, "peak wind", *remarks.PeakWind, metar.PeakWind{Direction: 290, Speed: 32, Time: at(12, 28)})
+	expectEqual(t, "wind shift", *remarks.WindShift, metar.WindShift{Time: at(12, 15), FrontalPassage: true})
+	expectEqual(t, "sea level pressure", floatValue(remarks.SeaLevelPressure), 1010.8)
+	expectEqual(t, "precise temperature", floatValue(remarks.PreciseTemperature), 8.3)
+	expectEqual(t, "precise dew point", floatValue(remarks.PreciseDewPoint), 3.3)
+	expectEqual(t, "6h max", floatValue(remarks.MaxTemperature6h), 9.4)
+	expectEqual(t, "6h min", floatValue(remarks.MinTemperature6h), -5.6)
+	expectEqual(t, "24h max", floatValue(remarks.MaxTemperature24h), 11.2)
+	expectEqual(t, "24h min", floatValue(remarks.MinTemperature24h), 5.6)
+	expectEqual(t, "pressure tendency", *remarks.PressureTendency, metar.PressureTendency{Character: 2, Change: 1.2})
+	expectEqual(t, "sensor outages", len(remarks.SensorOutages), 2)
+	expectEqual(t, "maintenance", remarks.Maintenance, true)
+	expectEqual(t, "unparsed", len(remarks.Unparsed), 1)
+	expectEqual(t, "unparsed group", remarks.Unparsed[0], "P0001")
+
+	events := []metar.WeatherEvent{
+		{Phenomenon: "RA", Event: metar.WeatherEventBegin, Time: at(12, 5)},
+		{Phenomenon: "RA", Event: metar.WeatherEventEnd, Time: at(12, 20)},
+		{Phenomenon: "SN", Event: metar.WeatherEventBegin, Time: at(12, 20)},
+	}
+	if len(remarks.WeatherEvents) != len(events) {
+		t.Fatalf("weather events = %d, want %d", len(remarks.WeatherEvents), len(events))
+	}
+	for i, want := range events {
+		expectEqual(t, "weather event", *remarks.WeatherEvents[i], want)
+	}
+}
+
+func TestParseRemarkTime(t *testing.T) {
+	observation := time.Date(2025, time.March, 15, 0, 10, 0, 0, time.UTC)
+	tests := []struct {
+		token string
+		want  time.Time
+		ok    bool
+	}{
+		{"05", time.Date(2025, time.March, 15, 0, 5, 0, 0, time.UTC), true},
+		{"55", time.Date(2025, time.March, 14, 23, 55, 0, 0, time.UTC), true},
+		{"2350", time.Date(2025, time.March, 14, 23, 50, 0, 0, time.UTC), true},
+		{"0008", time.Date(2025, time.March, 15, 0, 8, 0, 0, time.UTC), true},
+		{"61", time.Time{}, false},
+		{"2460", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRemarkTime(tt.token, observation)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("parseRemarkTime(%q) = %s, %v, want %s, %v", tt.token, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSeaLevelPressureRange(t *testing.T) {
+	tests := []struct {
+		token string
+		want  float64
+	}{
+		{"SLP982", 998.2},
+		{"SLP500", 950.0},
+		{"SLP499", 1049.9},
+		{"SLP013", 1001.3},
+	}
+	for _, tt := range tests {
+		remarks := parseRemarks([]string{tt.token}, reference)
+		expectEqual[any](t, tt.token, floatValue(remarks.SeaLevelPressure), tt.want)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package parser
+package parser
+
+import (
+	"fmt"
+	"metar-service/src/interfaces/metar"
+	"regexp"
+	"strconv"
+)
+
+var (
+	rvrPattern           = regexp.MustCompile(`^R(\d{2}[LCR]?)/([MP])?(\d{4})(?:V([MP])?(\d{4}))?(FT)?/?([UDN])?$`)
+	motnePattern         = regexp.MustCompile(`^(\d{2})([0-9/])([1259/])(\d{2}|//)(\d{2}|//)$`)
+	runwayStatePattern   = regexp.MustCompile(`^R(\d{2}[LCR]?)/([0-9/])([1259/])(\d{2}|//)(\d{2}|//)$`)
+	runwayClearedPattern = regexp.MustCompile(`^R(\d{2}[LCR]?)/CLRD(\d{2}|//)$`)
+	runwayCodePattern    = regexp.MustCompile(`^R(\d{2}[LCR]?)/([0-6])/([0-6])/([0-6])$`)
+	brakingActions       = map[int]string{
+		91: "POOR",
+		92: "MEDIUM/POOR",
+		93: "MEDIUM",
+		94: "MEDIUM/GOOD",
+		95: "GOOD",
+		99: "UNRELIABLE",
+	}
+)
+
+// parseRunwayVisualRange 解析跑道视程组
+func parseRunwayVisualRange(token string) (*metar.RunwayVisualRange, bool) {
+	match := rvrPattern.FindStringSubmatch(token)
+	if match == nil {
+		return nil, false
+	}
+	value, _ := strconv.Atoi(match[3])
+	rvr := &metar.RunwayVisualRange{
+		Raw:              token,
+		Runway:           match[1],
+		Modifier:         match[2],
+		Value:            value,
+		VariableModifier: match[4],
+		Unit:             metar.VisibilityUnitMeter,
+		Tendency:         match[7],
+	}
+	if match[5] != "" {
+		variable, _ := strconv.Atoi(match[5])
+		rvr.VariableValue = &variable
+	}
+	if match[6] != "" {
+		rvr.Unit = match[6]
+	}
+	return rvr, true
+}
+
+// parseRunwayState 解析跑道状态组
+func parseRunwayState(token string) (*metar.RunwayState, bool) {
+	if match := runwayStatePattern.FindStringSubmatch(token); match != nil {
+		state := &metar.RunwayState{Raw: token, Runway: match[1]}
+		decodeRunwayDeposit(state, match[2], match[3], match[4], match[5])
+		return state, true
+	}
+	if match := motnePattern.FindStringSubmatch(token); match != nil {
+		state := &metar.RunwayState{Raw: token, Runway: motneRunway(match[1])}
+		decodeRunwayDeposit(state, match[2], match[3], match[4], match[5])
+		return state, true
+	}
+	if match := runwayClearedPattern.FindStringSubmatch(token); match != nil {
+		state := &metar.RunwayState{Raw: token, Runway: match[1], Cleared: true}
+		decodeRunwayFriction(state, match[2])
+		return state, true
+	}
+	if match := runwayCodePattern.FindStringSubmatch(token); match != nil {
+		state := &metar.RunwayState{Raw: token, Runway: match[1], ConditionCodes: make([]int, 0, 3)}
+		for _, code := range match[2:] {
+			value, _ := strconv.Atoi(code)
+			state.ConditionCodes = append(state.ConditionCodes, value)
+		}
+		return state, true
+	}
+	if token == "SNOCLO" || token == "R/SNOCLO" {
+		return &metar.RunwayState{Raw: token, Runway: "88", Closed: true}, true
+	}
+	return nil, false
+}
+
+// motneRunway 将MOTNE格式中的跑道代码转换为跑道号, 50以上的代码表示右跑道
+func motneRunway(code string) string {
+	value, _ := strconv.Atoi(code)
+	if value > 50 && value < 88 {
+		return fmt.Sprintf("%02dR", value-50)
+	}
+	return code
+}
+
+func decodeRunwayDeposit(state *metar.RunwayState, deposit, extent, depth, friction string) {
+	if deposit != "/" {
+		state.Deposit = deposit
+	}
+	if extent != "/" {
+		state.Extent = extent
+	}
+	if value, err := strconv.Atoi(depth); err == nil {
+		switch {
+		case value <= 90:
+			state.Depth = intPtr(value)
+		case value >= 92 && value <= 98:
+			// 92至98依次表示10厘米至40厘米
+			state.Depth = intPtr((value - 90) * 50)
+		case value == 99:
+			state.Closed = true
+		}
+	}
+	decodeRunwayFriction(state, friction)
+}
+
+func decodeRunwayFriction(state *metar.RunwayState, friction string) {
+	value, err := strconv.Atoi(friction)
+	if err != nil {
+		return
+	}
+	if value <= 90 {
+		coefficient := float64(value) / 100
+		state.Friction = &coefficient
+		return
+	}
+	state.BrakingAction = brakingActions[value]
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package parser
+package parser
+
+import (
+	"metar-service/src/interfaces/metar"
+	"testing"
+)
+
+func TestParseRunwayVisualRange(t *testing.T) {
+	tests := []struct {
+		token            string
+		runway           string
+		modifier         string
+		value            int
+		variableModifier string
+		variableValue    any
+		unit             string
+		tendency         string
+	}{
+		{"R36L/P1500U", "36L", "P", 1500, "", nil, metar.VisibilityUnitMeter, "U"},
+		{"R18/0600V1000N", "18", "", 600, "", 1000, metar.VisibilityUnitMeter, "N"},
+		{"R09R/M0050", "09R", "M", 50, "", nil, metar.VisibilityUnitMeter, ""},
+		{"R04R/1800V2400FT", "04R", "", 1800, "", 2400, "FT", ""},
+		{"R22L/M0600VP6000FT/D", "22L", "M", 600, "P", 6000, "FT", "D"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			rvr, ok := parseRunwayVisualRange(tt.token)
+			if !ok {
+				t.Fatalf("parseRunwayVisualRange(%q) failed", tt.token)
+			}
+			expectEqual(t, "runway", rvr.Runway, tt.runway)
+			expectEqual(t, "modifier", rvr.Modifier, tt.modifier)
+			expectEqual(t, "value", rvr.Value, tt.value)
+			expectEqual(t, "variable modifier", rvr.VariableModifier, tt.variableModifier)
+			expectEqual(t, "variable value", intValue(rvr.VariableValue), tt.variableValue)
+			expectEqual(t, "unit", rvr.Unit, tt.unit)
+			expectEqual(t, "tendency", rvr.Tendency, tt.tendency)
+		})
+	}
+	for _, token := range []string{"R36L", "R36L/150", "RA", "R360/1500"} {
+		if _, ok := parseRunwayVisualRange(token); ok {
+			t.Errorf("parseRunwayVisualRange(%q) succeeded, want failure", token)
+		}
+	}
+}
+
+func floatValue(value *float64) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func TestParseRunwayState(t *testing.T) {
+	tests := []struct {
+		token          string
+		runway         string
+		deposit        string
+		extent         string
+		depth          any
+		friction       any
+		brakingAction  string
+		cleared        bool
+		closed         bool
+		conditionCodes int
+	}{
+		{"R24/451293", "24", "4", "5", 12, nil, "MEDIUM", false, false, 0},
+		{"R88/2///55", "88", "2", "", nil, 0.55, "", false, false, 0},
+		{"R14L/CLRD95", "14L", "", "", nil, nil, "GOOD", true, false, 0},
+		{"R27/CLRD//", "27", "", "", nil, nil, "", true, false, 0},
+		{"24451293", "24", "4", "5", 12, nil, "MEDIUM", false, false, 0},
+		{"74192691", "24R", "1", "9", 26, nil, "POOR", false, false, 0},
+		{"R24/459595", "24", "4", "5", 250, nil, "GOOD", false, false, 0},
+		{"R06/799999", "06", "7", "9", nil, nil, "UNRELIABLE", false, true, 0},
+		{"R09/5/5/4", "09", "", "", nil, nil, "", false, false, 3},
+		{"SNOCLO", "88", "", "", nil, nil, "", false, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			state, ok := parseRunwayState(tt.token)
+			if !ok {
+				t.Fatalf("parseRunwayState(%q) failed", tt.token)
+			}
+			expectEqual(t, "runway", state.Runway, tt.runway)
+			expectEqual(t, "deposit", state.Deposit, tt.deposit)
+			expectEqual(t, "extent", state.Extent, tt.extent)
+			expectEqual(t, "depth", intValue(state.Depth), tt.depth)
+			expectEqual(t, "friction", floatValue(state.Friction), tt.friction)
+			expectEqual(t, "braking action", state.BrakingAction, tt.brakingAction)
+			expectEqual(t, "cleared", state.Cleared, tt.cleared)
+			expectEqual(t, "closed", state.Closed, tt.closed)
+			expectEqual(t, "condition codes", len(state.ConditionCodes), tt.conditionCodes)
+		})
+	}
+	for _, token := range []string{"R24/45129", "1234567", "R24/CLRD9"} {
+		if _, ok := parseRunwayState(token); ok {
+			t.Errorf("parseRunwayState(%q) succeeded, want failure", token)
+		}
+	}
+}
+
+func TestMetarRunwayGroups(t *testing.T) {
+	result, err := NewMetarParser().ParseAt("METAR UUEE 150900Z 18003MPS 0800 R06L/0550U R06R/0600N FG -SN OVC002 M03/M03 Q1010 R06L/590335", reference)
+	if err != nil {
+		t.Fatalf("ParseAt() error = %v", err)
+	}
+	expectEqual(t, "rvr", len(result.RunwayVisualRanges), 2)
+	expectEqual(t, "runway states", len(result.RunwayStates), 1)
+	expectEqual(t, "state depth", intValue(result.RunwayStates[0].Depth), 3)
+	expectEqual(t, "state friction", floatValue(result.RunwayStates[0].Friction), 0.35)
+	expectEqual(t, "unparsed", len(result.Unparsed), 0)
+}
@@ -0,0 +1,238 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package parser
+package parser
+
+import (
+	"metar-service/src/interfaces/metar"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	validityPattern      = regexp.MustCompile(`^(\d{2})(\d{2})/(\d{2})(\d{2})$`)
+	fromPattern          = regexp.MustCompile(`^FM(\d{2})(\d{2})(\d{2})$`)
+	probabilityPattern   = regexp.MustCompile(`^PROB(30|40)$`)
+	temperatureFcPattern = regexp.MustCompile(`^(TX|TN)(M?\d{2})/(\d{2})(\d{2})Z$`)
+)
+
+type TafParser struct{}
+
+func NewTafParser() *TafParser {
+	return &TafParser{}
+}
+
+func (p *TafParser) Parse(data string) (*metar.Taf, error) {
+	return p.ParseAt(data, time.Now())
+}
+
+// ReportTime 返回报文的发布时间
+func (p *TafParser) ReportTime(data string) (time.Time, error) {
+	result, err := p.Parse(data)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if result.IssueTime.IsZero() {
+		return time.Time{}, metar.ErrReportInvalid
+	}
+	return result.IssueTime, nil
+}
+
+// ParseAt 以reference作为参考时间解析报文, 用于推断报文中省略的年月
+func (p *TafParser) ParseAt(data string, reference time.Time) (*metar.Taf, error) {
+	tokens := tokenize(data)
+	result := &metar.Taf{
+		Raw:          strings.Join(tokens, " "),
+		Periods:      make([]*metar.ForecastPeriod, 0),
+		Temperatures: make([]*metar.TemperatureForecast, 0),
+	}
+
+	index := 0
+	// 报头与修饰组, 如 TAF AMD ZBAA
+header:
+	for ; index < len(tokens); index++ {
+		switch tokens[index] {
+		case "TAF":
+		case "AMD":
+			result.Amendment = true
+		case "COR":
+			result.Correction = true
+		default:
+			break header
+		}
+	}
+	if index >= len(tokens) || !stationPattern.MatchString(tokens[index]) {
+		return nil, metar.ErrReportInvalid
+	}
+	result.Station = tokens[index]
+	index++
+
+	if index < len(tokens) {
+		if issueTime, ok := parseDayTime(tokens[index], reference); ok {
+			result.IssueTime = issueTime
+			reference = issueTime
+			index++
+		}
+	}
+	if index < len(tokens) && tokens[index] == "NIL" {
+		result.Nil = true
+		return result, nil
+	}
+	if index < len(tokens) {
+		if from, to, ok := parseValidity(tokens[index], reference); ok {
+			result.ValidFrom = from
+			result.ValidTo = to
+			index++
+		}
+	}
+	if index < len(tokens) && tokens[index] == "CNL" {
+		result.Cancelled = true
+		return result, nil
+	}
+
+	current := &metar.ForecastPeriod{
+		Type: metar.PeriodTypeBase,
+		From: result.ValidFrom,
+		To:   result.ValidTo,
+	}
+	start := index
+	finish := func(end int) {
+		current.Raw = strings.Join(tokens[start:end], " ")
+		result.Periods = append(result.Periods, current)
+	}
+
+	for index < len(tokens) {
+		token := tokens[index]
+
+		if token == "RMK" {
+			result.Remarks = strings.Join(tokens[index+1:], " ")
+			break
+		}
+
+		if period, consumed := parseChangeHeader(tokens[index:], reference); consumed > 0 {
+			finish(index)
+			current, start = period, index
+			index += consumed
+			continue
+		}
+
+		if temperature, ok := parseTemperatureForecast(token, reference); ok {
+			result.Temperatures = append(result.Temperatures, temperature)
+			index++
+			continue
+		}
+
+		if consumed := parseConditions(tokens[index:], &current.Conditions); consumed > 0 {
+			index += consumed
+			continue
+		}
+
+		current.Unparsed = append(current.Unparsed, token)
+		index++
+	}
+	finish(index)
+
+	closeFromPeriods(result)
+
+	return result, nil
+}
+
+// parseValidity 解析 DDHH/DDHH 格式的有效时段
+func parseValidity(token string, reference time.Time) (time.Time, time.Time, bool) {
+	match := validityPattern.FindStringSubmatch(token)
+	if match == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	fromDay, _ := strconv.Atoi(match[1])
+	fromHour, _ := strconv.Atoi(match[2])
+	toDay, _ := strconv.Atoi(match[3])
+	toHour, _ := strconv.Atoi(match[4])
+	from, ok := resolveDayTime(reference, fromDay, fromHour, 0)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+	// 结束时间以开始时间为参考, 保证跨月的有效时段能被正确推断
+	to, ok := resolveDayTime(from, toDay, toHour, 0)
+	if !ok || to.Before(from) {
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+// parseChangeHeader 解析变化组的起始部分, 如 FM171200 BECMG 1712/1714 PROB30 TEMPO 1712/1718
+// 返回值表示本次消耗的报文组数量, 为0表示不是变化组
+func parseChangeHeader(tokens []string, reference time.Time) (*metar.ForecastPeriod, int) {
+	token := tokens[0]
+	if match := fromPattern.FindStringSubmatch(token); match != nil {
+		day, _ := strconv.Atoi(match[1])
+		hour, _ := strconv.Atoi(match[2])
+		minute, _ := strconv.Atoi(match[3])
+		from, ok := resolveDayTime(reference, day, hour, minute)
+		if !ok {
+			return nil, 0
+		}
+		return &metar.ForecastPeriod{Type: metar.PeriodTypeFrom, From: from}, 1
+	}
+
+	period := &metar.ForecastPeriod{}
+	consumed := 0
+	if match := probabilityPattern.FindStringSubmatch(token); match != nil {
+		period.Type = metar.PeriodTypeProbability
+		period.Probability, _ = strconv.Atoi(match[1])
+		consumed++
+		if consumed < len(tokens) && tokens[consumed] == metar.PeriodTypeTemporary {
+			period.Type = metar.PeriodTypeTemporary
+			consumed++
+		}
+	} else if token == metar.PeriodTypeBecoming || token == metar.PeriodTypeTemporary {
+		period.Type = token
+		consumed++
+	} else {
+		return nil, 0
+	}
+
+	if consumed < len(tokens) {
+		if from, to, ok := parseValidity(tokens[consumed], reference); ok {
+			period.From = from
+			period.To = to
+			consumed++
+		}
+	}
+	return period, consumed
+}
+
+// parseTemperatureForecast 解析最高最低温度预报组, 如 TX15/1706Z TNM02/1722Z
+func parseTemperatureForecast(token string, reference time.Time) (*metar.TemperatureForecast, bool) {
+	match := temperatureFcPattern.FindStringSubmatch(token)
+	if match == nil {
+		return nil, false
+	}
+	value, ok := parseSignedInt(match[2])
+	if !ok {
+		return nil, false
+	}
+	day, _ := strconv.Atoi(match[3])
+	hour, _ := strconv.Atoi(match[4])
+	forecastTime, ok := resolveDayTime(reference, day, hour, 0)
+	if !ok {
+		return nil, false
+	}
+	return &metar.TemperatureForecast{Type: match[1], Value: *value, Time: forecastTime}, true
+}
+
+// closeFromPeriods 补全FM组与基本预报的结束时间
+// FM组持续到下一个FM组开始或报文有效期结束, 基本预报持续到第一个FM组开始
+func closeFromPeriods(taf *metar.Taf) {
+	last := taf.Periods[0]
+	for _, period := range taf.Periods[1:] {
+		if period.Type != metar.PeriodTypeFrom {
+			continue
+		}
+		last.To = period.From
+		last = period
+	}
+	last.To = taf.ValidTo
+}
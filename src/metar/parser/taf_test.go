@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package parser
+package parser
+
+import (
+	"errors"
+	"metar-service/src/interfaces/metar"
+	"testing"
+	"time"
+)
+
+func utc(day int, hour int) time.Time {
+	return time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestTafParserParseAt(t *testing.T) {
+	type period struct {
+		kind        string
+		probability int
+		from        time.Time
+		to          time.Time
+		raw         string
+	}
+	tests := []struct {
+		name    string
+		data    string
+		periods []period
+		check   func(t *testing.T, result *metar.Taf)
+	}{
+		{
+			name: "change groups",
+			data: "TAF ZBAA 151100Z 1512/1618 36005MPS 9999 FEW030 TX15/1506Z TNM02/1522Z " +
+				"BECMG 1520/1522 18004MPS TEMPO 1600/1604 3000 -SHRA PROB30 TEMPO 1608/1612 TSRA FEW030CB=",
+			periods: []period{
+				{metar.PeriodTypeBase, 0, utc(15, 12), utc(16, 18), "36005MPS 9999 FEW030 TX15/1506Z TNM02/1522Z"},
+				{metar.PeriodTypeBecoming, 0, utc(15, 20), utc(15, 22), "BECMG 1520/1522 18004MPS"},
+				{metar.PeriodTypeTemporary, 0, utc(16, 0), utc(16, 4), "TEMPO 1600/1604 3000 -SHRA"},
+				{metar.PeriodTypeTemporary, 30, utc(16, 8), utc(16, 12), "PROB30 TEMPO 1608/1612 TSRA FEW030CB"},
+			},
+			check: func(t *testing.T, result *metar.Taf) {
+				expectEqual(t, "issue time", result.IssueTime, time.Date(2025, time.March, 15, 11, 0, 0, 0, time.UTC))
+				expectEqual(t, "temperatures", len(result.Temperatures), 2)
+				expectEqual(t, "min temperature", *result.Temperatures[1], metar.TemperatureForecast{Type: metar.TemperatureTypeMin, Value: -2, Time: utc(15, 22)})
+				expectEqual(t, "tempo weather", result.Periods[2].Weather[0].Raw, "-SHRA")
+			},
+		},
+		{
+			name: "from groups",
+			data: "TAF AMD KJFK 151140Z 1512/1618 27010KT P6SM SCT050 FM151800 30015G25KT 5SM -RA BKN020 FM160600 VRB03KT P6SM SKC",
+			periods: []period{
+				{metar.PeriodTypeBase, 0, utc(15, 12), utc(15, 18), "27010KT P6SM SCT050"},
+				{metar.PeriodTypeFrom, 0, utc(15, 18), utc(16, 6), "FM151800 30015G25KT 5SM -RA BKN020"},
+				{metar.PeriodTypeFrom, 0, utc(16, 6), utc(16, 18), "FM160600 VRB03KT P6SM SKC"},
+			},
+			check: func(t *testing.T, result *metar.Taf) {
+				expectEqual(t, "amendment", result.Amendment, true)
+				expectEqual(t, "base visibility modifier", result.Periods[0].Visibility.Modifier, "P")
+				expectEqual(t, "from visibility", result.Periods[1].Visibility.Value, 5.0)
+			},
+		},
+		{
+			name: "validity across month end",
+			data: "TAF EGLL 311700Z 3118/0124 22012KT 9999 BKN035 PROB40 0106/0110 4000 RA",
+			periods: []period{
+				{metar.PeriodTypeBase, 0, time.Date(2025, time.March, 31, 18, 0, 0, 0, time.UTC), time.Date(2025, time.April, 2, 0, 0, 0, 0, time.UTC), "22012KT 9999 BKN035"},
+				{metar.PeriodTypeProbability, 40, time.Date(2025, time.April, 1, 6, 0, 0, 0, time.UTC), time.Date(2025, time.April, 1, 10, 0, 0, 0, time.UTC), "PROB40 0106/0110 4000 RA"},
+			},
+		},
+		{
+			name: "cancelled",
+			data: "TAF AMD ZSSS 151300Z 1512/1618 CNL=",
+			check: func(t *testing.T, result *metar.Taf) {
+				expectEqual(t, "cancelled", result.Cancelled, true)
+				expectEqual(t, "periods", len(result.Periods), 0)
+			},
+		},
+		{
+			name: "nil",
+			data: "TAF ZSSS 151100Z NIL=",
+			check: func(t *testing.T, result *metar.Taf) {
+				expectEqual(t, "nil", result.Nil, true)
+			},
+		},
+		{
+			name: "remarks",
+			data: "TAF CYYZ 151140Z 1512/1612 24012KT P6SM BKN030 RMK NXT FCST BY 151800Z",
+			periods: []period{
+				{metar.PeriodTypeBase, 0, utc(15, 12), utc(16, 12), "24012KT P6SM BKN030"},
+			},
+			check: func(t *testing.T, result *metar.Taf) {
+				expectEqual(t, "remarks", result.Remarks, "NXT FCST BY 151800Z")
+			},
+		},
+	}
+	parser := NewTafParser()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parser.ParseAt(tt.data, reference)
+			if err != nil {
+				t.Fatalf("ParseAt() error = %v", err)
+			}
+			if tt.periods != nil && len(result.Periods) != len(tt.periods) {
+				t.Fatalf("periods = %d, want %d", len(result.Periods), len(tt.periods))
+			}
+			for i, want := range tt.periods {
+				got := result.Periods[i]
+				expectEqual(t, "type", got.Type, want.kind)
+				expectEqual(t, "probability", got.Probability, want.probability)
+				expectEqual(t, "from", got.From, want.from)
+				expectEqual(t, "to", got.To, want.to)
+				expectEqual(t, "raw", got.Raw, want.raw)
+				expectEqual(t, "unparsed", len(got.Unparsed), 0)
+			}
+			if tt.check != nil {
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+func TestTafParserInvalid(t *testing.T) {
+	parser := NewTafParser()
+	for _, data := range []string{"", "TAF", "TAF AMD 151100Z 1512/1618"} {
+		if _, err := parser.ParseAt(data, reference); !errors.Is(err, metar.ErrReportInvalid) {
+			t.Errorf("ParseAt(%q) error = %v, want %v", data, err, metar.ErrReportInvalid)
+		}
+	}
+	result, err := parser.ParseAt("TAF ZBAA 151100Z 1518/1512 36005MPS 9999 NSC", reference)
+	if err != nil {
+		t.Fatalf("ParseAt() error = %v", err)
+	}
+	if !result.ValidFrom.IsZero() {
+		t.Errorf("ValidFrom of reversed validity = %s, want zero", result.ValidFrom)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package parser
+package parser
+
+import (
+	"metar-service/src/interfaces/metar"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var trendTimePattern = regexp.MustCompile(`^(FM|TL|AT)(\d{2})(\d{2})$`)
+
+// TrendValidity 趋势预报的有效时长
+const TrendValidity = 2 * time.Hour
+
+// parseTrends 解析METAR中的趋势预报部分, 如 NOSIG BECMG FM1030 TL1100 3000 BR TEMPO AT1200 0800 FG
+// 未指定时间标识的趋势预报适用于观测时间起的两小时
+func parseTrends(tokens []string, observationTime time.Time) []*metar.ForecastPeriod {
+	trends := make([]*metar.ForecastPeriod, 0)
+	var current *metar.ForecastPeriod
+	start := 0
+	finish := func(end int) {
+		if current == nil {
+			return
+		}
+		current.Raw = strings.Join(tokens[start:end], " ")
+		trends = append(trends, current)
+	}
+
+	for index := 0; index < len(tokens); {
+		token := tokens[index]
+		if isTrendKeyword(token) {
+			finish(index)
+			current = &metar.ForecastPeriod{
+				Type: token,
+				From: observationTime,
+				To:   observationTime.Add(TrendValidity),
+			}
+			start = index
+			index++
+			continue
+		}
+		if current == nil {
+			index++
+			continue
+		}
+		if match := trendTimePattern.FindStringSubmatch(token); match != nil && !observationTime.IsZero() {
+			hour, _ := strconv.Atoi(match[2])
+			minute, _ := strconv.Atoi(match[3])
+			markTime := resolveTrendTime(observationTime, hour, minute)
+			switch match[1] {
+			case "FM":
+				current.From = markTime
+			case "TL":
+				current.To = markTime
+			case "AT":
+				current.From = markTime
+				current.To = markTime
+			}
+			index++
+			continue
+		}
+		if consumed := parseConditions(tokens[index:], &current.Conditions); consumed > 0 {
+			index += consumed
+			continue
+		}
+		current.Unparsed = append(current.Unparsed, token)
+		index++
+	}
+	finish(len(tokens))
+
+	return trends
+}
+
+// resolveTrendTime 将趋势预报中的时分推断为观测时间之后最近的时刻
+func resolveTrendTime(observationTime time.Time, hour, minute int) time.Time {
+	day := observationTime.Truncate(24 * time.Hour)
+	result := day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	if result.Before(observationTime.Add(-time.Hour)) {
+		result = result.Add(24 * time.Hour)
+	}
+	return result
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package parser
+package parser
+
+import (
+	"metar-service/src/interfaces/metar"
+	"testing"
+	"time"
+)
+
+func TestMetarTrends(t *testing.T) {
+	observation := time.Date(2025, time.March, 15, 23, 0, 0, 0, time.UTC)
+	type trend struct {
+		kind string
+		from time.Time
+		to   time.Time
+		raw  string
+	}
+	tests := []struct {
+		name   string
+		data   string
+		trends []trend
+	}{
+		{
+			name:   "nosig",
+			data:   "METAR ZBAA 152300Z 36005MPS CAVOK 12/M05 Q1021 NOSIG",
+			trends: []trend{{metar.PeriodTypeNoSignificant, observation, observation.Add(TrendValidity), "NOSIG"}},
+		},
+		{
+			name: "time markers across midnight",
+			data: "METAR EGLL 152300Z 22010KT 9999 BKN030 10/08 Q1010 BECMG FM2330 TL0030 3000 BR TEMPO AT0100 0800 FG",
+			trends: []trend{
+				{metar.PeriodTypeBecoming, observation.Add(30 * time.Minute), observation.Add(90 * time.Minute), "BECMG FM2330 TL0030 3000 BR"},
+				{metar.PeriodTypeTemporary, observation.Add(2 * time.Hour), observation.Add(2 * time.Hour), "TEMPO AT0100 0800 FG"},
+			},
+		},
+		{
+			name:   "trend before remarks",
+			data:   "METAR KJFK 152251Z 27015KT 10SM BKN030 10/02 A2992 TEMPO 2SM -SHRA RMK AO2 SLP132",
+			trends: []trend{{metar.PeriodTypeTemporary, observation.Add(-9 * time.Minute), observation.Add(TrendValidity - 9*time.Minute), "TEMPO 2SM -SHRA"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewMetarParser().ParseAt(tt.data, reference)
+			if err != nil {
+				t.Fatalf("ParseAt() error = %v", err)
+			}
+			if len(result.Trends) != len(tt.trends) {
+				t.Fatalf("trends = %d, want %d", len(result.Trends), len(tt.trends))
+			}
+			for i, want := range tt.trends {
+				got := result.Trends[i]
+				expectEqual(t, "type", got.Type, want.kind)
+				expectEqual(t, "from", got.From, want.from)
+				expectEqual(t, "to", got.To, want.to)
+				expectEqual(t, "raw", got.Raw, want.raw)
+				expectEqual(t, "unparsed", len(got.Unparsed), 0)
+			}
+			expectEqual(t, "unparsed", len(result.Unparsed), 0)
+		})
+	}
+}
+
+func TestMetarTrendConditions(t *testing.T) {
+	result, err := NewMetarParser().ParseAt("METAR ZSSS 151000Z 09004MPS 6000 BR SCT020 15/13 Q1015 BECMG 1500 -RA BKN008", reference)
+	if err != nil {
+		t.Fatalf("ParseAt() error = %v", err)
+	}
+	trend := result.Trends[0]
+	expectEqual(t, "visibility", trend.Visibility.Meters, 1500.0)
+	expectEqual(t, "weather", trend.Weather[0].Raw, "-RA")
+	expectEqual(t, "ceiling", intValue(trend.Ceiling()), 800)
+	expectEqual(t, "wind", trend.Wind == nil, true)
+	expectEqual(t, "observation visibility", result.Visibility.Meters, 6000.0)
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import (
+	"metar-service/src/interfaces/config"
+	"sync"
+	"time"
+)
+
+// minLearnedInterval 学习发报间隔时忽略小于该值的间隔
+const minLearnedInterval = 15 * time.Minute
+
+// maxLearnedCycles 学习发报间隔时忽略超过当前间隔该倍数的间隔, 报文按查询获取, 较长的间隔通常是中间的报文没有被查询
+const maxLearnedCycles = 2
+
+type stationInterval struct {
+	lastRoutine time.Time // 最近一份定时报的观测时间
+	interval    time.Duration
+}
+
+// CachePolicy 根据报文观测时间与站点发报间隔计算缓存时间
+type CachePolicy struct {
+	config   *config.CachePolicyConfig
+	lock     sync.Mutex
+	stations map[string]*stationInterval
+}
+
+func NewCachePolicy(c *config.CachePolicyConfig) *CachePolicy {
+	return &CachePolicy{
+		config:   c,
+		stations: make(map[string]*stationInterval),
+	}
+}
+
+// TTL 缓存到下一份定时报预计可用的时刻, 即下一个发报时刻加发布延迟
+// 特选报(SPECI)与更正报不改变发报时刻, 下一个发报时刻仍按最近的定时报推算
+// 观测时间未知时退回到整点与半点对齐的旧策略
+func (p *CachePolicy) TTL(icao string, observationTime time.Time, routine bool) time.Duration {
+	now := time.Now()
+	if observationTime.IsZero() {
+		return p.clamp(halfHourBoundary(now))
+	}
+	next := p.nextReport(icao, observationTime, routine)
+	return p.clamp(next.Add(p.config.DelayDuration).Sub(now))
+}
+
+func (p *CachePolicy) NegativeTTL(_ string) time.Duration {
+	return p.config.NegativeTTLDuration
+}
+
+func (p *CachePolicy) StaleMaxAge(_ string) time.Duration {
+	return p.config.StaleMaxAgeDuration
+}
+
+// nextReport 返回观测时间之后的下一个定时报发报时刻
+// 定时报以自身为发报时刻, 其他报文以最近的定时报为准, 没有定时报时按发报间隔对齐
+func (p *CachePolicy) nextReport(icao string, observationTime time.Time, routine bool) time.Time {
+	interval, anchor := p.learn(icao, observationTime, routine)
+	if anchor.IsZero() || anchor.After(observationTime) {
+		anchor = observationTime.Truncate(interval)
+	}
+	next := anchor.Add(interval)
+	for !next.After(observationTime) {
+		next = next.Add(interval)
+	}
+	return next
+}
+
+// learn 记录定时报并返回站点的发报间隔与最近的定时报观测时间
+// 配置了发报间隔的站点不学习, 其他站点取相邻两份定时报间隔的最小值
+func (p *CachePolicy) learn(icao string, observationTime time.Time, routine bool) (time.Duration, time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	station, ok := p.stations[icao]
+	if !ok {
+		station = &stationInterval{}
+		p.stations[icao] = station
+	}
+	interval, configured := p.config.StationDurations[icao]
+	if !configured {
+		interval = p.config.IntervalDuration
+		if station.interval > 0 {
+			interval = station.interval
+		}
+	}
+	if !routine {
+		return interval, station.lastRoutine
+	}
+	if station.lastRoutine.IsZero() {
+		station.lastRoutine = observationTime
+		return interval, station.lastRoutine
+	}
+	gap := observationTime.Sub(station.lastRoutine)
+	if gap <= 0 {
+		return interval, station.lastRoutine
+	}
+	station.lastRoutine = observationTime
+	if !configured && gap >= minLearnedInterval && gap <= maxLearnedCycles*interval {
+		if station.interval == 0 || gap < station.interval {
+			station.interval = gap
+		}
+		interval = station.interval
+	}
+	return interval, station.lastRoutine
+}
+
+func (p *CachePolicy) clamp(ttl time.Duration) time.Duration {
+	if ttl < p.config.MinTTLDuration {
+		return p.config.MinTTLDuration
+	}
+	if p.config.MaxTTLDuration > 0 && ttl > p.config.MaxTTLDuration {
+		return p.config.MaxTTLDuration
+	}
+	return ttl
+}
+
+func halfHourBoundary(now time.Time) time.Duration {
+	minute := now.Minute()
+	var addMinutes int
+	if minute < 30 {
+		addMinutes = 30 - minute
+	} else {
+		addMinutes = 60 - minute
+	}
+	return time.Duration(addMinutes) * time.Minute
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import (
+	"metar-service/src/interfaces/config"
+	"testing"
+	"time"
+)
+
+func newTestPolicy(stations map[string]time.Duration) *CachePolicy {
+	return NewCachePolicy(&config.CachePolicyConfig{
+		IntervalDuration: 30 * time.Minute,
+		StationDurations: stations,
+		DelayDuration:    5 * time.Minute,
+		MinTTLDuration:   time.Minute,
+		MaxTTLDuration:   time.Hour,
+	})
+}
+
+func clock(hour int, minute int) time.Time {
+	return time.Date(2025, time.June, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestCachePolicyNextReport(t *testing.T) {
+	type report struct {
+		observation time.Time
+		routine     bool
+	}
+	tests := []struct {
+		name     string
+		stations map[string]time.Duration
+		reports  []report
+		want     time.Time
+	}{
+		{
+			name:    "first routine report uses default interval",
+			reports: []report{{clock(10, 0), true}},
+			want:    clock(10, 30),
+		},
+		{
+			name:    "learn hourly interval",
+			reports: []report{{clock(10, 50), true}, {clock(11, 50), true}},
+			want:    clock(12, 50),
+		},
+		{
+			name:    "ignore gaps between infrequent queries",
+			reports: []report{{clock(10, 0), true}, {clock(13, 0), true}},
+			want:    clock(13, 30),
+		},
+		{
+			name:    "keep minimum gap",
+			reports: []report{{clock(10, 0), true}, {clock(10, 30), true}, {clock(11, 30), true}},
+			want:    clock(12, 0),
+		},
+		{
+			name:    "speci anchors to last routine report",
+			reports: []report{{clock(13, 0), true}, {clock(13, 7), false}},
+			want:    clock(13, 30),
+		},
+		{
+			name:    "speci without routine report aligns to interval",
+			reports: []report{{clock(10, 7), false}},
+			want:    clock(10, 30),
+		},
+		{
+			name:    "speci does not reset learned interval",
+			reports: []report{{clock(10, 50), true}, {clock(11, 50), true}, {clock(12, 10), false}, {clock(12, 50), true}},
+			want:    clock(13, 50),
+		},
+		{
+			name:    "speci after missed routine slots",
+			reports: []report{{clock(10, 0), true}, {clock(11, 42), false}},
+			want:    clock(12, 0),
+		},
+		{
+			name:     "configured interval",
+			stations: map[string]time.Duration{"KJFK": time.Hour},
+			reports:  []report{{clock(10, 51), true}, {clock(11, 21), true}},
+			want:     clock(12, 21),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := newTestPolicy(tt.stations)
+			var got time.Time
+			for _, r := range tt.reports {
+				got = policy.nextReport("KJFK", r.observation, r.routine)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("nextReport() = %s, want %s", got.Format("15:04"), tt.want.Format("15:04"))
+			}
+		})
+	}
+}
+
+func TestCachePolicyTTL(t *testing.T) {
+	policy := newTestPolicy(nil)
+	observation := time.Now().Add(-time.Minute)
+	if got := policy.TTL("ZBAA", observation, true); got < 30*time.Minute || got > 35*time.Minute {
+		t.Errorf("TTL() = %s, want between 30m and 35m", got)
+	}
+	if got := policy.TTL("ZBAA", observation.Add(-3*time.Hour), true); got != time.Minute {
+		t.Errorf("TTL() of outdated report = %s, want min ttl", got)
+	}
+	if got := policy.TTL("ZSSS", time.Time{}, true); got <= 0 || got > 30*time.Minute {
+		t.Errorf("TTL() without observation time = %s, want until next half hour", got)
+	}
+}
+
+func TestRoutineReport(t *testing.T) {
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{"METAR ZBAA 010600Z 36005MPS CAVOK 20/05 Q1015 NOSIG", true},
+		{"ZBAA 010600Z 36005MPS CAVOK 20/05 Q1015 NOSIG", true},
+		{"SPECI ZBAA 010607Z 36005MPS 2000 BR BKN004 20/19 Q1015", false},
+		{"METAR COR ZBAA 010600Z 36005MPS CAVOK 20/05 Q1015", false},
+		{"METAR KJFK 011251Z COR 18010KT 10SM FEW250 25/12 A3001", false},
+		{"TAF AMD ZBAA 010700Z 0106/0212 36005MPS 9999 NSC", false},
+		{"RJTT 010600Z CCA 36005KT 9999 FEW030 20/05 Q1015", false},
+	}
+	for _, tt := range tests {
+		if got := routineReport(tt.data); got != tt.want {
+			t.Errorf("routineReport(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
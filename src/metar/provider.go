@@ -32,8 +32,12 @@ func NewProvider(
 	}
 }
 
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
 func (p *Provider) Get(icao string) (string, error) {
-	if icao == "" || len(icao) != 4 {
+	if !metar.ValidICAO(icao) {
 		return "", metar.ErrICAOInvalid
 	}
 	url := fmt.Sprintf(p.config.Target, icao)
@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import (
+	"math/rand/v2"
+	"metar-service/src/interfaces/config"
+	"sort"
+	"sync"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type stationActivity struct {
+	hits         int       // 当前时间窗口内的查询次数
+	previousHits int       // 上一个时间窗口内的查询次数
+	refreshAt    time.Time // 计划刷新时间
+	refreshing   bool
+}
+
+// Refresher 统计各站点的查询次数, 在热门站点的缓存过期前通过数据源提前刷新
+type Refresher struct {
+	logger      logger.Interface
+	config      *config.RefreshConfig
+	refresh     func(icao string) error
+	lock        sync.Mutex
+	stations    map[string]*stationActivity
+	windowStart time.Time
+	limiter     chan struct{}
+	stop        chan struct{}
+	stopOnce    sync.Once
+}
+
+func NewRefresher(
+	lg logger.Interface,
+	name string,
+	c *config.RefreshConfig,
+	refresh func(icao string) error,
+) *Refresher {
+	return &Refresher{
+		logger:      logger.NewLoggerAdapter(lg, name+"-refresher"),
+		config:      c,
+		refresh:     refresh,
+		stations:    make(map[string]*stationActivity),
+		windowStart: time.Now(),
+		limiter:     make(chan struct{}, c.Concurrency),
+		stop:        make(chan struct{}),
+	}
+}
+
+// Hit 记录一次查询
+func (r *Refresher) Hit(icao string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	station, ok := r.stations[icao]
+	if !ok {
+		if len(r.stations) >= r.config.MaxStations {
+			return
+		}
+		station = &stationActivity{}
+		r.stations[icao] = station
+	}
+	station.hits++
+}
+
+// Schedule 根据缓存过期时间安排刷新, 加入随机偏移使各站点的刷新时间分散
+func (r *Refresher) Schedule(icao string, expireAt time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	station, ok := r.stations[icao]
+	if !ok {
+		return
+	}
+	jitter := time.Duration(rand.Int64N(int64(r.config.AheadDuration)/2 + 1))
+	station.refreshAt = expireAt.Add(-r.config.AheadDuration + jitter)
+	// 缓存时间很短时(如报文超时未更新)不早于缓存时间过半再刷新, 避免频繁请求数据源
+	now := time.Now()
+	if earliest := now.Add(expireAt.Sub(now) / 2); station.refreshAt.Before(earliest) {
+		station.refreshAt = earliest
+	}
+}
+
+func (r *Refresher) Start() {
+	go func() {
+		ticker := time.NewTicker(r.config.IntervalDuration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case now := <-ticker.C:
+				r.scan(now)
+			}
+		}
+	}()
+}
+
+func (r *Refresher) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+}
+
+// scan 找出到达刷新时间的热门站点并刷新, 查询次数多的站点优先
+func (r *Refresher) scan(now time.Time) {
+	r.lock.Lock()
+	if now.Sub(r.windowStart) >= r.config.WindowDuration {
+		r.rotate(now)
+	}
+	due := make([]string, 0)
+	for icao, station := range r.stations {
+		if station.refreshing || station.refreshAt.IsZero() || station.refreshAt.After(now) {
+			continue
+		}
+		if station.hits+station.previousHits < r.config.MinHits {
+			continue
+		}
+		station.refreshing = true
+		due = append(due, icao)
+	}
+	sort.Slice(due, func(i, j int) bool {
+		a, b := r.stations[due[i]], r.stations[due[j]]
+		return a.hits+a.previousHits > b.hits+b.previousHits
+	})
+	r.lock.Unlock()
+
+	for _, icao := range due {
+		select {
+		case <-r.stop:
+			return
+		case r.limiter <- struct{}{}:
+		}
+		go func() {
+			defer func() { <-r.limiter }()
+			err := r.refresh(icao)
+			r.lock.Lock()
+			if station, ok := r.stations[icao]; ok {
+				station.refreshing = false
+				if err != nil {
+					// 刷新失败时等待下一个扫描周期重试, 缓存仍然有效
+					station.refreshAt = time.Now().Add(r.config.IntervalDuration)
+				}
+			}
+			r.lock.Unlock()
+			if err != nil {
+				r.logger.Debugf("Refresh %s fail: %v", icao, err)
+			}
+		}()
+	}
+}
+
+// rotate 切换统计窗口, 移除两个窗口内都没有查询的站点
+func (r *Refresher) rotate(now time.Time) {
+	for icao, station := range r.stations {
+		if station.hits == 0 && station.previousHits == 0 && !station.refreshing {
+			delete(r.stations, icao)
+			continue
+		}
+		station.previousHits = station.hits
+		station.hits = 0
+	}
+	r.windowStart = now
+}
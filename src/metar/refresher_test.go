@@ -0,0 +1,157 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package metar
+package metar
+
+import (
+	"metar-service/src/interfaces/config"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestRefresher 创建刷新调度器, 刷新的站点按完成顺序写入返回的通道
+func newTestRefresher(maxStations int) (*Refresher, chan string) {
+	refreshed := make(chan string, 16)
+	c := &config.RefreshConfig{
+		IntervalDuration: 10 * time.Second,
+		AheadDuration:    2 * time.Minute,
+		WindowDuration:   time.Hour,
+		MinHits:          3,
+		MaxStations:      maxStations,
+		Concurrency:      1,
+	}
+	return &Refresher{
+		config: c,
+		refresh: func(icao string) error {
+			refreshed <- icao
+			return nil
+		},
+		stations:    make(map[string]*stationActivity),
+		windowStart: time.Now(),
+		limiter:     make(chan struct{}, c.Concurrency),
+		stop:        make(chan struct{}),
+	}, refreshed
+}
+
+func hit(r *Refresher, icao string, count int) {
+	for range count {
+		r.Hit(icao)
+	}
+}
+
+func TestRefresherSchedule(t *testing.T) {
+	r, _ := newTestRefresher(10)
+	now := time.Now()
+
+	r.Schedule("ZBAA", now.Add(30*time.Minute))
+	if _, ok := r.stations["ZBAA"]; ok {
+		t.Fatal("Schedule() tracked a station that was never queried")
+	}
+
+	hit(r, "ZBAA", 1)
+	expireAt := now.Add(30 * time.Minute)
+	r.Schedule("ZBAA", expireAt)
+	refreshAt := r.stations["ZBAA"].refreshAt
+	if refreshAt.Before(expireAt.Add(-2*time.Minute)) || refreshAt.After(expireAt.Add(-time.Minute)) {
+		t.Errorf("refreshAt = %s before expiry, want between 1m and 2m", expireAt.Sub(refreshAt))
+	}
+
+	// 缓存时间很短时不早于缓存时间过半刷新
+	expireAt = time.Now().Add(time.Minute)
+	r.Schedule("ZBAA", expireAt)
+	if refreshAt := r.stations["ZBAA"].refreshAt; refreshAt.Before(expireAt.Add(-31 * time.Second)) {
+		t.Errorf("refreshAt = %s before expiry, want at most half of ttl", expireAt.Sub(refreshAt))
+	}
+}
+
+func TestRefresherMaxStations(t *testing.T) {
+	r, _ := newTestRefresher(2)
+	hit(r, "ZBAA", 1)
+	hit(r, "ZSSS", 1)
+	hit(r, "ZGGG", 1)
+	hit(r, "ZBAA", 1)
+	if len(r.stations) != 2 {
+		t.Errorf("stations = %d, want 2", len(r.stations))
+	}
+	if r.stations["ZBAA"].hits != 2 {
+		t.Errorf("ZBAA hits = %d, want 2", r.stations["ZBAA"].hits)
+	}
+}
+
+func TestRefresherScan(t *testing.T) {
+	r, refreshed := newTestRefresher(10)
+	now := time.Now()
+	hit(r, "ZBAA", 5)
+	hit(r, "ZSSS", 10)
+	hit(r, "ZGGG", 2)
+	hit(r, "ZUUU", 5)
+	for _, icao := range []string{"ZBAA", "ZSSS", "ZGGG"} {
+		r.stations[icao].refreshAt = now.Add(-time.Second)
+	}
+	r.stations["ZUUU"].refreshAt = now.Add(time.Minute)
+
+	r.scan(now)
+	got := make([]string, 0)
+	for range 2 {
+		select {
+		case icao := <-refreshed:
+			got = append(got, icao)
+		case <-time.After(time.Second):
+			t.Fatalf("refreshed = %v, want 2 stations", got)
+		}
+	}
+	// 并发数为1时按查询次数从多到少刷新
+	if got[0] != "ZSSS" || got[1] != "ZBAA" {
+		t.Errorf("refreshed = %v, want [ZSSS ZBAA]", got)
+	}
+	select {
+	case icao := <-refreshed:
+		t.Errorf("refreshed unexpected station %s", icao)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestRefresherScanSkipsRefreshing(t *testing.T) {
+	r, _ := newTestRefresher(10)
+	block := make(chan struct{})
+	var count sync.WaitGroup
+	count.Add(1)
+	r.refresh = func(string) error {
+		count.Done()
+		<-block
+		return nil
+	}
+	hit(r, "ZBAA", 3)
+	r.stations["ZBAA"].refreshAt = time.Now().Add(-time.Second)
+
+	r.scan(time.Now())
+	count.Wait()
+	// 刷新未完成时再次扫描不会重复刷新, 否则count会变为负数
+	r.scan(time.Now())
+	close(block)
+}
+
+func TestRefresherRotate(t *testing.T) {
+	r, _ := newTestRefresher(10)
+	hit(r, "ZBAA", 3)
+	hit(r, "ZSSS", 1)
+
+	start := r.windowStart
+	r.scan(start.Add(time.Hour))
+	if station := r.stations["ZBAA"]; station.hits != 0 || station.previousHits != 3 {
+		t.Errorf("ZBAA hits = %d/%d, want 0/3", station.hits, station.previousHits)
+	}
+	// 两个窗口内都没有查询的站点被移除
+	for window := 2; window <= 3; window++ {
+		hit(r, "ZSSS", 1)
+		r.scan(start.Add(time.Duration(window) * time.Hour))
+	}
+	if _, ok := r.stations["ZBAA"]; ok {
+		t.Error("idle station ZBAA was not removed")
+	}
+	if _, ok := r.stations["ZSSS"]; !ok {
+		t.Error("active station ZSSS was removed")
+	}
+}
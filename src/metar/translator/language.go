@@ -0,0 +1,213 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package translator
+package translator
+
+// language 一种语言的翻译词表与格式
+type language struct {
+	groupSeparator   string // 报文组之间的分隔符
+	sentenceEnd      string
+	timeFormat       string // 日 时 分
+	hourFormat       string // 日 时
+	observed         string // 站点 时间
+	auto             string
+	correction       string
+	missing          string
+	amendment        string
+	cancelled        string
+	forecast         string // 站点 开始 结束
+	calm             string
+	windDirection    string
+	windVariable     string
+	windSpeed        string // 数值 单位
+	windGust         string // 数值 单位
+	windVary         string // 起点 终点
+	windUnits        map[string]string
+	cavok            string
+	visibilityGood   string
+	visibilityMeter  string
+	visibilityMile   string
+	lessThan         string
+	moreThan         string
+	visibilityMin    string // 方向 距离
+	directions       map[string]string
+	intensities      map[string]string
+	descriptors      map[string]string
+	descriptorOnly   map[string]string // 只有特征没有现象时的描述, 如 VCSH
+	phenomena        map[string]string
+	phenomenaJoiner  string
+	vicinity         string // 天气现象
+	noSignificantWx  string
+	covers           map[string]string
+	cloudLayer       string // 云量 高度
+	cloudTypes       map[string]string
+	verticalVis      string
+	temperature      string
+	dewPoint         string
+	qnhHectopascal   string
+	qnhInchHg        string
+	recentWeather    string
+	rvr              string // 跑道 数值
+	rvrVariable      string // 数值
+	rvrUnits         map[string]string
+	tendencies       map[string]string
+	runwayState      string // 跑道
+	allRunways       string
+	deposits         map[string]string
+	depth            string
+	friction         string
+	brakingActions   map[string]string
+	runwayCleared    string
+	runwayClosed     string
+	conditionCodes   string
+	trendNoSig       string
+	trendPeriod      map[string]string // 类型 -> 描述
+	trendFrom        string
+	trendUntil       string
+	periodFrom       string            // 时间
+	periodRange      map[string]string // 类型 -> 开始 结束
+	probability      string            // 概率
+	maxTemperature   string            // 温度 时间
+	minTemperature   string            // 温度 时间
+	periodSeparator  string
+	periodIntroducer string
+}
+
+var chinese = &language{
+	groupSeparator:   "，",
+	sentenceEnd:      "。",
+	timeFormat:       "%d日%02d:%02d",
+	hourFormat:       "%d日%02d时",
+	observed:         "%s %s(UTC)观测",
+	auto:             "自动观测",
+	correction:       "更正报",
+	missing:          "缺报",
+	amendment:        "修订报",
+	cancelled:        "已取消",
+	forecast:         "%s 预报有效期%s至%s(UTC)",
+	calm:             "静风",
+	windDirection:    "风向%d度",
+	windVariable:     "风向不定",
+	windSpeed:        "风速%d%s",
+	windGust:         "阵风%d%s",
+	windVary:         "风向在%d度至%d度之间变化",
+	windUnits:        map[string]string{"KT": "节", "MPS": "米/秒", "KMH": "公里/小时"},
+	cavok:            "能见度10公里以上 无低于5000英尺的云 无重要天气",
+	visibilityGood:   "能见度10公里以上",
+	visibilityMeter:  "能见度%s%d米",
+	visibilityMile:   "能见度%s%s英里",
+	lessThan:         "小于",
+	moreThan:         "大于",
+	visibilityMin:    "%s方向最低能见度%d米",
+	directions:       map[string]string{"N": "北", "NE": "东北", "E": "东", "SE": "东南", "S": "南", "SW": "西南", "W": "西", "NW": "西北"},
+	intensities:      map[string]string{"-": "小", "+": "大"},
+	descriptorOnly:   map[string]string{"SH": "阵性降水", "TS": "雷暴"},
+	descriptors:      map[string]string{"MI": "浅", "BC": "散片", "PR": "部分", "DR": "低吹", "BL": "高吹", "SH": "阵", "TS": "雷暴", "FZ": "冻"},
+	phenomena:        map[string]string{"DZ": "毛毛雨", "RA": "雨", "SN": "雪", "SG": "米雪", "IC": "冰晶", "PL": "冰粒", "GR": "冰雹", "GS": "小冰雹", "UP": "未知降水", "BR": "轻雾", "FG": "雾", "FU": "烟", "VA": "火山灰", "DU": "浮尘", "SA": "扬沙", "HZ": "霾", "PY": "飞沫", "PO": "尘卷风", "SQ": "飑", "FC": "漏斗云", "SS": "沙暴", "DS": "尘暴"},
+	phenomenaJoiner:  "夹",
+	vicinity:         "附近有%s",
+	noSignificantWx:  "无重要天气",
+	covers:           map[string]string{"FEW": "少云", "SCT": "疏云", "BKN": "多云", "OVC": "阴天", "SKC": "晴空", "CLR": "晴空", "NSC": "无重要云", "NCD": "未探测到云", "///": "云量不明"},
+	cloudLayer:       "%s%d英尺",
+	cloudTypes:       map[string]string{"CB": "(积雨云)", "TCU": "(浓积云)"},
+	verticalVis:      "垂直能见度%d英尺",
+	temperature:      "气温%d°C",
+	dewPoint:         "露点%d°C",
+	qnhHectopascal:   "修正海压%.0f百帕",
+	qnhInchHg:        "高度表拨正值%.2f英寸汞柱",
+	recentWeather:    "近时%s",
+	rvr:              "跑道%s视程%s",
+	rvrVariable:      "%s至%s",
+	rvrUnits:         map[string]string{"m": "米", "FT": "英尺"},
+	tendencies:       map[string]string{"U": "呈上升趋势", "D": "呈下降趋势", "N": "无明显变化"},
+	runwayState:      "跑道%s",
+	allRunways:       "所有跑道",
+	deposits:         map[string]string{"0": "干燥", "1": "潮湿", "2": "湿或有积水", "3": "霜", "4": "干雪", "5": "湿雪", "6": "雪浆", "7": "冰", "8": "压实的雪", "9": "冻结的车辙"},
+	depth:            "厚度%d毫米",
+	friction:         "摩擦系数%.2f",
+	brakingActions:   map[string]string{"POOR": "刹车效应差", "MEDIUM/POOR": "刹车效应中到差", "MEDIUM": "刹车效应中", "MEDIUM/GOOD": "刹车效应中到好", "GOOD": "刹车效应好", "UNRELIABLE": "刹车效应不可靠"},
+	runwayCleared:    "污染已清除",
+	runwayClosed:     "不可用",
+	conditionCodes:   "跑道状况代码%s",
+	trendNoSig:       "未来两小时无重要变化",
+	trendPeriod:      map[string]string{"BECMG": "趋势: 将转变为", "TEMPO": "趋势: 短时"},
+	trendFrom:        "从%02d:%02d起",
+	trendUntil:       "至%02d:%02d",
+	periodFrom:       "%s起",
+	periodRange:      map[string]string{"BECMG": "%s至%s逐渐转变为", "TEMPO": "%s至%s短时", "PROB": "%s至%s"},
+	probability:      "有%d%%的可能",
+	maxTemperature:   "最高气温%d°C(%s)",
+	minTemperature:   "最低气温%d°C(%s)",
+	periodSeparator:  "；",
+	periodIntroducer: ": ",
+}
+
+var english = &language{
+	groupSeparator:   ", ",
+	sentenceEnd:      ".",
+	timeFormat:       "day %d %02d:%02d",
+	hourFormat:       "day %d %02d:00",
+	observed:         "%s observed %s UTC",
+	auto:             "automated observation",
+	correction:       "corrected report",
+	missing:          "report missing",
+	amendment:        "amended forecast",
+	cancelled:        "cancelled",
+	forecast:         "%s forecast valid from %s to %s UTC",
+	calm:             "wind calm",
+	windDirection:    "wind %03d°",
+	windVariable:     "wind variable",
+	windSpeed:        "at %d %s",
+	windGust:         "gusting %d %s",
+	windVary:         "varying between %03d° and %03d°",
+	windUnits:        map[string]string{"KT": "kt", "MPS": "m/s", "KMH": "km/h"},
+	cavok:            "visibility 10 km or more, no cloud below 5000 ft, no significant weather",
+	visibilityGood:   "visibility 10 km or more",
+	visibilityMeter:  "visibility %s%d m",
+	visibilityMile:   "visibility %s%s SM",
+	lessThan:         "less than ",
+	moreThan:         "more than ",
+	visibilityMin:    "minimum visibility to the %s %d m",
+	directions:       map[string]string{"N": "north", "NE": "northeast", "E": "east", "SE": "southeast", "S": "south", "SW": "southwest", "W": "west", "NW": "northwest"},
+	intensities:      map[string]string{"-": "light ", "+": "heavy "},
+	descriptorOnly:   map[string]string{"SH": "showers", "TS": "thunderstorm"},
+	descriptors:      map[string]string{"MI": "shallow ", "BC": "patches of ", "PR": "partial ", "DR": "low drifting ", "BL": "blowing ", "SH": "showers of ", "TS": "thunderstorm with ", "FZ": "freezing "},
+	phenomena:        map[string]string{"DZ": "drizzle", "RA": "rain", "SN": "snow", "SG": "snow grains", "IC": "ice crystals", "PL": "ice pellets", "GR": "hail", "GS": "small hail", "UP": "unknown precipitation", "BR": "mist", "FG": "fog", "FU": "smoke", "VA": "volcanic ash", "DU": "widespread dust", "SA": "sand", "HZ": "haze", "PY": "spray", "PO": "dust whirls", "SQ": "squalls", "FC": "funnel cloud", "SS": "sandstorm", "DS": "duststorm"},
+	phenomenaJoiner:  " and ",
+	vicinity:         "%s in the vicinity",
+	noSignificantWx:  "no significant weather",
+	covers:           map[string]string{"FEW": "few clouds", "SCT": "scattered clouds", "BKN": "broken clouds", "OVC": "overcast", "SKC": "sky clear", "CLR": "clear below 12000 ft", "NSC": "no significant cloud", "NCD": "no cloud detected", "///": "unknown cloud cover"},
+	cloudLayer:       "%s at %d ft",
+	cloudTypes:       map[string]string{"CB": " (cumulonimbus)", "TCU": " (towering cumulus)"},
+	verticalVis:      "vertical visibility %d ft",
+	temperature:      "temperature %d°C",
+	dewPoint:         "dew point %d°C",
+	qnhHectopascal:   "QNH %.0f hPa",
+	qnhInchHg:        "altimeter %.2f inHg",
+	recentWeather:    "recent %s",
+	rvr:              "runway %s visual range %s",
+	rvrVariable:      "%s to %s",
+	rvrUnits:         map[string]string{"m": " m", "FT": " ft"},
+	tendencies:       map[string]string{"U": " increasing", "D": " decreasing", "N": " no change"},
+	runwayState:      "runway %s",
+	allRunways:       "all runways",
+	deposits:         map[string]string{"0": "clear and dry", "1": "damp", "2": "wet or water patches", "3": "rime or frost", "4": "dry snow", "5": "wet snow", "6": "slush", "7": "ice", "8": "compacted snow", "9": "frozen ruts"},
+	depth:            "depth %d mm",
+	friction:         "friction coefficient %.2f",
+	brakingActions:   map[string]string{"POOR": "braking action poor", "MEDIUM/POOR": "braking action medium to poor", "MEDIUM": "braking action medium", "MEDIUM/GOOD": "braking action medium to good", "GOOD": "braking action good", "UNRELIABLE": "braking action unreliable"},
+	runwayCleared:    "contamination cleared",
+	runwayClosed:     "closed",
+	conditionCodes:   "runway condition codes %s",
+	trendNoSig:       "no significant change expected in the next two hours",
+	trendPeriod:      map[string]string{"BECMG": "trend: becoming", "TEMPO": "trend: temporarily"},
+	trendFrom:        "from %02d:%02d",
+	trendUntil:       "until %02d:%02d",
+	periodFrom:       "from %s",
+	periodRange:      map[string]string{"BECMG": "becoming between %s and %s", "TEMPO": "temporarily between %s and %s", "PROB": "between %s and %s"},
+	probability:      "%d%% probability",
+	maxTemperature:   "maximum temperature %d°C at %s",
+	minTemperature:   "minimum temperature %d°C at %s",
+	periodSeparator:  "; ",
+	periodIntroducer: ": ",
+}
@@ -0,0 +1,332 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package translator
+package translator
+
+import (
+	"fmt"
+	"metar-service/src/interfaces/metar"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	LanguageChinese = "zh"
+	LanguageEnglish = "en"
+)
+
+var languages = map[string]*language{
+	LanguageChinese: chinese,
+	LanguageEnglish: english,
+}
+
+// Translator 将解析后的METAR与TAF翻译为自然语言
+type Translator struct {
+}
+
+func NewTranslator() *Translator {
+	return &Translator{}
+}
+
+func (t *Translator) TranslateMetar(data *metar.Metar, lang string) (string, error) {
+	l, ok := languages[lang]
+	if !ok {
+		return "", metar.ErrLanguageNotSupported
+	}
+
+	header := []string{fmt.Sprintf(l.observed, data.Station, l.formatTime(data.Time))}
+	if data.Auto {
+		header = append(header, l.auto)
+	}
+	if data.Correction {
+		header = append(header, l.correction)
+	}
+	if data.Nil {
+		header = append(header, l.missing)
+		return strings.Join(header, l.groupSeparator) + l.sentenceEnd, nil
+	}
+
+	groups := append(header, l.conditions(&data.Conditions)...)
+	for _, rvr := range data.RunwayVisualRanges {
+		groups = append(groups, l.runwayVisualRangeGroup(rvr))
+	}
+	for _, state := range data.RunwayStates {
+		groups = append(groups, l.runwayStateGroup(state))
+	}
+	if data.Temperature != nil {
+		groups = append(groups, fmt.Sprintf(l.temperature, *data.Temperature))
+	}
+	if data.DewPoint != nil {
+		groups = append(groups, fmt.Sprintf(l.dewPoint, *data.DewPoint))
+	}
+	if data.Pressure != nil {
+		if data.Pressure.Unit == metar.PressureUnitInchHg {
+			groups = append(groups, fmt.Sprintf(l.qnhInchHg, data.Pressure.Value))
+		} else {
+			groups = append(groups, fmt.Sprintf(l.qnhHectopascal, data.Pressure.Value))
+		}
+	}
+	for _, weather := range data.RecentWeather {
+		groups = append(groups, fmt.Sprintf(l.recentWeather, l.weather(weather)))
+	}
+
+	sentences := []string{strings.Join(groups, l.groupSeparator)}
+	for _, trend := range data.Trends {
+		sentences = append(sentences, l.trend(trend, data.Time))
+	}
+	return strings.Join(sentences, l.periodSeparator) + l.sentenceEnd, nil
+}
+
+func (t *Translator) TranslateTaf(data *metar.Taf, lang string) (string, error) {
+	l, ok := languages[lang]
+	if !ok {
+		return "", metar.ErrLanguageNotSupported
+	}
+
+	header := []string{fmt.Sprintf(l.forecast, data.Station, l.formatHour(data.ValidFrom), l.formatHour(data.ValidTo))}
+	if data.Amendment {
+		header = append(header, l.amendment)
+	}
+	if data.Correction {
+		header = append(header, l.correction)
+	}
+	if data.Nil {
+		header = append(header, l.missing)
+	}
+	if data.Cancelled {
+		header = append(header, l.cancelled)
+	}
+	if data.Nil || data.Cancelled {
+		return strings.Join(header, l.groupSeparator) + l.sentenceEnd, nil
+	}
+
+	sentences := make([]string, 0, len(data.Periods)+1)
+	for _, period := range data.Periods {
+		groups := l.conditions(&period.Conditions)
+		if period.Type == metar.PeriodTypeBase {
+			sentences = append(sentences, strings.Join(append(header, groups...), l.groupSeparator))
+			continue
+		}
+		sentences = append(sentences, l.periodPrefix(period)+l.periodIntroducer+strings.Join(groups, l.groupSeparator))
+	}
+	if len(sentences) == 0 {
+		sentences = append(sentences, strings.Join(header, l.groupSeparator))
+	}
+
+	temperatures := make([]string, 0, len(data.Temperatures))
+	for _, temperature := range data.Temperatures {
+		format := l.maxTemperature
+		if temperature.Type == metar.TemperatureTypeMin {
+			format = l.minTemperature
+		}
+		temperatures = append(temperatures, fmt.Sprintf(format, temperature.Value, l.formatHour(temperature.Time)))
+	}
+	if len(temperatures) > 0 {
+		sentences = append(sentences, strings.Join(temperatures, l.groupSeparator))
+	}
+
+	return strings.Join(sentences, l.periodSeparator) + l.sentenceEnd, nil
+}
+
+func (l *language) formatTime(t time.Time) string {
+	return fmt.Sprintf(l.timeFormat, t.Day(), t.Hour(), t.Minute())
+}
+
+func (l *language) formatHour(t time.Time) string {
+	return fmt.Sprintf(l.hourFormat, t.Day(), t.Hour())
+}
+
+// conditions 翻译风、能见度、天气现象与云组
+func (l *language) conditions(conditions *metar.Conditions) []string {
+	groups := make([]string, 0)
+	if conditions.Wind != nil {
+		groups = append(groups, l.wind(conditions.Wind))
+	}
+	if conditions.Cavok {
+		groups = append(groups, l.cavok)
+	} else if conditions.Visibility != nil {
+		groups = append(groups, l.visibility(conditions.Visibility)...)
+	}
+	for _, weather := range conditions.Weather {
+		groups = append(groups, l.weather(weather))
+	}
+	if conditions.NoSignificantWx {
+		groups = append(groups, l.noSignificantWx)
+	}
+	for _, cloud := range conditions.Clouds {
+		groups = append(groups, l.cloud(cloud))
+	}
+	if conditions.VerticalVisibility != nil {
+		groups = append(groups, fmt.Sprintf(l.verticalVis, *conditions.VerticalVisibility))
+	}
+	return groups
+}
+
+func (l *language) wind(wind *metar.Wind) string {
+	if wind.Speed != nil && *wind.Speed == 0 && (wind.Direction == nil || *wind.Direction == 0) {
+		return l.calm
+	}
+	unit := l.windUnits[wind.Unit]
+	parts := make([]string, 0, 4)
+	if wind.Variable {
+		parts = append(parts, l.windVariable)
+	} else if wind.Direction != nil {
+		parts = append(parts, fmt.Sprintf(l.windDirection, *wind.Direction))
+	}
+	if wind.Speed != nil {
+		parts = append(parts, fmt.Sprintf(l.windSpeed, *wind.Speed, unit))
+	}
+	if wind.Gust != nil {
+		parts = append(parts, fmt.Sprintf(l.windGust, *wind.Gust, unit))
+	}
+	if wind.VariableFrom != nil && wind.VariableTo != nil {
+		parts = append(parts, fmt.Sprintf(l.windVary, *wind.VariableFrom, *wind.VariableTo))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (l *language) modifier(modifier string) string {
+	switch modifier {
+	case "M":
+		return l.lessThan
+	case "P":
+		return l.moreThan
+	default:
+		return ""
+	}
+}
+
+func (l *language) visibility(visibility *metar.Visibility) []string {
+	groups := make([]string, 0, 2)
+	switch {
+	case visibility.Unit == metar.VisibilityUnitStatuteMile:
+		value := strconv.FormatFloat(visibility.Value, 'f', -1, 64)
+		groups = append(groups, fmt.Sprintf(l.visibilityMile, l.modifier(visibility.Modifier), value))
+	case visibility.Meters >= 10000:
+		groups = append(groups, l.visibilityGood)
+	default:
+		groups = append(groups, fmt.Sprintf(l.visibilityMeter, l.modifier(visibility.Modifier), int(visibility.Value)))
+	}
+	if visibility.Minimum != nil {
+		direction := l.directions[visibility.Minimum.Direction]
+		groups = append(groups, fmt.Sprintf(l.visibilityMin, direction, visibility.Minimum.Distance))
+	}
+	return groups
+}
+
+func (l *language) weather(weather *metar.Weather) string {
+	phenomena := make([]string, 0, len(weather.Phenomena))
+	for _, phenomenon := range weather.Phenomena {
+		if name, ok := l.phenomena[phenomenon]; ok {
+			phenomena = append(phenomena, name)
+		} else {
+			phenomena = append(phenomena, phenomenon)
+		}
+	}
+
+	var result string
+	if len(phenomena) == 0 {
+		result = l.descriptorOnly[weather.Descriptor]
+		if result == "" {
+			result = strings.TrimSpace(l.descriptors[weather.Descriptor])
+		}
+	} else {
+		result = l.descriptors[weather.Descriptor] + strings.Join(phenomena, l.phenomenaJoiner)
+	}
+	if result == "" {
+		return weather.Raw
+	}
+	if weather.Intensity == "VC" {
+		return fmt.Sprintf(l.vicinity, result)
+	}
+	return l.intensities[weather.Intensity] + result
+}
+
+func (l *language) cloud(cloud *metar.Cloud) string {
+	cover, ok := l.covers[cloud.Cover]
+	if !ok {
+		cover = cloud.Cover
+	}
+	if cloud.Height == nil {
+		return cover + l.cloudTypes[cloud.Type]
+	}
+	return fmt.Sprintf(l.cloudLayer, cover, *cloud.Height) + l.cloudTypes[cloud.Type]
+}
+
+func (l *language) rvrValue(modifier string, value int, unit string) string {
+	return l.modifier(modifier) + strconv.Itoa(value) + l.rvrUnits[unit]
+}
+
+func (l *language) runwayVisualRangeGroup(rvr *metar.RunwayVisualRange) string {
+	value := l.rvrValue(rvr.Modifier, rvr.Value, rvr.Unit)
+	if rvr.VariableValue != nil {
+		value = fmt.Sprintf(l.rvrVariable, value, l.rvrValue(rvr.VariableModifier, *rvr.VariableValue, rvr.Unit))
+	}
+	return fmt.Sprintf(l.rvr, rvr.Runway, value) + l.tendencies[rvr.Tendency]
+}
+
+func (l *language) runwayStateGroup(state *metar.RunwayState) string {
+	runway := fmt.Sprintf(l.runwayState, state.Runway)
+	if state.Runway == "88" {
+		runway = l.allRunways
+	}
+	parts := []string{runway}
+	if deposit, ok := l.deposits[state.Deposit]; ok {
+		parts = append(parts, deposit)
+	}
+	if state.Depth != nil {
+		parts = append(parts, fmt.Sprintf(l.depth, *state.Depth))
+	}
+	if state.Friction != nil {
+		parts = append(parts, fmt.Sprintf(l.friction, *state.Friction))
+	}
+	if action, ok := l.brakingActions[state.BrakingAction]; ok {
+		parts = append(parts, action)
+	}
+	if state.Cleared {
+		parts = append(parts, l.runwayCleared)
+	}
+	if state.Closed {
+		parts = append(parts, l.runwayClosed)
+	}
+	if len(state.ConditionCodes) > 0 {
+		codes := make([]string, 0, len(state.ConditionCodes))
+		for _, code := range state.ConditionCodes {
+			codes = append(codes, strconv.Itoa(code))
+		}
+		parts = append(parts, fmt.Sprintf(l.conditionCodes, strings.Join(codes, "/")))
+	}
+	return strings.Join(parts, " ")
+}
+
+// trend 翻译趋势预报, 起止时间与默认有效期一致时省略
+func (l *language) trend(trend *metar.ForecastPeriod, observationTime time.Time) string {
+	if trend.Type == metar.PeriodTypeNoSignificant {
+		return l.trendNoSig
+	}
+	prefix := l.trendPeriod[trend.Type]
+	if !trend.From.IsZero() && !trend.From.Equal(observationTime) {
+		prefix += " " + fmt.Sprintf(l.trendFrom, trend.From.Hour(), trend.From.Minute())
+	}
+	if !trend.To.IsZero() && !trend.To.Equal(observationTime.Add(2*time.Hour)) {
+		prefix += " " + fmt.Sprintf(l.trendUntil, trend.To.Hour(), trend.To.Minute())
+	}
+	return prefix + l.periodIntroducer + strings.Join(l.conditions(&trend.Conditions), l.groupSeparator)
+}
+
+func (l *language) periodPrefix(period *metar.ForecastPeriod) string {
+	if period.Type == metar.PeriodTypeFrom {
+		return fmt.Sprintf(l.periodFrom, l.formatTime(period.From))
+	}
+	prefix := ""
+	if period.Probability > 0 {
+		prefix = fmt.Sprintf(l.probability, period.Probability) + " "
+	}
+	format, ok := l.periodRange[period.Type]
+	if !ok {
+		format = l.periodRange[metar.PeriodTypeProbability]
+	}
+	return prefix + fmt.Sprintf(format, l.formatHour(period.From), l.formatHour(period.To))
+}
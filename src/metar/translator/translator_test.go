@@ -0,0 +1,111 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package translator
+package translator
+
+import (
+	"errors"
+	"metar-service/src/interfaces/metar"
+	"metar-service/src/metar/parser"
+	"testing"
+	"time"
+)
+
+var reference = time.Date(2025, time.March, 15, 12, 0, 0, 0, time.UTC)
+
+func TestTranslateMetar(t *testing.T) {
+	tests := []struct {
+		data string
+		zh   string
+		en   string
+	}{
+		{
+			data: "METAR ZBAA 151100Z 36005MPS 320V040 9999 FEW030 SCT100 12/M05 Q1021 NOSIG",
+			zh:   "ZBAA 15日11:00(UTC)观测，风向360度 风速5米/秒 风向在320度至40度之间变化，能见度10公里以上，少云3000英尺，疏云10000英尺，气温12°C，露点-5°C，修正海压1021百帕；未来两小时无重要变化。",
+			en: "ZBAA observed day 15 11:00 UTC, wind 360° at 5 m/s varying between 320° and 040°, visibility 10 km or more, " +
+				"few clouds at 3000 ft, scattered clouds at 10000 ft, temperature 12°C, dew point -5°C, QNH 1021 hPa; no significant change expected in the next two hours.",
+		},
+		{
+			data: "SPECI KJFK 151151Z 27015G25KT 1 1/2SM R04R/2000V4000FT -SHRA BR BKN008 OVC015CB 08/07 A2992",
+			zh:   "KJFK 15日11:51(UTC)观测，风向270度 风速15节 阵风25节，能见度1.5英里，小阵雨，轻雾，多云800英尺，阴天1500英尺(积雨云)，跑道04R视程2000英尺至4000英尺，气温8°C，露点7°C，高度表拨正值29.92英寸汞柱。",
+			en: "KJFK observed day 15 11:51 UTC, wind 270° at 15 kt gusting 25 kt, visibility 1.5 SM, light showers of rain, mist, " +
+				"broken clouds at 800 ft, overcast at 1500 ft (cumulonimbus), runway 04R visual range 2000 ft to 4000 ft, temperature 8°C, dew point 7°C, altimeter 29.92 inHg.",
+		},
+		{
+			data: "METAR LFPG 150630Z VRB02KT 0200 R27L/0350N FG VV001 05/05 Q1018 R27L/451293 BECMG FM0700 3000 BR",
+			zh:   "LFPG 15日06:30(UTC)观测，风向不定 风速2节，能见度200米，雾，垂直能见度100英尺，跑道27L视程350米无明显变化，跑道27L 干雪 厚度12毫米 刹车效应中，气温5°C，露点5°C，修正海压1018百帕；趋势: 将转变为 从07:00起: 能见度3000米，轻雾。",
+			en: "LFPG observed day 15 06:30 UTC, wind variable at 2 kt, visibility 200 m, fog, vertical visibility 100 ft, runway 27L visual range 350 m no change, " +
+				"runway 27L dry snow depth 12 mm braking action medium, temperature 5°C, dew point 5°C, QNH 1018 hPa; trend: becoming from 07:00: visibility 3000 m, mist.",
+		},
+		{
+			data: "METAR EGLL 150950Z 00000KT CAVOK M01/M03 Q1030",
+			zh:   "EGLL 15日09:50(UTC)观测，静风，能见度10公里以上 无低于5000英尺的云 无重要天气，气温-1°C，露点-3°C，修正海压1030百帕。",
+			en: "EGLL observed day 15 09:50 UTC, wind calm, visibility 10 km or more, no cloud below 5000 ft, no significant weather, " +
+				"temperature -1°C, dew point -3°C, QNH 1030 hPa.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			data, err := parser.NewMetarParser().ParseAt(tt.data, reference)
+			if err != nil {
+				t.Fatalf("ParseAt() error = %v", err)
+			}
+			for lang, want := range map[string]string{LanguageChinese: tt.zh, LanguageEnglish: tt.en} {
+				if got, err := NewTranslator().TranslateMetar(data, lang); err != nil || got != want {
+					t.Errorf("TranslateMetar(%s) = %q, %v\nwant %q", lang, got, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTranslateTaf(t *testing.T) {
+	tests := []struct {
+		data string
+		zh   string
+		en   string
+	}{
+		{
+			data: "TAF ZBAA 151100Z 1512/1618 36005MPS 9999 FEW030 TX15/1606Z TNM02/1522Z BECMG 1520/1522 18004MPS " +
+				"TEMPO 1600/1604 3000 -SHRA PROB30 TEMPO 1608/1612 TSRA FEW030CB",
+			zh: "ZBAA 预报有效期15日12时至16日18时(UTC)，风向360度 风速5米/秒，能见度10公里以上，少云3000英尺；15日20时至15日22时逐渐转变为: 风向180度 风速4米/秒；" +
+				"16日00时至16日04时短时: 能见度3000米，小阵雨；有30%的可能 16日08时至16日12时短时: 雷暴雨，少云3000英尺(积雨云)；最高气温15°C(16日06时)，最低气温-2°C(15日22时)。",
+			en: "ZBAA forecast valid from day 15 12:00 to day 16 18:00 UTC, wind 360° at 5 m/s, visibility 10 km or more, few clouds at 3000 ft; " +
+				"becoming between day 15 20:00 and day 15 22:00: wind 180° at 4 m/s; temporarily between day 16 00:00 and day 16 04:00: visibility 3000 m, light showers of rain; " +
+				"30% probability temporarily between day 16 08:00 and day 16 12:00: thunderstorm with rain, few clouds at 3000 ft (cumulonimbus); " +
+				"maximum temperature 15°C at day 16 06:00, minimum temperature -2°C at day 15 22:00.",
+		},
+		{
+			data: "TAF KJFK 151140Z 1512/1618 27010KT P6SM SCT050 FM151800 30015G25KT 5SM -RA BKN020 PROB40 1602/1606 2SM +TSRA OVC010CB",
+			zh: "KJFK 预报有效期15日12时至16日18时(UTC)，风向270度 风速10节，能见度大于6英里，疏云5000英尺；15日18:00起: 风向300度 风速15节 阵风25节，能见度5英里，小雨，多云2000英尺；" +
+				"有40%的可能 16日02时至16日06时: 能见度2英里，大雷暴雨，阴天1000英尺(积雨云)。",
+			en: "KJFK forecast valid from day 15 12:00 to day 16 18:00 UTC, wind 270° at 10 kt, visibility more than 6 SM, scattered clouds at 5000 ft; " +
+				"from day 15 18:00: wind 300° at 15 kt gusting 25 kt, visibility 5 SM, light rain, broken clouds at 2000 ft; " +
+				"40% probability between day 16 02:00 and day 16 06:00: visibility 2 SM, heavy thunderstorm with rain, overcast at 1000 ft (cumulonimbus).",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			data, err := parser.NewTafParser().ParseAt(tt.data, reference)
+			if err != nil {
+				t.Fatalf("ParseAt() error = %v", err)
+			}
+			for lang, want := range map[string]string{LanguageChinese: tt.zh, LanguageEnglish: tt.en} {
+				if got, err := NewTranslator().TranslateTaf(data, lang); err != nil || got != want {
+					t.Errorf("TranslateTaf(%s) = %q, %v\nwant %q", lang, got, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTranslateUnsupportedLanguage(t *testing.T) {
+	data, err := parser.NewMetarParser().ParseAt("METAR ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021", reference)
+	if err != nil {
+		t.Fatalf("ParseAt() error = %v", err)
+	}
+	if _, err := NewTranslator().TranslateMetar(data, "fr"); !errors.Is(err, metar.ErrLanguageNotSupported) {
+		t.Errorf("TranslateMetar(fr) error = %v, want %v", err, metar.ErrLanguageNotSupported)
+	}
+}
@@ -0,0 +1,363 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package override
+package override
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"metar-service/src/interfaces/metar"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"half-nothing.cn/service-core/interfaces/cache"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+const (
+	// defaultScenarioTail 未指定持续时间时, 场景在最后一步生效后持续的时间
+	defaultScenarioTail = time.Hour
+	// sharedScenarioKey 共享缓存中保存正在运行的场景的键
+	sharedScenarioKey = "scenario"
+	// scenarioLockTTL 启动时加载场景持有跨实例锁的最长时间
+	scenarioLockTTL = 10 * time.Second
+)
+
+// sharedScenario 共享缓存中正在运行的场景, ID标识运行场景的实例
+type sharedScenario struct {
+	ID     string                `json:"id"`
+	Status *metar.ScenarioStatus `json:"status"`
+}
+
+// plannedStep 解析后的场景步骤, 时间均为距场景开始的时间
+type plannedStep struct {
+	reportType string
+	icao       string
+	data       string
+	at         time.Duration
+	expireAt   time.Duration
+}
+
+// ParseScenario 解析YAML或JSON格式的场景脚本
+func ParseScenario(data []byte) (*metar.Scenario, error) {
+	scenario := &metar.Scenario{}
+	if err := yaml.Unmarshal(data, scenario); err != nil {
+		return nil, fmt.Errorf("%w: %v", metar.ErrScenarioInvalid, err)
+	}
+	return scenario, nil
+}
+
+// Runner 按场景脚本定时设置管理员指定报文, 同一时间只运行一个场景
+// 设置共享缓存后场景状态保存在共享缓存中, 任意实例都可以查看与停止场景, 步骤由加载场景的实例推进
+type Runner struct {
+	logger  logger.Interface
+	stores  map[string]metar.OverrideInterface
+	shared  cache.Interface[string, *string]
+	locker  metar.LockerInterface
+	lock    sync.Mutex
+	timers  []*time.Timer
+	running *metar.ScenarioStatus
+	id      string // 本实例运行的场景ID
+}
+
+func NewRunner(
+	lg logger.Interface,
+	metarStore metar.OverrideInterface,
+	tafStore metar.OverrideInterface,
+) *Runner {
+	return &Runner{
+		logger: logger.NewLoggerAdapter(lg, "scenario-runner"),
+		stores: map[string]metar.OverrideInterface{
+			metar.ReportTypeMetar: metarStore,
+			metar.ReportTypeTaf:   tafStore,
+		},
+	}
+}
+
+// SetShared 设置保存场景状态的共享缓存, 指定报文的存储也需要在实例间共享
+func (r *Runner) SetShared(shared cache.Interface[string, *string]) {
+	r.shared = shared
+	// 共享缓存提供跨实例的互斥锁, 保证多个实例同时启动时只有一个实例加载场景
+	if locker, ok := shared.(metar.LockerInterface); ok {
+		r.locker = locker
+	}
+}
+
+// Load 停止正在运行的场景并从现在开始运行新场景, 其他实例运行的场景同样会被替换
+func (r *Runner) Load(scenario *metar.Scenario) error {
+	steps, end, err := r.plan(scenario)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.stop()
+
+	start := time.Now()
+	status := &metar.ScenarioStatus{
+		Name:      scenario.Name,
+		StartTime: start,
+		EndTime:   start.Add(end),
+		Steps:     len(steps),
+	}
+	r.running = status
+	r.id = scenarioID()
+	r.save()
+	for _, step := range steps {
+		r.timers = append(r.timers, time.AfterFunc(step.at, func() { r.apply(status, step) }))
+	}
+	r.timers = append(r.timers, time.AfterFunc(end, func() { r.finish(status) }))
+
+	r.logger.Infof("Scenario %s started with %d steps, ends at %s", scenario.Name, len(steps), status.EndTime.Format(time.RFC3339))
+	return nil
+}
+
+// LoadIdle 集群内没有正在运行的场景时加载场景, 返回是否加载
+// 用于启动时加载场景, 多个实例同时启动或重启时只有一个实例运行场景
+func (r *Runner) LoadIdle(scenario *metar.Scenario) (bool, error) {
+	if r.locker != nil {
+		acquired, err := r.locker.TryLock(sharedScenarioKey, scenarioLockTTL)
+		if err != nil {
+			return false, fmt.Errorf("fail to acquire scenario lock: %w", err)
+		}
+		if !acquired {
+			return false, nil
+		}
+		defer r.locker.Unlock(sharedScenarioKey)
+	}
+	if status := r.Status(); status != nil {
+		r.logger.Infof("Scenario %s is already running, skip loading %s", status.Name, scenario.Name)
+		return false, nil
+	}
+	return true, r.Load(scenario)
+}
+
+// Stop 停止正在运行的场景, 使用共享缓存时同样停止其他实例运行的场景
+func (r *Runner) Stop() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.stop()
+}
+
+// Close 服务退出时取消本实例的定时器, 本实例运行的场景无法继续推进, 同时停止该场景
+func (r *Runner) Close() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.owned() {
+		r.stop()
+		return
+	}
+	r.abandon()
+}
+
+func (r *Runner) Status() *metar.ScenarioStatus {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.status()
+}
+
+// status 返回正在运行的场景状态的副本, 调用方需持有锁
+func (r *Runner) status() *metar.ScenarioStatus {
+	if r.shared != nil {
+		if record := r.load(); record != nil && time.Now().Before(record.Status.EndTime) {
+			return record.Status
+		}
+		return nil
+	}
+	if r.running == nil {
+		return nil
+	}
+	status := *r.running
+	return &status
+}
+
+// stop 取消未生效的步骤并删除场景设置的报文, 调用方需持有锁
+func (r *Runner) stop() {
+	status := r.status()
+	r.abandon()
+	if status == nil {
+		return
+	}
+	for _, store := range r.stores {
+		store.DeleteSource(metar.OverrideSourceScenario)
+	}
+	if r.shared != nil {
+		r.shared.Del(sharedScenarioKey)
+	}
+	r.logger.Infof("Scenario %s stopped", status.Name)
+}
+
+// abandon 只取消本实例的定时器, 不影响其他实例运行的场景, 调用方需持有锁
+func (r *Runner) abandon() {
+	for _, timer := range r.timers {
+		timer.Stop()
+	}
+	r.timers = nil
+	r.running = nil
+	r.id = ""
+}
+
+// owned 本实例运行的场景是否仍是集群内正在运行的场景, 调用方需持有锁
+func (r *Runner) owned() bool {
+	if r.running == nil {
+		return false
+	}
+	if r.shared == nil {
+		return true
+	}
+	record := r.load()
+	return record != nil && record.ID == r.id
+}
+
+func (r *Runner) apply(status *metar.ScenarioStatus, step *plannedStep) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	// 定时器触发时场景可能已被替换
+	if r.running != status {
+		return
+	}
+	// 场景已被其他实例停止或替换
+	if !r.owned() {
+		r.logger.Infof("Scenario %s was stopped or replaced by other instance", status.Name)
+		r.abandon()
+		return
+	}
+	r.stores[step.reportType].Set(&metar.Override{
+		ICAO:     step.icao,
+		Data:     step.data,
+		Source:   metar.OverrideSourceScenario,
+		ExpireAt: status.StartTime.Add(step.expireAt),
+	})
+	status.Applied++
+	r.save()
+	r.logger.Infof("Scenario %s applied %s of %s", status.Name, step.reportType, step.icao)
+}
+
+func (r *Runner) finish(status *metar.ScenarioStatus) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.running != status {
+		return
+	}
+	if !r.owned() {
+		r.abandon()
+		return
+	}
+	r.stop()
+}
+
+// save 将本实例运行的场景写入共享缓存, 场景结束后自动过期, 调用方需持有锁
+func (r *Runner) save() {
+	if r.shared == nil || r.running == nil {
+		return
+	}
+	data, err := json.Marshal(&sharedScenario{ID: r.id, Status: r.running})
+	if err != nil {
+		r.logger.Errorf("Fail to encode scenario: %v", err)
+		return
+	}
+	value := string(data)
+	r.shared.SetWithTTL(sharedScenarioKey, &value, time.Until(r.running.EndTime))
+}
+
+func (r *Runner) load() *sharedScenario {
+	data, ok := r.shared.Get(sharedScenarioKey)
+	if !ok || data == nil {
+		return nil
+	}
+	record := &sharedScenario{}
+	if err := json.Unmarshal([]byte(*data), record); err != nil || record.Status == nil {
+		r.logger.Errorf("Fail to decode scenario: %v", err)
+		return nil
+	}
+	return record
+}
+
+// scenarioID 生成标识本次运行场景的随机ID
+func scenarioID() string {
+	buffer := make([]byte, 8)
+	_, _ = rand.Read(buffer)
+	return hex.EncodeToString(buffer)
+}
+
+// plan 校验场景并计算每一步的生效与过期时间, 返回按生效时间排序的步骤与场景持续时间
+func (r *Runner) plan(scenario *metar.Scenario) ([]*plannedStep, time.Duration, error) {
+	if len(scenario.Steps) == 0 {
+		return nil, 0, fmt.Errorf("%w: no steps", metar.ErrScenarioInvalid)
+	}
+	if scenario.Name == "" {
+		scenario.Name = "unnamed"
+	}
+
+	steps := make([]*plannedStep, 0, len(scenario.Steps))
+	explicit := make(map[*plannedStep]bool)
+	for index, step := range scenario.Steps {
+		at, err := time.ParseDuration(step.At)
+		if err != nil || at < 0 {
+			return nil, 0, fmt.Errorf("%w: step %d has invalid time %s", metar.ErrScenarioInvalid, index, step.At)
+		}
+		reportType := strings.ToLower(step.Type)
+		if reportType == "" {
+			reportType = metar.ReportTypeMetar
+		}
+		if _, ok := r.stores[reportType]; !ok {
+			return nil, 0, fmt.Errorf("%w: step %d has invalid type %s", metar.ErrScenarioInvalid, index, step.Type)
+		}
+		if len(step.ICAO) != 4 || strings.TrimSpace(step.Data) == "" {
+			return nil, 0, fmt.Errorf("%w: step %d need an icao and a report", metar.ErrScenarioInvalid, index)
+		}
+		planned := &plannedStep{
+			reportType: reportType,
+			icao:       strings.ToUpper(step.ICAO),
+			data:       strings.TrimSpace(step.Data),
+			at:         at,
+		}
+		if step.Expire != "" {
+			expire, err := time.ParseDuration(step.Expire)
+			if err != nil || expire <= 0 {
+				return nil, 0, fmt.Errorf("%w: step %d has invalid expire %s", metar.ErrScenarioInvalid, index, step.Expire)
+			}
+			planned.expireAt = at + expire
+			explicit[planned] = true
+		}
+		steps = append(steps, planned)
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return steps[i].at < steps[j].at
+	})
+
+	end := steps[len(steps)-1].at + defaultScenarioTail
+	if scenario.Duration != "" {
+		duration, err := time.ParseDuration(scenario.Duration)
+		if err != nil || duration <= 0 {
+			return nil, 0, fmt.Errorf("%w: invalid duration %s", metar.ErrScenarioInvalid, scenario.Duration)
+		}
+		if duration < steps[len(steps)-1].at {
+			return nil, 0, fmt.Errorf("%w: duration is shorter than the last step", metar.ErrScenarioInvalid)
+		}
+		end = duration
+	}
+
+	// 未指定有效时间的步骤持续到同一站点的下一步或场景结束
+	for index, step := range steps {
+		if explicit[step] {
+			step.expireAt = min(step.expireAt, end)
+			continue
+		}
+		step.expireAt = end
+		for _, next := range steps[index+1:] {
+			if next.reportType == step.reportType && next.icao == step.icao {
+				step.expireAt = next.at
+				break
+			}
+		}
+	}
+	return steps, end, nil
+}
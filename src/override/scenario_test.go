@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package override
+package override
+
+import (
+	"metar-service/src/interfaces/metar"
+	"testing"
+	"time"
+)
+
+// newSharedRunners 创建共用同一个共享缓存的多个实例的场景运行器
+func newSharedRunners(count int) ([]*Runner, *sharedCache) {
+	c := &sharedCache{data: make(map[string]string)}
+	runners := make([]*Runner, count)
+	for i := range runners {
+		metarStore := &SharedStore{logger: nopLogger{}, cache: c, key: metar.ReportTypeMetar}
+		tafStore := &SharedStore{logger: nopLogger{}, cache: c, key: metar.ReportTypeTaf}
+		runners[i] = NewRunner(nopLogger{}, metarStore, tafStore)
+		runners[i].SetShared(c)
+	}
+	return runners, c
+}
+
+func testScenario(name string) *metar.Scenario {
+	return &metar.Scenario{Name: name, Duration: "1h", Steps: []*metar.ScenarioStep{
+		{At: "0s", ICAO: "ZBAA", Data: "METAR ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021"},
+		{At: "100ms", ICAO: "ZSSS", Data: "METAR ZSSS 151100Z 09004MPS CAVOK 15/05 Q1018"},
+	}}
+}
+
+// waitApplied 等待场景生效的步数达到want
+func waitApplied(t *testing.T, runner *Runner, want int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if status := runner.Status(); status != nil && status.Applied >= want {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Status() = %+v, want %d steps applied", runner.Status(), want)
+}
+
+func TestSharedRunnerStatusAndStop(t *testing.T) {
+	runners, _ := newSharedRunners(2)
+	defer runners[0].Close()
+	if err := runners[0].Load(testScenario("crosswind")); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	// 其他实例可以查看加载场景的实例推进的状态
+	waitApplied(t, runners[1], 1)
+	if status := runners[1].Status(); status.Name != "crosswind" || status.Steps != 2 {
+		t.Errorf("Status() on other instance = %+v, want crosswind with 2 steps", status)
+	}
+	expectICAO(t, runners[1].stores[metar.ReportTypeMetar].List(), "ZBAA")
+
+	// 其他实例停止场景后, 加载场景的实例不再推进剩余步骤
+	runners[1].Stop()
+	if status := runners[0].Status(); status != nil {
+		t.Errorf("Status() after Stop() on other instance = %+v, want nil", status)
+	}
+	time.Sleep(200 * time.Millisecond)
+	expectICAO(t, runners[0].stores[metar.ReportTypeMetar].List())
+	if runners[0].running != nil {
+		t.Error("owner kept running scenario stopped by other instance")
+	}
+}
+
+func TestSharedRunnerReplace(t *testing.T) {
+	runners, _ := newSharedRunners(2)
+	defer runners[1].Close()
+	if err := runners[0].Load(testScenario("first")); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	waitApplied(t, runners[0], 1)
+	second := testScenario("second")
+	second.Steps = second.Steps[:1]
+	if err := runners[1].Load(second); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if status := runners[0].Status(); status == nil || status.Name != "second" || status.Applied != 1 {
+		t.Errorf("Status() = %+v, want second scenario", status)
+	}
+	// 被替换的场景剩余步骤不再生效
+	expectICAO(t, runners[0].stores[metar.ReportTypeMetar].List(), "ZBAA")
+
+	// 未运行场景的实例退出时不影响其他实例运行的场景
+	runners[0].Close()
+	if status := runners[1].Status(); status == nil || status.Name != "second" {
+		t.Errorf("Status() after Close() on other instance = %+v, want second scenario", status)
+	}
+}
+
+func TestSharedRunnerLoadIdle(t *testing.T) {
+	runners, _ := newSharedRunners(2)
+	defer runners[0].Close()
+	if loaded, err := runners[0].LoadIdle(testScenario("startup")); err != nil || !loaded {
+		t.Fatalf("LoadIdle() = %v, %v, want loaded", loaded, err)
+	}
+	if loaded, err := runners[1].LoadIdle(testScenario("startup")); err != nil || loaded {
+		t.Errorf("LoadIdle() on other instance = %v, %v, want skipped", loaded, err)
+	}
+	if runners[1].running != nil {
+		t.Error("other instance runs the startup scenario")
+	}
+
+	// 运行场景的实例退出时场景无法继续推进, 同时停止该场景
+	runners[0].Close()
+	if status := runners[1].Status(); status != nil {
+		t.Errorf("Status() after owner Close() = %+v, want nil", status)
+	}
+	if loaded, err := runners[1].LoadIdle(testScenario("startup")); err != nil || !loaded {
+		t.Errorf("LoadIdle() after owner Close() = %v, %v, want loaded", loaded, err)
+	}
+	runners[1].Close()
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package override
+package override
+
+import (
+	"encoding/json"
+	"fmt"
+	"metar-service/src/interfaces/metar"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/cache"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+const (
+	// sharedSnapshotTTL 本地快照的有效时间, 其他实例的修改最多延迟该时间生效
+	sharedSnapshotTTL = 2 * time.Second
+	// sharedLockTTL 修改报文时持有跨实例锁的最长时间
+	sharedLockTTL = 5 * time.Second
+	// sharedLockWait 等待其他实例释放锁的最长时间
+	sharedLockWait  = 3 * time.Second
+	sharedLockRetry = 50 * time.Millisecond
+)
+
+// SharedStore 保存在共享缓存中的指定报文, 所有实例共用同一份数据
+type SharedStore struct {
+	logger   logger.Interface
+	cache    cache.Interface[string, *string]
+	locker   metar.LockerInterface
+	key      string
+	lock     sync.Mutex
+	snapshot map[string]*metar.Override
+	loadedAt time.Time
+}
+
+func NewSharedStore(
+	lg logger.Interface,
+	c cache.Interface[string, *string],
+	reportType string,
+) *SharedStore {
+	store := &SharedStore{
+		logger: logger.NewLoggerAdapter(lg, fmt.Sprintf("override-%s", reportType)),
+		cache:  c,
+		key:    reportType,
+	}
+	// 共享缓存提供跨实例的互斥锁, 避免多个实例同时修改时互相覆盖
+	if locker, ok := c.(metar.LockerInterface); ok {
+		store.locker = locker
+	}
+	return store
+}
+
+// Get 每次查询都会调用, 使用短时间的本地快照减少对共享缓存的访问
+func (s *SharedStore) Get(icao string) (*metar.Override, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.snapshot == nil || time.Since(s.loadedAt) > sharedSnapshotTTL {
+		s.snapshot = s.load()
+		s.loadedAt = time.Now()
+	}
+	override, ok := s.snapshot[strings.ToUpper(icao)]
+	if !ok || !time.Now().Before(override.ExpireAt) {
+		return nil, false
+	}
+	return override, true
+}
+
+func (s *SharedStore) Set(override *metar.Override) {
+	override.ICAO = strings.ToUpper(override.ICAO)
+	s.update(func(overrides map[string]*metar.Override) {
+		overrides[override.ICAO] = override
+	})
+}
+
+func (s *SharedStore) Delete(icao string) {
+	s.update(func(overrides map[string]*metar.Override) {
+		delete(overrides, strings.ToUpper(icao))
+	})
+}
+
+func (s *SharedStore) DeleteSource(source string) {
+	s.update(func(overrides map[string]*metar.Override) {
+		for icao, override := range overrides {
+			if override.Source == source {
+				delete(overrides, icao)
+			}
+		}
+	})
+}
+
+// List 返回所有实例指定的未过期报文
+func (s *SharedStore) List() []*metar.Override {
+	now := time.Now()
+	overrides := make([]*metar.Override, 0)
+	for _, override := range s.load() {
+		if now.Before(override.ExpireAt) {
+			overrides = append(overrides, override)
+		}
+	}
+	sort.Slice(overrides, func(i, j int) bool {
+		return overrides[i].ICAO < overrides[j].ICAO
+	})
+	return overrides
+}
+
+// update 在跨实例锁内读取、修改并写回全部报文, 同时清除已过期的报文
+func (s *SharedStore) update(modify func(overrides map[string]*metar.Override)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.tryLock() {
+		defer s.locker.Unlock(s.key)
+	}
+	overrides := s.load()
+	modify(overrides)
+	now := time.Now()
+	for icao, override := range overrides {
+		if !now.Before(override.ExpireAt) {
+			delete(overrides, icao)
+		}
+	}
+	data, err := json.Marshal(overrides)
+	if err != nil {
+		s.logger.Errorf("Fail to encode overrides: %v", err)
+		return
+	}
+	value := string(data)
+	s.cache.Set(s.key, &value)
+	s.snapshot = overrides
+	s.loadedAt = now
+}
+
+// tryLock 等待获取跨实例锁, 超时后仍继续修改, 返回是否持有锁
+func (s *SharedStore) tryLock() bool {
+	if s.locker == nil {
+		return false
+	}
+	deadline := time.Now().Add(sharedLockWait)
+	for {
+		ok, err := s.locker.TryLock(s.key, sharedLockTTL)
+		if err == nil && ok {
+			return true
+		}
+		if time.Now().After(deadline) {
+			s.logger.Warnf("Fail to acquire override lock, update without lock: %v", err)
+			return false
+		}
+		time.Sleep(sharedLockRetry)
+	}
+}
+
+func (s *SharedStore) load() map[string]*metar.Override {
+	overrides := make(map[string]*metar.Override)
+	data, ok := s.cache.Get(s.key)
+	if !ok || data == nil {
+		return overrides
+	}
+	if err := json.Unmarshal([]byte(*data), &overrides); err != nil {
+		s.logger.Errorf("Fail to decode overrides: %v", err)
+		return make(map[string]*metar.Override)
+	}
+	return overrides
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package override
+package override
+
+import (
+	"metar-service/src/interfaces/metar"
+	"sync"
+	"testing"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/cache"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// sharedCache 模拟多个实例共用的缓存
+type sharedCache struct {
+	cache.Interface[string, *string]
+	lock sync.Mutex
+	data map[string]string
+}
+
+func (c *sharedCache) Set(key string, value *string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.data[key] = *value
+}
+
+func (c *sharedCache) SetWithTTL(key string, value *string, _ time.Duration) {
+	c.Set(key, value)
+}
+
+func (c *sharedCache) Del(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.data, key)
+}
+
+func (c *sharedCache) Get(key string) (*string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	value, ok := c.data[key]
+	return &value, ok
+}
+
+type nopLogger struct {
+	logger.Interface
+}
+
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+
+func newSharedStores(count int) []*SharedStore {
+	c := &sharedCache{data: make(map[string]string)}
+	stores := make([]*SharedStore, count)
+	for i := range stores {
+		stores[i] = &SharedStore{logger: nopLogger{}, cache: c, key: metar.ReportTypeMetar}
+	}
+	return stores
+}
+
+func TestSharedStoreAcrossInstances(t *testing.T) {
+	stores := newSharedStores(2)
+	expireAt := time.Now().Add(time.Hour)
+
+	stores[0].Set(&metar.Override{ICAO: "zbaa", Data: "ZBAA", Source: metar.OverrideSourceManual, ExpireAt: expireAt})
+	stores[0].Set(&metar.Override{ICAO: "ZSSS", Data: "ZSSS", Source: metar.OverrideSourceScenario, ExpireAt: expireAt})
+	stores[1].Set(&metar.Override{ICAO: "ZGGG", Data: "ZGGG", Source: metar.OverrideSourceScenario, ExpireAt: expireAt})
+	stores[1].Set(&metar.Override{ICAO: "ZUUU", Data: "ZUUU", Source: metar.OverrideSourceManual, ExpireAt: time.Now().Add(-time.Second)})
+
+	if override, ok := stores[1].Get("ZBAA"); !ok || override.Data != "ZBAA" {
+		t.Errorf("Get(ZBAA) on other instance = %v, %v, want set", override, ok)
+	}
+	if _, ok := stores[1].Get("ZUUU"); ok {
+		t.Error("Get(ZUUU) returned an expired override")
+	}
+	expectICAO(t, stores[0].List(), "ZBAA", "ZGGG", "ZSSS")
+
+	stores[1].DeleteSource(metar.OverrideSourceScenario)
+	expectICAO(t, stores[0].List(), "ZBAA")
+	stores[0].Delete("ZBAA")
+	expectICAO(t, stores[1].List())
+}
+
+func TestSharedStoreSnapshot(t *testing.T) {
+	stores := newSharedStores(2)
+	if _, ok := stores[1].Get("ZBAA"); ok {
+		t.Fatal("Get(ZBAA) found override in empty store")
+	}
+	stores[0].Set(&metar.Override{ICAO: "ZBAA", Data: "ZBAA", ExpireAt: time.Now().Add(time.Hour)})
+	// 快照过期前使用本地快照, 过期后读取其他实例的修改
+	if _, ok := stores[1].Get("ZBAA"); ok {
+		t.Error("Get(ZBAA) bypassed the local snapshot")
+	}
+	stores[1].loadedAt = time.Now().Add(-sharedSnapshotTTL - time.Millisecond)
+	if _, ok := stores[1].Get("ZBAA"); !ok {
+		t.Error("Get(ZBAA) did not reload an expired snapshot")
+	}
+}
+
+func expectICAO(t *testing.T, overrides []*metar.Override, icaos ...string) {
+	t.Helper()
+	if len(overrides) != len(icaos) {
+		t.Fatalf("overrides = %d, want %v", len(overrides), icaos)
+	}
+	for i, icao := range icaos {
+		if overrides[i].ICAO != icao {
+			t.Errorf("override %d = %s, want %s", i, overrides[i].ICAO, icao)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package override
+package override
+
+import (
+	"metar-service/src/interfaces/metar"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Store 管理员指定报文的内存存储, 只在本实例生效, 使用Redis缓存时由 SharedStore 在实例间共享
+type Store struct {
+	lock      sync.RWMutex
+	overrides map[string]*metar.Override
+}
+
+func NewStore() *Store {
+	return &Store{
+		overrides: make(map[string]*metar.Override),
+	}
+}
+
+func (s *Store) Get(icao string) (*metar.Override, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	override, ok := s.overrides[strings.ToUpper(icao)]
+	if !ok || !time.Now().Before(override.ExpireAt) {
+		return nil, false
+	}
+	return override, true
+}
+
+func (s *Store) Set(override *metar.Override) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	override.ICAO = strings.ToUpper(override.ICAO)
+	s.overrides[override.ICAO] = override
+}
+
+func (s *Store) Delete(icao string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.overrides, strings.ToUpper(icao))
+}
+
+func (s *Store) DeleteSource(source string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for icao, override := range s.overrides {
+		if override.Source == source {
+			delete(s.overrides, icao)
+		}
+	}
+}
+
+// List 返回未过期的报文并顺便清除已过期的报文
+func (s *Store) List() []*metar.Override {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := time.Now()
+	overrides := make([]*metar.Override, 0, len(s.overrides))
+	for icao, override := range s.overrides {
+		if !now.Before(override.ExpireAt) {
+			delete(s.overrides, icao)
+			continue
+		}
+		overrides = append(overrides, override)
+	}
+	sort.Slice(overrides, func(i, j int) bool {
+		return overrides[i].ICAO < overrides[j].ICAO
+	})
+	return overrides
+}
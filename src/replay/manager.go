@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package replay
+package replay
+
+import (
+	"errors"
+	"metar-service/src/interfaces/metar"
+	"time"
+)
+
+// Manager 从归档中返回模拟时间时的报文, 与实时报文管理器接口一致, 只在单次请求内使用
+type Manager struct {
+	archive  metar.ArchiveInterface
+	stations metar.StationDatabaseInterface
+	fallback metar.FallbackInterface
+	at       time.Time
+	maxAge   time.Duration
+}
+
+func NewManager(
+	archive metar.ArchiveInterface,
+	stations metar.StationDatabaseInterface,
+	fallback metar.FallbackInterface,
+	at time.Time,
+	maxAge time.Duration,
+) *Manager {
+	return &Manager{
+		archive:  archive,
+		stations: stations,
+		fallback: fallback,
+		at:       at,
+		maxAge:   maxAge,
+	}
+}
+
+func (m *Manager) Query(icao string) (string, error) {
+	result, err := m.QueryResult(icao)
+	if err != nil {
+		return "", err
+	}
+	return result.Data, nil
+}
+
+// QueryResult 返回模拟时间前最新的报文, 报文年龄按模拟时间计算
+func (m *Manager) QueryResult(code string) (*metar.QueryResult, error) {
+	icao, err := metar.ResolveICAO(m.stations, code)
+	if err != nil {
+		return nil, err
+	}
+	result, err := m.queryResult(icao)
+	if errors.Is(err, metar.ErrTargetNotFound) && m.fallback != nil && m.fallback.Auto() {
+		if results, fallbackErr := m.fallback.Nearest(icao, 1, m.queryResult); fallbackErr == nil {
+			result, err = results[0], nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Query = code
+	return result, nil
+}
+
+// Nearest 查询模拟时间时附近有报文的站点
+func (m *Manager) Nearest(code string, count int) ([]*metar.QueryResult, error) {
+	icao, err := metar.ResolveICAO(m.stations, code)
+	if err != nil {
+		return nil, err
+	}
+	if m.fallback == nil {
+		return nil, metar.ErrTargetNotFound
+	}
+	results, err := m.fallback.Nearest(icao, count, m.queryResult)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		result.Query = code
+	}
+	return results, nil
+}
+
+func (m *Manager) queryResult(icao string) (*metar.QueryResult, error) {
+	report, err := m.archive.At(icao, m.at)
+	if err != nil {
+		return nil, err
+	}
+	if m.at.Sub(report.ReportTime) > m.maxAge {
+		return nil, metar.ErrTargetNotFound
+	}
+	age := int64(m.at.Sub(report.ReportTime) / time.Second)
+	return &metar.QueryResult{
+		ICAO:            icao,
+		Status:          metar.QueryStatusOk,
+		Data:            report.Data,
+		Provider:        report.Provider,
+		FetchTime:       &report.FetchTime,
+		ObservationTime: &report.ReportTime,
+		Age:             &age,
+	}, nil
+}
+
+func (m *Manager) BatchQuery(icaos []string) []string {
+	data := make([]string, 0, len(icaos))
+	for _, result := range m.BatchQueryResult(icaos) {
+		if result.Status == metar.QueryStatusOk {
+			data = append(data, result.Data)
+		}
+	}
+	return data
+}
+
+// BatchQueryResult 批量查询, 归档查询不访问数据源, 按顺序依次查询
+func (m *Manager) BatchQueryResult(icaos []string) []*metar.QueryResult {
+	results := make([]*metar.QueryResult, 0, len(icaos))
+	for _, icao := range icaos {
+		result, err := m.QueryResult(icao)
+		if err != nil {
+			result = &metar.QueryResult{ICAO: icao, Query: icao}
+		}
+		result.Status = metar.QueryStatus(err)
+		results = append(results, result)
+	}
+	return results
+}
+
+// Purge 回放数据来自归档, 没有缓存可以清除
+func (m *Manager) Purge(_ string) {}
+
+func (m *Manager) PurgeAll() {}
+
+func (m *Manager) RefreshProvider(_ string) int {
+	return 0
+}
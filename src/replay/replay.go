@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package replay
+package replay
+
+import (
+	"metar-service/src/interfaces/config"
+	"metar-service/src/interfaces/metar"
+	"sync"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// Replay 历史回放, 全局模拟时钟从配置的起始时间开始按倍率计时
+type Replay struct {
+	logger       logger.Interface
+	config       *config.ReplayConfig
+	metarArchive metar.ArchiveInterface
+	tafArchive   metar.ArchiveInterface
+	stations     metar.StationDatabaseInterface
+	fallback     metar.FallbackInterface
+	lock         sync.RWMutex
+	enable       bool
+	start        time.Time // 模拟时钟的起始时间
+	speed        float64
+	origin       time.Time // 模拟时钟开始计时的真实时间
+}
+
+func NewReplay(
+	lg logger.Interface,
+	c *config.ReplayConfig,
+	metarArchive metar.ArchiveInterface,
+	tafArchive metar.ArchiveInterface,
+	stations metar.StationDatabaseInterface,
+	fallback metar.FallbackInterface,
+) *Replay {
+	replay := &Replay{
+		logger:       logger.NewLoggerAdapter(lg, "replay"),
+		config:       c,
+		metarArchive: metarArchive,
+		tafArchive:   tafArchive,
+		stations:     stations,
+		fallback:     fallback,
+		enable:       c.Enable,
+		start:        c.StartTime,
+		speed:        c.Speed,
+		origin:       time.Now(),
+	}
+	if c.Enable {
+		replay.logger.Infof("Global replay enabled, simulated clock starts at %s with speed %g", c.StartTime.Format(time.RFC3339), c.Speed)
+	}
+	return replay
+}
+
+func (r *Replay) Now() (time.Time, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.now()
+}
+
+func (r *Replay) now() (time.Time, bool) {
+	if !r.enable {
+		return time.Time{}, false
+	}
+	elapsed := time.Duration(float64(time.Since(r.origin)) * r.speed)
+	return r.start.Add(elapsed), true
+}
+
+func (r *Replay) Clock() *metar.ReplayClock {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	clock := &metar.ReplayClock{Enable: r.enable, Speed: r.speed}
+	if now, ok := r.now(); ok {
+		start := r.start
+		clock.Start = &start
+		clock.Now = &now
+	}
+	return clock
+}
+
+func (r *Replay) SetClock(start time.Time, speed float64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.enable = true
+	r.start = start.UTC()
+	r.speed = speed
+	r.origin = time.Now()
+	r.logger.Infof("Global replay clock set, simulated clock starts at %s with speed %g", r.start.Format(time.RFC3339), speed)
+}
+
+func (r *Replay) ClearClock() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.enable = false
+	r.logger.Infof("Global replay clock cleared")
+}
+
+func (r *Replay) MetarManager(at time.Time) metar.ManagerInterface {
+	return NewManager(r.metarArchive, r.stations, r.fallback, at, r.config.MetarMaxAgeDuration)
+}
+
+func (r *Replay) TafManager(at time.Time) metar.ManagerInterface {
+	return NewManager(r.tafArchive, r.stations, r.fallback, at, r.config.TafMaxAgeDuration)
+}
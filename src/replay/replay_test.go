@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package replay
+package replay
+
+import (
+	"metar-service/src/interfaces/config"
+	"testing"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// nopLogger 丢弃测试中的日志
+type nopLogger struct {
+	logger.Interface
+}
+
+func (nopLogger) Infof(string, ...any) {}
+
+func TestReplayClock(t *testing.T) {
+	start := time.Date(2025, time.June, 1, 8, 0, 0, 0, time.UTC)
+	replay := NewReplay(nopLogger{}, &config.ReplayConfig{Enable: true, StartTime: start, Speed: 1}, nil, nil, nil, nil)
+	if now, ok := replay.Now(); !ok || now.Before(start) || now.Sub(start) > time.Second {
+		t.Fatalf("Now() = %s, %v, want configured start %s", now, ok, start)
+	}
+
+	// 运行时修改的模拟时钟从修改时刻开始计时
+	changed := time.Date(2024, time.December, 31, 23, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	replay.SetClock(changed, 3600)
+	time.Sleep(10 * time.Millisecond)
+	clock := replay.Clock()
+	if !clock.Enable || clock.Speed != 3600 || clock.Start == nil || !clock.Start.Equal(changed) || clock.Start.Location() != time.UTC {
+		t.Fatalf("Clock() = %+v, want start %s in UTC with speed 3600", clock, changed)
+	}
+	if elapsed := clock.Now.Sub(changed); elapsed < 30*time.Second || elapsed > time.Hour {
+		t.Errorf("Clock() now = %s, want start advanced by speed 3600", clock.Now)
+	}
+
+	replay.ClearClock()
+	if now, ok := replay.Now(); ok {
+		t.Errorf("Now() after ClearClock() = %s, want disabled", now)
+	}
+	if clock := replay.Clock(); clock.Enable || clock.Start != nil || clock.Now != nil {
+		t.Errorf("Clock() after ClearClock() = %+v, want disabled", clock)
+	}
+}
+
+func TestReplayClockDisabled(t *testing.T) {
+	replay := NewReplay(nopLogger{}, &config.ReplayConfig{Speed: 1}, nil, nil, nil, nil)
+	if _, ok := replay.Now(); ok {
+		t.Fatal("Now() enabled without global replay")
+	}
+	start := time.Date(2025, time.June, 1, 8, 0, 0, 0, time.UTC)
+	replay.SetClock(start, 1)
+	if now, ok := replay.Now(); !ok || now.Before(start) {
+		t.Errorf("Now() after SetClock() = %s, %v, want from %s", now, ok, start)
+	}
+}
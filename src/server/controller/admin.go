@@ -0,0 +1,169 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import (
+	"io"
+	DTO "metar-service/src/interfaces/server/dto"
+	"metar-service/src/interfaces/server/service"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+const (
+	// defaultOverrideExpire 未指定有效时间时指定报文的有效时间
+	defaultOverrideExpire = time.Hour
+	// maxScenarioSize 场景脚本的最大字节数
+	maxScenarioSize = 1 << 20
+	// defaultReplaySpeed 未指定倍率时模拟时钟的速度
+	defaultReplaySpeed = 1
+)
+
+type Admin struct {
+	logger  logger.Interface
+	service service.AdminInterface
+}
+
+func NewAdmin(
+	lg logger.Interface,
+	service service.AdminInterface,
+) *Admin {
+	return &Admin{
+		logger:  logger.NewLoggerAdapter(lg, "admin-controller"),
+		service: service,
+	}
+}
+
+func (a *Admin) ListOverride(ctx echo.Context) error {
+	data := &DTO.QueryOverride{}
+
+	if err := ctx.Bind(data); err != nil {
+		a.logger.Errorf("ListOverride handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	a.logger.Debugf("ListOverride with argument: %#v", data)
+
+	return a.service.ListOverride(data.Type).Response(ctx)
+}
+
+func (a *Admin) SetOverride(ctx echo.Context) error {
+	data := &DTO.SetOverride{}
+
+	if err := ctx.Bind(data); err != nil {
+		a.logger.Errorf("SetOverride handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	a.logger.Debugf("SetOverride with argument: %#v", data)
+
+	r, err := dto.ValidStruct(data)
+	if err != nil {
+		a.logger.Errorf("SetOverride handle fail, validate err, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrServerError)
+	}
+	if r != nil {
+		a.logger.Errorf("SetOverride handle fail, validate argument fail, %v", r)
+		return dto.ErrorResponse(ctx, r)
+	}
+
+	expire := defaultOverrideExpire
+	if data.Expire != "" {
+		if expire, err = time.ParseDuration(data.Expire); err != nil {
+			a.logger.Errorf("SetOverride handle fail, invalid expire %s, %v", data.Expire, err)
+			return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+		}
+	}
+
+	return a.service.SetOverride(data.Type, data.ICAO, data.Data, expire).Response(ctx)
+}
+
+func (a *Admin) DeleteOverride(ctx echo.Context) error {
+	data := &DTO.DeleteOverride{}
+
+	if err := ctx.Bind(data); err != nil {
+		a.logger.Errorf("DeleteOverride handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	a.logger.Debugf("DeleteOverride with argument: %#v", data)
+
+	r, err := dto.ValidStruct(data)
+	if err != nil {
+		a.logger.Errorf("DeleteOverride handle fail, validate err, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrServerError)
+	}
+	if r != nil {
+		a.logger.Errorf("DeleteOverride handle fail, validate argument fail, %v", r)
+		return dto.ErrorResponse(ctx, r)
+	}
+
+	return a.service.DeleteOverride(data.Type, data.ICAO).Response(ctx)
+}
+
+// LoadScenario 请求体为YAML或JSON格式的场景脚本
+func (a *Admin) LoadScenario(ctx echo.Context) error {
+	data, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxScenarioSize))
+	if err != nil {
+		a.logger.Errorf("LoadScenario handle fail, read body fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	a.logger.Debugf("LoadScenario with %d bytes", len(data))
+
+	return a.service.LoadScenario(data).Response(ctx)
+}
+
+func (a *Admin) ScenarioStatus(ctx echo.Context) error {
+	return a.service.ScenarioStatus().Response(ctx)
+}
+
+func (a *Admin) StopScenario(ctx echo.Context) error {
+	return a.service.StopScenario().Response(ctx)
+}
+
+func (a *Admin) ReplayClock(ctx echo.Context) error {
+	return a.service.ReplayClock().Response(ctx)
+}
+
+func (a *Admin) SetReplayClock(ctx echo.Context) error {
+	data := &DTO.SetReplayClock{}
+
+	if err := ctx.Bind(data); err != nil {
+		a.logger.Errorf("SetReplayClock handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	a.logger.Debugf("SetReplayClock with argument: %#v", data)
+
+	r, err := dto.ValidStruct(data)
+	if err != nil {
+		a.logger.Errorf("SetReplayClock handle fail, validate err, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrServerError)
+	}
+	if r != nil {
+		a.logger.Errorf("SetReplayClock handle fail, validate argument fail, %v", r)
+		return dto.ErrorResponse(ctx, r)
+	}
+
+	start, err := time.Parse(time.RFC3339, data.Start)
+	if err != nil {
+		a.logger.Errorf("SetReplayClock handle fail, invalid start %s, %v", data.Start, err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+	speed := data.Speed
+	if speed == 0 {
+		speed = defaultReplaySpeed
+	}
+
+	return a.service.SetReplayClock(start, speed).Response(ctx)
+}
+
+func (a *Admin) ClearReplayClock(ctx echo.Context) error {
+	return a.service.ClearReplayClock().Response(ctx)
+}
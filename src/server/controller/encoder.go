@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import (
+	"metar-service/src/interfaces/metar"
+	"metar-service/src/interfaces/server/service"
+
+	"github.com/labstack/echo/v4"
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type Encoder struct {
+	logger  logger.Interface
+	service service.EncoderInterface
+}
+
+func NewEncoder(
+	lg logger.Interface,
+	service service.EncoderInterface,
+) *Encoder {
+	return &Encoder{
+		logger:  logger.NewLoggerAdapter(lg, "encoder-controller"),
+		service: service,
+	}
+}
+
+// EncodeMetar 请求体与解码接口返回的METAR结构一致
+func (e *Encoder) EncodeMetar(ctx echo.Context) error {
+	data := &metar.Metar{}
+
+	if err := ctx.Bind(data); err != nil {
+		e.logger.Errorf("EncodeMetar handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	e.logger.Debugf("EncodeMetar with argument: %#v", data)
+
+	return e.service.EncodeMetar(data).Response(ctx)
+}
+
+// EncodeTaf 请求体与解码接口返回的TAF结构一致
+func (e *Encoder) EncodeTaf(ctx echo.Context) error {
+	data := &metar.Taf{}
+
+	if err := ctx.Bind(data); err != nil {
+		e.logger.Errorf("EncodeTaf handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	e.logger.Debugf("EncodeTaf with argument: %#v", data)
+
+	return e.service.EncodeTaf(data).Response(ctx)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import (
+	"metar-service/src/interfaces/metar"
+	DTO "metar-service/src/interfaces/server/dto"
+	"metar-service/src/interfaces/server/service"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type History struct {
+	logger  logger.Interface
+	service service.HistoryInterface
+}
+
+func NewHistory(
+	lg logger.Interface,
+	service service.HistoryInterface,
+) *History {
+	return &History{
+		logger:  logger.NewLoggerAdapter(lg, "history-controller"),
+		service: service,
+	}
+}
+
+func (h *History) QueryMetarHistory(ctx echo.Context) error {
+	data, from, to, limit, status := h.bindHistory(ctx, "QueryMetarHistory")
+	if status != nil {
+		return dto.ErrorResponse(ctx, status)
+	}
+	return h.service.QueryMetarHistory(data.ICAO, from, to, limit).Response(ctx)
+}
+
+func (h *History) QueryTafHistory(ctx echo.Context) error {
+	data, from, to, limit, status := h.bindHistory(ctx, "QueryTafHistory")
+	if status != nil {
+		return dto.ErrorResponse(ctx, status)
+	}
+	return h.service.QueryTafHistory(data.ICAO, from, to, limit).Response(ctx)
+}
+
+// bindHistory 解析历史查询参数, 默认查询最近24小时, 时间格式为RFC3339
+func (h *History) bindHistory(ctx echo.Context, name string) (*DTO.QueryHistory, time.Time, time.Time, int, *dto.ApiStatus) {
+	data := &DTO.QueryHistory{}
+
+	if err := ctx.Bind(data); err != nil {
+		h.logger.Errorf("%s handle fail, parse argument fail, %v", name, err)
+		return nil, time.Time{}, time.Time{}, 0, dto.ErrErrorParam
+	}
+
+	h.logger.Debugf("%s with argument: %#v", name, data)
+
+	r, err := dto.ValidStruct(data)
+	if err != nil {
+		h.logger.Errorf("%s handle fail, validate err, %v", name, err)
+		return nil, time.Time{}, time.Time{}, 0, dto.ErrServerError
+	}
+	if r != nil {
+		h.logger.Errorf("%s handle fail, validate argument fail, %v", name, r)
+		return nil, time.Time{}, time.Time{}, 0, r
+	}
+
+	to := time.Now()
+	if data.To != "" {
+		if to, err = time.Parse(time.RFC3339, data.To); err != nil {
+			h.logger.Errorf("%s handle fail, invalid time %s, %v", name, data.To, err)
+			return nil, time.Time{}, time.Time{}, 0, dto.ErrErrorParam
+		}
+	}
+	from := to.Add(-metar.DefaultHistoryRange)
+	if data.From != "" {
+		if from, err = time.Parse(time.RFC3339, data.From); err != nil {
+			h.logger.Errorf("%s handle fail, invalid time %s, %v", name, data.From, err)
+			return nil, time.Time{}, time.Time{}, 0, dto.ErrErrorParam
+		}
+	}
+
+	limit := data.Limit
+	if limit <= 0 {
+		limit = metar.DefaultHistoryLimit
+	}
+	limit = min(limit, metar.MaxHistoryLimit)
+
+	return data, from, to, limit, nil
+}
@@ -5,16 +5,24 @@
 package controller
 
 import (
+	"encoding/json"
 	"fmt"
+	"metar-service/src/interfaces/metar"
 	DTO "metar-service/src/interfaces/server/dto"
 	"metar-service/src/interfaces/server/service"
+	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"half-nothing.cn/service-core/interfaces/http/dto"
 	"half-nothing.cn/service-core/interfaces/logger"
 )
 
+// ReplayHeader 指定单次请求回放的模拟时间(RFC3339), 值为live时忽略全局回放返回实时报文
+const ReplayHeader = "X-Replay-Time"
+
 type Metar struct {
 	logger  logger.Interface
 	service service.MetarInterface
@@ -30,6 +38,58 @@ func NewMetar(
 	}
 }
 
+// replayService 根据请求头与全局回放选择查询实时报文或回放报文的服务, 同时返回当前时间或模拟时间
+func (m *Metar) replayService(ctx echo.Context) (service.MetarInterface, time.Time, *dto.ApiStatus) {
+	header := ctx.Request().Header.Get(ReplayHeader)
+	if strings.EqualFold(header, "live") {
+		return m.service, time.Now(), nil
+	}
+	at, replay := m.service.ReplayTime()
+	if header != "" {
+		var err error
+		if at, err = time.Parse(time.RFC3339, header); err != nil {
+			m.logger.Errorf("Invalid replay time %s, %v", header, err)
+			return nil, time.Time{}, dto.ErrErrorParam
+		}
+		replay = true
+	}
+	if !replay {
+		return m.service, time.Now(), nil
+	}
+	svc, status := m.service.Replay(at)
+	if status != nil {
+		return nil, time.Time{}, status
+	}
+	return svc, at, nil
+}
+
+// formatConflict decode、meta与lang最多指定其一, raw只作用于原始报文与翻译结果
+func formatConflict(raw bool, decode bool, meta bool, lang string) bool {
+	count := 0
+	for _, set := range []bool{decode, meta, lang != ""} {
+		if set {
+			count++
+		}
+	}
+	return count > 1 || (raw && (decode || meta))
+}
+
+// rawResponse 以HTML文本返回原始报文或翻译结果
+func rawResponse(ctx echo.Context, code int, data []string) error {
+	return dto.TextResponse(ctx, code, fmt.Sprintf("<pre>%s</pre>", strings.Join(data, "</pre>\n<pre>")))
+}
+
+// batchTexts 返回批量翻译中成功的结果, 失败的站点在HTML文本中省略
+func batchTexts(results []*metar.BatchResult[string]) []string {
+	texts := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.Status == metar.QueryStatusOk {
+			texts = append(texts, result.Data)
+		}
+	}
+	return texts
+}
+
 func (m *Metar) QueryMetar(ctx echo.Context) error {
 	data := &DTO.QueryMetar{}
 
@@ -50,21 +110,84 @@ func (m *Metar) QueryMetar(ctx echo.Context) error {
 		return dto.ErrorResponse(ctx, r)
 	}
 
+	if formatConflict(data.Raw, data.Decode, data.Meta, data.Lang) {
+		m.logger.Errorf("QueryMetar handle fail, conflicting format arguments: %#v", data)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	svc, _, status := m.replayService(ctx)
+	if status != nil {
+		return dto.ErrorResponse(ctx, status)
+	}
+
 	icaos := strings.Split(data.ICAO, ",")
 
+	if data.Decode {
+		if len(icaos) == 1 {
+			return svc.ParseMetar(icaos[0]).Response(ctx)
+		}
+		return svc.BatchParseMetar(icaos).Response(ctx)
+	}
+
+	if data.Meta {
+		if len(icaos) == 1 {
+			return svc.QueryMetarResult(icaos[0]).Response(ctx)
+		}
+		return svc.BatchQueryMetarResult(icaos).Response(ctx)
+	}
+
+	if data.Lang != "" && len(icaos) > 1 {
+		translated := svc.BatchTranslateMetar(icaos, data.Lang)
+		if !data.Raw || translated.Data == nil {
+			return translated.Response(ctx)
+		}
+		return rawResponse(ctx, translated.HttpCode, batchTexts(translated.Data))
+	}
+
 	var res *dto.ApiResponse[[]string]
 
-	if len(icaos) == 1 {
-		res = m.service.QueryMetar(icaos[0])
-	} else {
-		res = m.service.BatchQueryMetar(icaos)
+	switch {
+	case data.Lang != "":
+		res = svc.TranslateMetar(icaos[0], data.Lang)
+	case len(icaos) == 1:
+		res = svc.QueryMetar(icaos[0])
+	default:
+		res = svc.BatchQueryMetar(icaos)
 	}
 
 	if !data.Raw || res.Data == nil {
 		return res.Response(ctx)
 	}
 
-	return dto.TextResponse(ctx, res.HttpCode, fmt.Sprintf("<pre>%s</pre>", strings.Join(res.Data, "</pre>\n<pre>")))
+	return rawResponse(ctx, res.HttpCode, res.Data)
+}
+
+func (m *Metar) BatchQueryMetar(ctx echo.Context) error {
+	data := &DTO.QueryBatch{}
+
+	if err := ctx.Bind(data); err != nil {
+		m.logger.Errorf("BatchQueryMetar handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	m.logger.Debugf("BatchQueryMetar with argument: %#v", data)
+
+	r, err := dto.ValidStruct(data)
+	if err != nil {
+		m.logger.Errorf("BatchQueryMetar handle fail, validate err, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrServerError)
+	}
+	if r != nil {
+		m.logger.Errorf("BatchQueryMetar handle fail, validate argument fail, %v", r)
+		return dto.ErrorResponse(ctx, r)
+	}
+
+	svc, _, status := m.replayService(ctx)
+	if status != nil {
+		return dto.ErrorResponse(ctx, status)
+	}
+
+	return svc.BatchQueryMetarResult(strings.Split(data.ICAO, ",")).Response(ctx)
 }
 
 func (m *Metar) QueryTaf(ctx echo.Context) error {
@@ -87,19 +210,289 @@ func (m *Metar) QueryTaf(ctx echo.Context) error {
 		return dto.ErrorResponse(ctx, r)
 	}
 
+	if formatConflict(data.Raw, data.Decode, data.Meta, data.Lang) {
+		m.logger.Errorf("QueryTaf handle fail, conflicting format arguments: %#v", data)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	svc, _, status := m.replayService(ctx)
+	if status != nil {
+		return dto.ErrorResponse(ctx, status)
+	}
+
 	icaos := strings.Split(data.ICAO, ",")
 
+	if data.Decode {
+		if len(icaos) == 1 {
+			return svc.ParseTaf(icaos[0]).Response(ctx)
+		}
+		return svc.BatchParseTaf(icaos).Response(ctx)
+	}
+
+	if data.Meta {
+		if len(icaos) == 1 {
+			return svc.QueryTafResult(icaos[0]).Response(ctx)
+		}
+		return svc.BatchQueryTafResult(icaos).Response(ctx)
+	}
+
+	if data.Lang != "" && len(icaos) > 1 {
+		translated := svc.BatchTranslateTaf(icaos, data.Lang)
+		if !data.Raw || translated.Data == nil {
+			return translated.Response(ctx)
+		}
+		return rawResponse(ctx, translated.HttpCode, batchTexts(translated.Data))
+	}
+
 	var res *dto.ApiResponse[[]string]
 
-	if len(icaos) == 1 {
-		res = m.service.QueryTaf(icaos[0])
-	} else {
-		res = m.service.BatchQueryTaf(icaos)
+	switch {
+	case data.Lang != "":
+		res = svc.TranslateTaf(icaos[0], data.Lang)
+	case len(icaos) == 1:
+		res = svc.QueryTaf(icaos[0])
+	default:
+		res = svc.BatchQueryTaf(icaos)
 	}
 
 	if !data.Raw || res.Data == nil {
 		return res.Response(ctx)
 	}
 
-	return dto.TextResponse(ctx, res.HttpCode, fmt.Sprintf("<pre>%s</pre>", strings.Join(res.Data, "</pre>\n<pre>")))
+	return rawResponse(ctx, res.HttpCode, res.Data)
+}
+
+func (m *Metar) BatchQueryTaf(ctx echo.Context) error {
+	data := &DTO.QueryBatch{}
+
+	if err := ctx.Bind(data); err != nil {
+		m.logger.Errorf("BatchQueryTaf handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	m.logger.Debugf("BatchQueryTaf with argument: %#v", data)
+
+	r, err := dto.ValidStruct(data)
+	if err != nil {
+		m.logger.Errorf("BatchQueryTaf handle fail, validate err, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrServerError)
+	}
+	if r != nil {
+		m.logger.Errorf("BatchQueryTaf handle fail, validate argument fail, %v", r)
+		return dto.ErrorResponse(ctx, r)
+	}
+
+	svc, _, status := m.replayService(ctx)
+	if status != nil {
+		return dto.ErrorResponse(ctx, status)
+	}
+
+	return svc.BatchQueryTafResult(strings.Split(data.ICAO, ",")).Response(ctx)
+}
+
+func (m *Metar) QueryTafAt(ctx echo.Context) error {
+	data := &DTO.QueryTafAt{}
+
+	if err := ctx.Bind(data); err != nil {
+		m.logger.Errorf("QueryTafAt handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	m.logger.Debugf("QueryTafAt with argument: %#v", data)
+
+	r, err := dto.ValidStruct(data)
+	if err != nil {
+		m.logger.Errorf("QueryTafAt handle fail, validate err, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrServerError)
+	}
+	if r != nil {
+		m.logger.Errorf("QueryTafAt handle fail, validate argument fail, %v", r)
+		return dto.ErrorResponse(ctx, r)
+	}
+
+	svc, now, status := m.replayService(ctx)
+	if status != nil {
+		return dto.ErrorResponse(ctx, status)
+	}
+
+	at := now
+	if data.Time != "" {
+		at, err = time.Parse(time.RFC3339, data.Time)
+		if err != nil {
+			m.logger.Errorf("QueryTafAt handle fail, invalid time %s, %v", data.Time, err)
+			return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+		}
+	}
+
+	return svc.QueryTafAt(data.ICAO, at).Response(ctx)
+}
+
+func (m *Metar) NearestMetar(ctx echo.Context) error {
+	svc, data, status := m.bindNearest(ctx, "NearestMetar")
+	if status != nil {
+		return dto.ErrorResponse(ctx, status)
+	}
+	return svc.NearestMetar(data.ICAO, data.Count).Response(ctx)
+}
+
+func (m *Metar) NearestTaf(ctx echo.Context) error {
+	svc, data, status := m.bindNearest(ctx, "NearestTaf")
+	if status != nil {
+		return dto.ErrorResponse(ctx, status)
+	}
+	return svc.NearestTaf(data.ICAO, data.Count).Response(ctx)
+}
+
+// bindNearest 解析附近站点查询参数, 默认返回最近的一个站点
+func (m *Metar) bindNearest(ctx echo.Context, name string) (service.MetarInterface, *DTO.QueryNearest, *dto.ApiStatus) {
+	data := &DTO.QueryNearest{}
+
+	if err := ctx.Bind(data); err != nil {
+		m.logger.Errorf("%s handle fail, parse argument fail, %v", name, err)
+		return nil, nil, dto.ErrErrorParam
+	}
+
+	m.logger.Debugf("%s with argument: %#v", name, data)
+
+	r, err := dto.ValidStruct(data)
+	if err != nil {
+		m.logger.Errorf("%s handle fail, validate err, %v", name, err)
+		return nil, nil, dto.ErrServerError
+	}
+	if r != nil {
+		m.logger.Errorf("%s handle fail, validate argument fail, %v", name, r)
+		return nil, nil, r
+	}
+
+	if data.Count <= 0 {
+		data.Count = metar.DefaultNearestCount
+	}
+	data.Count = min(data.Count, metar.MaxNearestCount)
+
+	svc, _, status := m.replayService(ctx)
+	if status != nil {
+		return nil, nil, status
+	}
+	return svc, data, nil
+}
+
+func (m *Metar) AreaMetar(ctx echo.Context) error {
+	svc, area, status := m.bindArea(ctx, "AreaMetar")
+	if status != nil {
+		return dto.ErrorResponse(ctx, status)
+	}
+	return svc.AreaMetar(area).Response(ctx)
+}
+
+func (m *Metar) AreaTaf(ctx echo.Context) error {
+	svc, area, status := m.bindArea(ctx, "AreaTaf")
+	if status != nil {
+		return dto.ErrorResponse(ctx, status)
+	}
+	return svc.AreaTaf(area).Response(ctx)
+}
+
+// bindArea 解析区域查询参数, bbox与圆形区域需指定其一
+func (m *Metar) bindArea(ctx echo.Context, name string) (service.MetarInterface, *metar.Area, *dto.ApiStatus) {
+	data := &DTO.QueryArea{}
+
+	if err := ctx.Bind(data); err != nil {
+		m.logger.Errorf("%s handle fail, parse argument fail, %v", name, err)
+		return nil, nil, dto.ErrErrorParam
+	}
+
+	m.logger.Debugf("%s with argument: %#v", name, data)
+
+	area, status := m.parseArea(name, data.BBox, data.Latitude, data.Longitude, data.RadiusNM)
+	if status != nil {
+		return nil, nil, status
+	}
+
+	svc, _, status := m.replayService(ctx)
+	if status != nil {
+		return nil, nil, status
+	}
+	return svc, area, nil
+}
+
+// parseArea bbox为"西,南,东,北"(度), 未指定bbox时使用中心与半径(海里)指定的圆形区域
+func (m *Metar) parseArea(name string, bbox string, latitude *float64, longitude *float64, radiusNM float64) (*metar.Area, *dto.ApiStatus) {
+	area := &metar.Area{}
+	if bbox != "" {
+		bounds := strings.Split(bbox, ",")
+		if len(bounds) != 4 {
+			m.logger.Errorf("%s handle fail, invalid bbox %s", name, bbox)
+			return nil, dto.ErrErrorParam
+		}
+		targets := []*float64{&area.West, &area.South, &area.East, &area.North}
+		for index, bound := range bounds {
+			value, err := strconv.ParseFloat(strings.TrimSpace(bound), 64)
+			if err != nil {
+				m.logger.Errorf("%s handle fail, invalid bbox %s, %v", name, bbox, err)
+				return nil, dto.ErrErrorParam
+			}
+			*targets[index] = value
+		}
+		if !area.Valid() {
+			m.logger.Errorf("%s handle fail, invalid bbox %s", name, bbox)
+			return nil, dto.ErrErrorParam
+		}
+		return area, nil
+	}
+	if radiusNM <= 0 {
+		m.logger.Errorf("%s handle fail, need bbox or radius_nm", name)
+		return nil, dto.ErrErrorParam
+	}
+	// 未指定中心时不能使用默认值0, 否则会静默查询赤道与本初子午线交点附近的区域
+	if latitude == nil || longitude == nil {
+		m.logger.Errorf("%s handle fail, need lat and lon with radius_nm", name)
+		return nil, dto.ErrErrorParam
+	}
+	area.Latitude = *latitude
+	area.Longitude = *longitude
+	area.Radius = radiusNM * metar.NauticalMile
+	if !area.Valid() {
+		m.logger.Errorf("%s handle fail, invalid center %g,%g", name, area.Latitude, area.Longitude)
+		return nil, dto.ErrErrorParam
+	}
+	return area, nil
+}
+
+// GeoJSONMetar 直接返回GeoJSON要素集合, 便于地图库直接使用, 出错时返回与其他接口一致的错误结构
+func (m *Metar) GeoJSONMetar(ctx echo.Context) error {
+	data := &DTO.QueryGeoJSON{}
+
+	if err := ctx.Bind(data); err != nil {
+		m.logger.Errorf("GeoJSONMetar handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	m.logger.Debugf("GeoJSONMetar with argument: %#v", data)
+
+	svc, _, status := m.replayService(ctx)
+	if status != nil {
+		return dto.ErrorResponse(ctx, status)
+	}
+
+	var res *dto.ApiResponse[*metar.FeatureCollection]
+	if data.ICAO != "" {
+		res = svc.GeoJSONMetar(strings.Split(data.ICAO, ","))
+	} else {
+		area, status := m.parseArea("GeoJSONMetar", data.BBox, data.Latitude, data.Longitude, data.RadiusNM)
+		if status != nil {
+			return dto.ErrorResponse(ctx, status)
+		}
+		res = svc.AreaGeoJSONMetar(area)
+	}
+
+	if res.Data == nil {
+		return res.Response(ctx)
+	}
+
+	body, err := json.Marshal(res.Data)
+	if err != nil {
+		m.logger.Errorf("GeoJSONMetar handle fail, marshal fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrServerError)
+	}
+	return ctx.Blob(http.StatusOK, "application/geo+json", body)
 }
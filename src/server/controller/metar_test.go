@@ -0,0 +1,76 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import (
+	"testing"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// nopLogger 丢弃测试中的日志
+type nopLogger struct {
+	logger.Interface
+}
+
+func (nopLogger) Errorf(string, ...any) {}
+
+func TestFormatConflict(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    bool
+		decode bool
+		meta   bool
+		lang   string
+		want   bool
+	}{
+		{"plain", false, false, false, "", false},
+		{"raw", true, false, false, "", false},
+		{"decode", false, true, false, "", false},
+		{"meta", false, false, true, "", false},
+		{"raw translation", true, false, false, "zh", false},
+		{"decode and meta", false, true, true, "", true},
+		{"decode and lang", false, true, false, "en", true},
+		{"meta and lang", false, false, true, "zh", true},
+		{"raw decode", true, true, false, "", true},
+		{"raw meta", true, false, true, "", true},
+	}
+	for _, tt := range tests {
+		if got := formatConflict(tt.raw, tt.decode, tt.meta, tt.lang); got != tt.want {
+			t.Errorf("formatConflict() %s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseArea(t *testing.T) {
+	latitude, longitude, outOfRange := 31.2, 121.3, 181.0
+	tests := []struct {
+		name      string
+		bbox      string
+		latitude  *float64
+		longitude *float64
+		radiusNM  float64
+		want      bool
+	}{
+		{"bbox", "120,30,122,32", nil, nil, 0, true},
+		{"bbox across antimeridian", "170,-40,-170,-10", nil, nil, 0, true},
+		{"bbox with three bounds", "120,30,122", nil, nil, 0, false},
+		{"bbox not a number", "120,30,east,32", nil, nil, 0, false},
+		{"bbox south above north", "120,32,122,30", nil, nil, 0, false},
+		{"bbox latitude out of range", "120,-95,122,32", nil, nil, 0, false},
+		{"radius", "", &latitude, &longitude, 50, true},
+		{"radius without center", "", nil, nil, 50, false},
+		{"radius without longitude", "", &latitude, nil, 50, false},
+		{"radius longitude out of range", "", &latitude, &outOfRange, 50, false},
+		{"neither bbox nor radius", "", &latitude, &longitude, 0, false},
+	}
+	controller := &Metar{logger: nopLogger{}}
+	for _, tt := range tests {
+		area, _ := controller.parseArea("AreaMetar", tt.bbox, tt.latitude, tt.longitude, tt.radiusNM)
+		if (area != nil) != tt.want {
+			t.Errorf("parseArea() %s = %+v, want valid %v", tt.name, area, tt.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package controller
+package controller
+
+import (
+	DTO "metar-service/src/interfaces/server/dto"
+	"metar-service/src/interfaces/server/service"
+
+	"github.com/labstack/echo/v4"
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type Station struct {
+	logger  logger.Interface
+	service service.StationInterface
+}
+
+func NewStation(
+	lg logger.Interface,
+	service service.StationInterface,
+) *Station {
+	return &Station{
+		logger:  logger.NewLoggerAdapter(lg, "station-controller"),
+		service: service,
+	}
+}
+
+func (s *Station) QueryStation(ctx echo.Context) error {
+	data := &DTO.QueryStation{}
+
+	if err := ctx.Bind(data); err != nil {
+		s.logger.Errorf("QueryStation handle fail, parse argument fail, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrErrorParam)
+	}
+
+	s.logger.Debugf("QueryStation with argument: %#v", data)
+
+	r, err := dto.ValidStruct(data)
+	if err != nil {
+		s.logger.Errorf("QueryStation handle fail, validate err, %v", err)
+		return dto.ErrorResponse(ctx, dto.ErrServerError)
+	}
+	if r != nil {
+		s.logger.Errorf("QueryStation handle fail, validate argument fail, %v", r)
+		return dto.ErrorResponse(ctx, r)
+	}
+
+	return s.service.QueryStation(data.ICAO).Response(ctx)
+}
@@ -5,12 +5,15 @@
 package server
 
 import (
+	"crypto/subtle"
 	"io"
 	"metar-service/src/interfaces/content"
+	"metar-service/src/interfaces/metar"
 	controllerImpl "metar-service/src/server/controller"
 	serviceImpl "metar-service/src/server/service"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"github.com/labstack/gommon/log"
 	h "half-nothing.cn/service-core/http"
 	"half-nothing.cn/service-core/interfaces/logger"
@@ -32,13 +35,50 @@ func StartHttpServer(content *content.ApplicationContent) {
 		h.SetTelemetry(e, c.TelemetryConfig, h.SkipperHealthCheck)
 	}
 
-	metarController := controllerImpl.NewMetar(lg, serviceImpl.NewMetar(lg, content.MetarManager(), content.TafManager()))
+	metarController := controllerImpl.NewMetar(lg, serviceImpl.NewMetar(lg, content.MetarManager(), content.TafManager(), content.MetarParser(), content.TafParser(), content.Classifier(), content.Translator(), content.Replay(), content.StationDatabase()))
+
+	historyController := controllerImpl.NewHistory(lg, serviceImpl.NewHistory(lg, content.MetarArchive(), content.TafArchive(), content.StationDatabase()))
+
+	encoderController := controllerImpl.NewEncoder(lg, serviceImpl.NewEncoder(lg, content.MetarEncoder(), content.TafEncoder()))
+
+	stationController := controllerImpl.NewStation(lg, serviceImpl.NewStation(lg, content.StationDatabase()))
 
 	h.SetHealthPoint(e)
 
 	apiGroup := e.Group("/api/v1")
 	apiGroup.GET("/metar", metarController.QueryMetar)
+	apiGroup.GET("/metar/batch", metarController.BatchQueryMetar)
+	apiGroup.GET("/metar/nearest", metarController.NearestMetar)
+	apiGroup.GET("/metar/area", metarController.AreaMetar)
+	apiGroup.GET("/metar/geojson", metarController.GeoJSONMetar)
+	apiGroup.GET("/metar/history", historyController.QueryMetarHistory)
+	apiGroup.POST("/metar/encode", encoderController.EncodeMetar)
 	apiGroup.GET("/taf", metarController.QueryTaf)
+	apiGroup.GET("/taf/batch", metarController.BatchQueryTaf)
+	apiGroup.GET("/taf/at", metarController.QueryTafAt)
+	apiGroup.GET("/taf/nearest", metarController.NearestTaf)
+	apiGroup.GET("/taf/area", metarController.AreaTaf)
+	apiGroup.GET("/taf/history", historyController.QueryTafHistory)
+	apiGroup.POST("/taf/encode", encoderController.EncodeTaf)
+	apiGroup.GET("/station", stationController.QueryStation)
+
+	if c.AdminConfig.Enable {
+		// 未启用归档时没有历史回放, 模拟时钟接口返回归档未启用
+		clock, _ := content.Replay().(metar.ReplayClockInterface)
+		adminController := controllerImpl.NewAdmin(lg, serviceImpl.NewAdmin(lg, content.MetarOverride(), content.TafOverride(), content.Scenario(), content.MetarParser(), content.TafParser(), content.StationDatabase(), clock))
+		adminGroup := apiGroup.Group("/admin", middleware.KeyAuth(func(key string, _ echo.Context) (bool, error) {
+			return subtle.ConstantTimeCompare([]byte(key), []byte(c.AdminConfig.Token)) == 1, nil
+		}))
+		adminGroup.GET("/override", adminController.ListOverride)
+		adminGroup.PUT("/override", adminController.SetOverride)
+		adminGroup.DELETE("/override", adminController.DeleteOverride)
+		adminGroup.GET("/scenario", adminController.ScenarioStatus)
+		adminGroup.POST("/scenario", adminController.LoadScenario)
+		adminGroup.DELETE("/scenario", adminController.StopScenario)
+		adminGroup.GET("/replay", adminController.ReplayClock)
+		adminGroup.PUT("/replay", adminController.SetReplayClock)
+		adminGroup.DELETE("/replay", adminController.ClearReplayClock)
+	}
 
 	h.SetUnmatchedRoute(e)
 	h.SetCleaner(content.Cleaner(), e)
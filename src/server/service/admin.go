@@ -0,0 +1,201 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"errors"
+	"metar-service/src/interfaces/metar"
+	"metar-service/src/override"
+	"strings"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type Admin struct {
+	logger      logger.Interface
+	metarStore  metar.OverrideInterface
+	tafStore    metar.OverrideInterface
+	runner      metar.ScenarioRunnerInterface
+	metarParser metar.ParserInterface[*metar.Metar]
+	tafParser   metar.ParserInterface[*metar.Taf]
+	stations    metar.StationDatabaseInterface
+	clock       metar.ReplayClockInterface // 全局模拟时钟, 未启用归档时为空
+}
+
+func NewAdmin(
+	lg logger.Interface,
+	metarStore metar.OverrideInterface,
+	tafStore metar.OverrideInterface,
+	runner metar.ScenarioRunnerInterface,
+	metarParser metar.ParserInterface[*metar.Metar],
+	tafParser metar.ParserInterface[*metar.Taf],
+	stations metar.StationDatabaseInterface,
+	clock metar.ReplayClockInterface,
+) *Admin {
+	return &Admin{
+		logger:      logger.NewLoggerAdapter(lg, "admin-service"),
+		metarStore:  metarStore,
+		tafStore:    tafStore,
+		runner:      runner,
+		metarParser: metarParser,
+		tafParser:   tafParser,
+		stations:    stations,
+		clock:       clock,
+	}
+}
+
+var (
+	ErrOverrideNotFound = dto.NewApiStatus("NOT_FOUND", "Override not found", dto.HttpCodeNotFound)
+	ErrScenarioNotFound = dto.NewApiStatus("NOT_FOUND", "No scenario is running", dto.HttpCodeNotFound)
+)
+
+// store 返回报文类型对应的存储, 类型为空时为METAR
+func (a *Admin) store(reportType string) metar.OverrideInterface {
+	switch strings.ToLower(reportType) {
+	case "", metar.ReportTypeMetar:
+		return a.metarStore
+	case metar.ReportTypeTaf:
+		return a.tafStore
+	default:
+		return nil
+	}
+}
+
+// validReport 检查报文能否被解析且报文中的站点与指定的站点一致, 避免下发的报文导致解码与翻译失败
+func (a *Admin) validReport(reportType string, icao string, data string) bool {
+	var station string
+	if strings.ToLower(reportType) == metar.ReportTypeTaf {
+		taf, err := a.tafParser.Parse(data)
+		if err != nil {
+			a.logger.Errorf("Invalid %s report %s: %v", reportType, data, err)
+			return false
+		}
+		station = taf.Station
+	} else {
+		report, err := a.metarParser.Parse(data)
+		if err != nil {
+			a.logger.Errorf("Invalid %s report %s: %v", reportType, data, err)
+			return false
+		}
+		station = report.Station
+	}
+	if station != icao {
+		a.logger.Errorf("Station %s of %s report does not match %s", station, reportType, icao)
+		return false
+	}
+	return true
+}
+
+func (a *Admin) ListOverride(reportType string) *dto.ApiResponse[[]*metar.Override] {
+	store := a.store(reportType)
+	if store == nil {
+		return dto.NewApiResponse[[]*metar.Override](dto.ErrErrorParam, nil)
+	}
+	return dto.NewApiResponse[[]*metar.Override](dto.SuccessHandleRequest, store.List())
+}
+
+func (a *Admin) SetOverride(reportType string, icao string, data string, expire time.Duration) *dto.ApiResponse[*metar.Override] {
+	store := a.store(reportType)
+	icao, err := metar.ResolveICAO(a.stations, icao)
+	if store == nil || err != nil || expire <= 0 {
+		return dto.NewApiResponse[*metar.Override](dto.ErrErrorParam, nil)
+	}
+	data = strings.TrimSpace(data)
+	if !a.validReport(reportType, icao, data) {
+		return dto.NewApiResponse[*metar.Override](dto.ErrErrorParam, nil)
+	}
+	override := &metar.Override{
+		ICAO:     icao,
+		Data:     data,
+		Source:   metar.OverrideSourceManual,
+		ExpireAt: time.Now().Add(expire),
+	}
+	store.Set(override)
+	a.logger.Infof("Override %s of %s until %s", reportType, override.ICAO, override.ExpireAt.Format(time.RFC3339))
+	return dto.NewApiResponse[*metar.Override](dto.SuccessHandleRequest, override)
+}
+
+func (a *Admin) DeleteOverride(reportType string, icao string) *dto.ApiResponse[bool] {
+	store := a.store(reportType)
+	icao, err := metar.ResolveICAO(a.stations, icao)
+	if store == nil || err != nil {
+		return dto.NewApiResponse[bool](dto.ErrErrorParam, false)
+	}
+	if _, ok := store.Get(icao); !ok {
+		return dto.NewApiResponse[bool](ErrOverrideNotFound, false)
+	}
+	store.Delete(icao)
+	a.logger.Infof("Override %s of %s removed", reportType, icao)
+	return dto.NewApiResponse[bool](dto.SuccessHandleRequest, true)
+}
+
+func (a *Admin) LoadScenario(data []byte) *dto.ApiResponse[*metar.ScenarioStatus] {
+	scenario, err := override.ParseScenario(data)
+	if err != nil {
+		a.logger.Errorf("LoadScenario fail, %v", err)
+		return dto.NewApiResponse[*metar.ScenarioStatus](dto.ErrErrorParam, nil)
+	}
+	for _, step := range scenario.Steps {
+		if step.ICAO, err = metar.ResolveICAO(a.stations, step.ICAO); err != nil {
+			a.logger.Errorf("LoadScenario fail, invalid icao of step at %s: %v", step.At, err)
+			return dto.NewApiResponse[*metar.ScenarioStatus](dto.ErrErrorParam, nil)
+		}
+		if !a.validReport(step.Type, step.ICAO, strings.TrimSpace(step.Data)) {
+			return dto.NewApiResponse[*metar.ScenarioStatus](dto.ErrErrorParam, nil)
+		}
+	}
+	if err := a.runner.Load(scenario); err != nil {
+		a.logger.Errorf("LoadScenario fail, %v", err)
+		if errors.Is(err, metar.ErrScenarioInvalid) {
+			return dto.NewApiResponse[*metar.ScenarioStatus](dto.ErrErrorParam, nil)
+		}
+		return dto.NewApiResponse[*metar.ScenarioStatus](dto.ErrServerError, nil)
+	}
+	return dto.NewApiResponse[*metar.ScenarioStatus](dto.SuccessHandleRequest, a.runner.Status())
+}
+
+func (a *Admin) ScenarioStatus() *dto.ApiResponse[*metar.ScenarioStatus] {
+	status := a.runner.Status()
+	if status == nil {
+		return dto.NewApiResponse[*metar.ScenarioStatus](ErrScenarioNotFound, nil)
+	}
+	return dto.NewApiResponse[*metar.ScenarioStatus](dto.SuccessHandleRequest, status)
+}
+
+func (a *Admin) StopScenario() *dto.ApiResponse[bool] {
+	if a.runner.Status() == nil {
+		return dto.NewApiResponse[bool](ErrScenarioNotFound, false)
+	}
+	a.runner.Stop()
+	return dto.NewApiResponse[bool](dto.SuccessHandleRequest, true)
+}
+
+func (a *Admin) ReplayClock() *dto.ApiResponse[*metar.ReplayClock] {
+	if a.clock == nil {
+		return dto.NewApiResponse[*metar.ReplayClock](ErrArchiveDisabled, nil)
+	}
+	return dto.NewApiResponse[*metar.ReplayClock](dto.SuccessHandleRequest, a.clock.Clock())
+}
+
+func (a *Admin) SetReplayClock(start time.Time, speed float64) *dto.ApiResponse[*metar.ReplayClock] {
+	if a.clock == nil {
+		return dto.NewApiResponse[*metar.ReplayClock](ErrArchiveDisabled, nil)
+	}
+	if start.IsZero() || speed <= 0 {
+		return dto.NewApiResponse[*metar.ReplayClock](dto.ErrErrorParam, nil)
+	}
+	a.clock.SetClock(start, speed)
+	return dto.NewApiResponse[*metar.ReplayClock](dto.SuccessHandleRequest, a.clock.Clock())
+}
+
+func (a *Admin) ClearReplayClock() *dto.ApiResponse[bool] {
+	if a.clock == nil {
+		return dto.NewApiResponse[bool](ErrArchiveDisabled, false)
+	}
+	a.clock.ClearClock()
+	return dto.NewApiResponse[bool](dto.SuccessHandleRequest, true)
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"metar-service/src/interfaces/metar"
+	"metar-service/src/metar/parser"
+	"metar-service/src/override"
+	"testing"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// nopLogger 丢弃测试中的日志
+type nopLogger struct {
+	logger.Interface
+}
+
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+func (nopLogger) Warnf(string, ...any)  {}
+
+func TestAdminOverrideResolvesICAO(t *testing.T) {
+	store := override.NewStore()
+	admin := &Admin{logger: nopLogger{}, metarStore: store, tafStore: override.NewStore(), metarParser: parser.NewMetarParser()}
+	data := "METAR ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021 NOSIG"
+
+	admin.SetOverride("metar", " zbaa ", data, time.Hour)
+	if override, ok := store.Get("ZBAA"); !ok || override.ICAO != "ZBAA" {
+		t.Fatalf("override of ZBAA = %v, %v, want stored", override, ok)
+	}
+	admin.SetOverride("metar", "ZB1", data, time.Hour)
+	if overrides := store.List(); len(overrides) != 1 {
+		t.Errorf("overrides = %d, want invalid icao ignored", len(overrides))
+	}
+
+	admin.DeleteOverride("metar", "zbaa ")
+	if _, ok := store.Get("ZBAA"); ok {
+		t.Error("override of ZBAA was not deleted")
+	}
+}
+
+func TestAdminOverrideRejectsStationMismatch(t *testing.T) {
+	store := override.NewStore()
+	admin := &Admin{logger: nopLogger{}, metarStore: store, tafStore: override.NewStore(), metarParser: parser.NewMetarParser()}
+
+	admin.SetOverride("metar", "ZSSS", "METAR ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021 NOSIG", time.Hour)
+	if overrides := store.List(); len(overrides) != 0 {
+		t.Errorf("overrides = %v, want report of ZBAA rejected for ZSSS", overrides)
+	}
+
+	scenario := []byte(`{"name":"mismatch","duration":"1h","steps":[{"at":"0s","type":"metar","icao":"ZSSS","data":"METAR ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021"}]}`)
+	admin.runner = override.NewRunner(nopLogger{}, store, admin.tafStore)
+	admin.LoadScenario(scenario)
+	if status := admin.runner.Status(); status != nil {
+		t.Errorf("Status() = %+v, want scenario with mismatched station rejected", status)
+	}
+}
+
+// replayClock 记录设置的模拟时钟
+type replayClock struct {
+	clock *metar.ReplayClock
+}
+
+func (c *replayClock) Clock() *metar.ReplayClock { return c.clock }
+
+func (c *replayClock) SetClock(start time.Time, speed float64) {
+	c.clock = &metar.ReplayClock{Enable: true, Start: &start, Speed: speed, Now: &start}
+}
+
+func (c *replayClock) ClearClock() {
+	c.clock = &metar.ReplayClock{}
+}
+
+func TestAdminReplayClock(t *testing.T) {
+	clock := &replayClock{clock: &metar.ReplayClock{}}
+	admin := &Admin{logger: nopLogger{}, clock: clock}
+	start := time.Date(2025, time.June, 1, 8, 0, 0, 0, time.UTC)
+
+	admin.SetReplayClock(start, 0)
+	admin.SetReplayClock(time.Time{}, 1)
+	if clock.clock.Enable {
+		t.Fatal("SetReplayClock() accepted invalid clock")
+	}
+	admin.SetReplayClock(start, 60)
+	if !clock.clock.Enable || !clock.clock.Start.Equal(start) || clock.clock.Speed != 60 {
+		t.Errorf("clock = %+v, want start %s with speed 60", clock.clock, start)
+	}
+	admin.ClearReplayClock()
+	if clock.clock.Enable {
+		t.Error("ClearReplayClock() did not disable clock")
+	}
+
+	// 未启用归档时没有模拟时钟, 不应panic
+	disabled := &Admin{logger: nopLogger{}}
+	disabled.ReplayClock()
+	disabled.SetReplayClock(start, 1)
+	disabled.ClearReplayClock()
+}
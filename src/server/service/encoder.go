@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"errors"
+	"metar-service/src/interfaces/metar"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type Encoder struct {
+	logger       logger.Interface
+	metarEncoder metar.EncoderInterface[*metar.Metar]
+	tafEncoder   metar.EncoderInterface[*metar.Taf]
+}
+
+func NewEncoder(
+	lg logger.Interface,
+	metarEncoder metar.EncoderInterface[*metar.Metar],
+	tafEncoder metar.EncoderInterface[*metar.Taf],
+) *Encoder {
+	return &Encoder{
+		logger:       logger.NewLoggerAdapter(lg, "encoder-service"),
+		metarEncoder: metarEncoder,
+		tafEncoder:   tafEncoder,
+	}
+}
+
+func (e *Encoder) EncodeMetar(data *metar.Metar) *dto.ApiResponse[string] {
+	result, err := e.metarEncoder.Encode(data)
+	if errors.Is(err, metar.ErrReportInvalid) {
+		e.logger.Errorf("EncodeMetar fail, %v", err)
+		return dto.NewApiResponse[string](dto.ErrErrorParam, "")
+	}
+	if err != nil {
+		e.logger.Errorf("EncodeMetar fail, %v", err)
+		return dto.NewApiResponse[string](dto.ErrServerError, "")
+	}
+	return dto.NewApiResponse[string](dto.SuccessHandleRequest, result)
+}
+
+func (e *Encoder) EncodeTaf(data *metar.Taf) *dto.ApiResponse[string] {
+	result, err := e.tafEncoder.Encode(data)
+	if errors.Is(err, metar.ErrReportInvalid) {
+		e.logger.Errorf("EncodeTaf fail, %v", err)
+		return dto.NewApiResponse[string](dto.ErrErrorParam, "")
+	}
+	if err != nil {
+		e.logger.Errorf("EncodeTaf fail, %v", err)
+		return dto.NewApiResponse[string](dto.ErrServerError, "")
+	}
+	return dto.NewApiResponse[string](dto.SuccessHandleRequest, result)
+}
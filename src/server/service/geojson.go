@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"metar-service/src/interfaces/metar"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+)
+
+// cavokVisibility CAVOK时的能见度(米)
+const cavokVisibility = 10000
+
+func (m *Metar) GeoJSONMetar(icaos []string) *dto.ApiResponse[*metar.FeatureCollection] {
+	data := m.toFeatureCollection(m.metarManager.BatchQueryResult(icaos))
+	return dto.NewApiResponse[*metar.FeatureCollection](dto.SuccessHandleRequest, data)
+}
+
+func (m *Metar) AreaGeoJSONMetar(area *metar.Area) *dto.ApiResponse[*metar.FeatureCollection] {
+	if !area.Valid() {
+		return dto.NewApiResponse[*metar.FeatureCollection](dto.ErrErrorParam, nil)
+	}
+	results, err := m.areaResults(m.metarManager, area)
+	if err != nil {
+		return dto.NewApiResponse[*metar.FeatureCollection](ErrNoStationData, nil)
+	}
+	data := m.toFeatureCollection(results)
+	return dto.NewApiResponse[*metar.FeatureCollection](dto.SuccessHandleRequest, data)
+}
+
+// toFeatureCollection 将有报文的查询结果转换为GeoJSON, 无法解析的报文只返回原始报文
+// 机场数据库中没有的站点没有坐标, 返回geometry为null的要素, 避免有报文的站点在地图上被静默丢弃
+func (m *Metar) toFeatureCollection(results []*metar.QueryResult) *metar.FeatureCollection {
+	collection := metar.NewFeatureCollection()
+	missing := make([]string, 0)
+	for _, result := range results {
+		if result.Status != metar.QueryStatusOk {
+			continue
+		}
+		properties := &metar.StationConditions{
+			ICAO:            result.ICAO,
+			Raw:             result.Data,
+			ObservationTime: result.ObservationTime,
+			Age:             result.Age,
+			Stale:           result.Stale,
+			Override:        result.Override,
+		}
+		if result.Fallback != nil {
+			properties.FallbackRequested = result.Fallback.Requested
+			properties.FallbackDistanceNM = &result.Fallback.DistanceNM
+		}
+		if report, err := m.metarParser.ParseAt(result.Data, m.now()); err != nil {
+			m.logger.Errorf("GeoJSONMetar fail, cannot parse %s: %v", result.Data, err)
+		} else {
+			m.classifyMetar(report)
+			setConditions(properties, &report.Conditions)
+		}
+		feature := &metar.Feature{
+			Type:       metar.GeoJSONFeature,
+			ID:         result.ICAO,
+			Properties: properties,
+		}
+		if station, ok := m.stations.Get(result.ICAO); ok {
+			properties.Name = station.Name
+			feature.Geometry = &metar.Point{
+				Type:        metar.GeoJSONPoint,
+				Coordinates: []float64{station.Longitude, station.Latitude},
+			}
+		} else {
+			missing = append(missing, result.ICAO)
+		}
+		collection.Features = append(collection.Features, feature)
+	}
+	if len(missing) > 0 {
+		m.logger.Warnf("GeoJSONMetar stations %v are not in station database, returned without geometry", missing)
+	}
+	return collection
+}
+
+func setConditions(properties *metar.StationConditions, conditions *metar.Conditions) {
+	properties.FlightCategory = conditions.FlightCategory
+	properties.Ceiling = conditions.Ceiling()
+	if wind := conditions.Wind; wind != nil {
+		properties.WindDirection = wind.Direction
+		properties.WindVariable = wind.Variable
+		properties.WindSpeed = wind.Speed
+		properties.WindGust = wind.Gust
+		properties.WindUnit = wind.Unit
+	}
+	if conditions.Visibility != nil {
+		visibility := conditions.Visibility.Meters
+		properties.Visibility = &visibility
+	} else if conditions.Cavok {
+		visibility := float64(cavokVisibility)
+		properties.Visibility = &visibility
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"metar-service/src/interfaces/config"
+	"metar-service/src/interfaces/metar"
+	"metar-service/src/metar/category"
+	"metar-service/src/metar/parser"
+	"strings"
+	"testing"
+)
+
+// stations 只包含ZBAA的机场数据库
+type stations struct {
+	metar.StationDatabaseInterface
+}
+
+func (stations) Get(icao string) (*metar.Station, bool) {
+	if icao == "ZBAA" {
+		return &metar.Station{ICAO: icao, Name: "Beijing Capital", Latitude: 40.08, Longitude: 116.58}, true
+	}
+	return nil, false
+}
+
+// Area 北半球的区域内只有ZBAA, 其他区域没有站点
+func (s stations) Area(area *metar.Area, _ int) []*metar.Station {
+	if area.North <= 0 {
+		return nil
+	}
+	station, _ := s.Get("ZBAA")
+	return []*metar.Station{station, {ICAO: "ZSSS"}}
+}
+
+func TestAreaResultsMissingStation(t *testing.T) {
+	service := newBatchService()
+	service.stations = stations{}
+
+	// 区域内没有机场数据库中的站点时返回错误, 与站点都没有报文区分
+	if _, err := service.areaResults(service.metarManager, &metar.Area{South: -10, West: 0, North: -5, East: 10}); !errors.Is(err, metar.ErrStationUnknown) {
+		t.Errorf("areaResults() error = %v, want %v", err, metar.ErrStationUnknown)
+	}
+	results, err := service.areaResults(service.metarManager, &metar.Area{South: 30, West: 100, North: 45, East: 125})
+	if err != nil || len(results) != 1 || results[0].ICAO != "ZBAA" {
+		t.Errorf("areaResults() = %v, %v, want only ZBAA with report", results, err)
+	}
+}
+
+func TestFeatureCollectionMissingStation(t *testing.T) {
+	service := &Metar{
+		logger:      nopLogger{},
+		metarParser: parser.NewMetarParser(),
+		classifier:  category.NewClassifier(&config.FlightCategoryConfig{Standard: "faa"}),
+		stations:    stations{},
+	}
+	collection := service.toFeatureCollection([]*metar.QueryResult{
+		{ICAO: "ZBAA", Status: metar.QueryStatusOk, Data: "METAR ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021 NOSIG"},
+		{ICAO: "ZZZZ", Status: metar.QueryStatusOk, Data: "METAR ZZZZ 151100Z 36005MPS 9999 FEW030 12/M05 Q1021"},
+		{ICAO: "ZSSS", Status: metar.QueryStatusNotFound},
+	})
+
+	if len(collection.Features) != 2 {
+		t.Fatalf("features = %d, want 2", len(collection.Features))
+	}
+	known, missing := collection.Features[0], collection.Features[1]
+	if known.Geometry == nil || known.Geometry.Coordinates[0] != 116.58 || known.Properties.Name != "Beijing Capital" {
+		t.Errorf("ZBAA feature = %+v, want point with station name", known)
+	}
+	if missing.ID != "ZZZZ" || missing.Geometry != nil || missing.Properties.FlightCategory == "" {
+		t.Errorf("ZZZZ feature = %+v, want conditions without geometry", missing)
+	}
+	data, err := json.Marshal(missing)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"geometry":null`) {
+		t.Errorf("Marshal() = %s, want null geometry", data)
+	}
+}
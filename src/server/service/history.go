@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"metar-service/src/interfaces/metar"
+	"time"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type History struct {
+	logger       logger.Interface
+	metarArchive metar.ArchiveInterface
+	tafArchive   metar.ArchiveInterface
+	stations     metar.StationDatabaseInterface
+}
+
+func NewHistory(
+	lg logger.Interface,
+	metarArchive metar.ArchiveInterface,
+	tafArchive metar.ArchiveInterface,
+	stations metar.StationDatabaseInterface,
+) *History {
+	return &History{
+		logger:       logger.NewLoggerAdapter(lg, "history-service"),
+		metarArchive: metarArchive,
+		tafArchive:   tafArchive,
+		stations:     stations,
+	}
+}
+
+var ErrArchiveDisabled = dto.NewApiStatus("ARCHIVE_DISABLED", "History archive is disabled", dto.HttpCodeNotFound)
+
+func (h *History) QueryMetarHistory(icao string, from time.Time, to time.Time, limit int) *dto.ApiResponse[[]*metar.ArchivedReport] {
+	return h.queryHistory(h.metarArchive, icao, from, to, limit)
+}
+
+func (h *History) QueryTafHistory(icao string, from time.Time, to time.Time, limit int) *dto.ApiResponse[[]*metar.ArchivedReport] {
+	return h.queryHistory(h.tafArchive, icao, from, to, limit)
+}
+
+func (h *History) queryHistory(
+	archive metar.ArchiveInterface,
+	icao string,
+	from time.Time,
+	to time.Time,
+	limit int,
+) *dto.ApiResponse[[]*metar.ArchivedReport] {
+	if archive == nil {
+		return dto.NewApiResponse[[]*metar.ArchivedReport](ErrArchiveDisabled, nil)
+	}
+	icao, err := metar.ResolveICAO(h.stations, icao)
+	if err != nil || from.After(to) {
+		return dto.NewApiResponse[[]*metar.ArchivedReport](dto.ErrErrorParam, nil)
+	}
+	data, err := archive.History(icao, from, to, limit)
+	if err != nil {
+		h.logger.Errorf("QueryHistory fail, cannot query history of %s: %v", icao, err)
+		return dto.NewApiResponse[[]*metar.ArchivedReport](dto.ErrServerError, nil)
+	}
+	return dto.NewApiResponse[[]*metar.ArchivedReport](dto.SuccessHandleRequest, data)
+}
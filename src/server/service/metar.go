@@ -7,6 +7,10 @@ package service
 import (
 	"errors"
 	"metar-service/src/interfaces/metar"
+	"metar-service/src/interfaces/server/service"
+	"metar-service/src/metar/forecast"
+	"net/http"
+	"time"
 
 	"half-nothing.cn/service-core/interfaces/http/dto"
 	"half-nothing.cn/service-core/interfaces/logger"
@@ -16,21 +20,99 @@ type Metar struct {
 	logger       logger.Interface
 	metarManager metar.ManagerInterface
 	tafManager   metar.ManagerInterface
+	metarParser  metar.ParserInterface[*metar.Metar]
+	tafParser    metar.ParserInterface[*metar.Taf]
+	classifier   metar.ClassifierInterface
+	translator   metar.TranslatorInterface
+	replay       metar.ReplayInterface
+	stations     metar.StationDatabaseInterface
+	reference    time.Time // 回放的模拟时间, 实时查询时为空
 }
 
 func NewMetar(
 	lg logger.Interface,
 	metarManager metar.ManagerInterface,
 	tafManager metar.ManagerInterface,
+	metarParser metar.ParserInterface[*metar.Metar],
+	tafParser metar.ParserInterface[*metar.Taf],
+	classifier metar.ClassifierInterface,
+	translator metar.TranslatorInterface,
+	replay metar.ReplayInterface,
+	stations metar.StationDatabaseInterface,
 ) *Metar {
 	return &Metar{
 		logger:       logger.NewLoggerAdapter(lg, "metar-service"),
 		metarManager: metarManager,
 		tafManager:   tafManager,
+		metarParser:  metarParser,
+		tafParser:    tafParser,
+		classifier:   classifier,
+		translator:   translator,
+		replay:       replay,
+		stations:     stations,
 	}
 }
 
-var ErrMetarNotFound = dto.NewApiStatus("NOT_FOUND", "Metar not found", dto.HttpCodeNotFound)
+// ReplayTime 返回全局回放的模拟时间, 未启用全局回放时返回false
+func (m *Metar) ReplayTime() (time.Time, bool) {
+	if m.replay == nil {
+		return time.Time{}, false
+	}
+	return m.replay.Now()
+}
+
+// Replay 返回从归档中查询模拟时间时报文的服务, 其余处理与实时查询一致
+func (m *Metar) Replay(at time.Time) (service.MetarInterface, *dto.ApiStatus) {
+	if m.replay == nil {
+		return nil, ErrArchiveDisabled
+	}
+	replayed := *m
+	replayed.metarManager = m.replay.MetarManager(at)
+	replayed.tafManager = m.replay.TafManager(at)
+	replayed.reference = at
+	return &replayed, nil
+}
+
+// now 返回解析报文的参考时间, 回放时为模拟时间
+func (m *Metar) now() time.Time {
+	if m.reference.IsZero() {
+		return time.Now()
+	}
+	return m.reference
+}
+
+// classifyMetar 计算观测与趋势预报的飞行类别, 趋势预报只包含变化的要素, 因此叠加到观测上再计算
+func (m *Metar) classifyMetar(data *metar.Metar) {
+	data.FlightCategory = m.classifier.Classify(&data.Conditions)
+	for _, trend := range data.Trends {
+		if trend.Type == metar.PeriodTypeNoSignificant {
+			trend.FlightCategory = data.FlightCategory
+			continue
+		}
+		trend.FlightCategory = m.classifier.Classify(forecast.Apply(&data.Conditions, &trend.Conditions))
+	}
+}
+
+// classifyTaf 计算各预报时段的飞行类别, 变化组叠加到其开始时刻的主导天气上再计算
+func (m *Metar) classifyTaf(taf *metar.Taf) {
+	for _, period := range taf.Periods {
+		conditions := &period.Conditions
+		if period.Type != metar.PeriodTypeBase && period.Type != metar.PeriodTypeFrom {
+			if current, err := forecast.ConditionsAt(taf, period.From); err == nil {
+				conditions = forecast.Apply(current.Prevailing, conditions)
+			}
+		}
+		period.FlightCategory = m.classifier.Classify(conditions)
+	}
+}
+
+var (
+	ErrMetarNotFound = dto.NewApiStatus("NOT_FOUND", "Metar not found", dto.HttpCodeNotFound)
+	// ErrNoStationData 站点不在机场数据库中或区域内没有机场数据库中的站点, 与站点没有报文区分
+	ErrNoStationData = dto.NewApiStatus("NO_STATION_DATA", "No station data in station database", dto.HttpCodeNotFound)
+	// ErrUpstreamUnavailable 所有数据源请求失败且没有可用的过期报文, 与服务内部错误区分
+	ErrUpstreamUnavailable = dto.NewApiStatus("UPSTREAM_UNAVAILABLE", "All metar providers are unavailable", dto.HttpCode(http.StatusServiceUnavailable))
+)
 
 func (m *Metar) QueryMetar(icao string) *dto.ApiResponse[[]string] {
 	data, err := m.metarManager.Query(icao)
@@ -40,6 +122,9 @@ func (m *Metar) QueryMetar(icao string) *dto.ApiResponse[[]string] {
 	if errors.Is(err, metar.ErrICAOInvalid) {
 		return dto.NewApiResponse[[]string](dto.ErrErrorParam, nil)
 	}
+	if errors.Is(err, metar.ErrUpstreamFailed) {
+		return dto.NewApiResponse[[]string](ErrUpstreamUnavailable, nil)
+	}
 	if err != nil {
 		return dto.NewApiResponse[[]string](dto.ErrServerError, nil)
 	}
@@ -51,7 +136,201 @@ func (m *Metar) BatchQueryMetar(icaos []string) *dto.ApiResponse[[]string] {
 	return dto.NewApiResponse[[]string](dto.SuccessHandleRequest, data)
 }
 
-var ErrTafNotFound = dto.NewApiStatus("NOT_FOUND", "Taf not found", dto.HttpCodeNotFound)
+func (m *Metar) QueryMetarResult(icao string) *dto.ApiResponse[[]*metar.QueryResult] {
+	data, err := m.metarManager.QueryResult(icao)
+	if errors.Is(err, metar.ErrTargetNotFound) {
+		return dto.NewApiResponse[[]*metar.QueryResult](ErrMetarNotFound, nil)
+	}
+	if errors.Is(err, metar.ErrICAOInvalid) {
+		return dto.NewApiResponse[[]*metar.QueryResult](dto.ErrErrorParam, nil)
+	}
+	if errors.Is(err, metar.ErrUpstreamFailed) {
+		return dto.NewApiResponse[[]*metar.QueryResult](ErrUpstreamUnavailable, nil)
+	}
+	if err != nil {
+		return dto.NewApiResponse[[]*metar.QueryResult](dto.ErrServerError, nil)
+	}
+	return dto.NewApiResponse[[]*metar.QueryResult](dto.SuccessHandleRequest, []*metar.QueryResult{data})
+}
+
+func (m *Metar) BatchQueryMetarResult(icaos []string) *dto.ApiResponse[[]*metar.QueryResult] {
+	data := m.metarManager.BatchQueryResult(icaos)
+	return dto.NewApiResponse[[]*metar.QueryResult](dto.SuccessHandleRequest, data)
+}
+
+func (m *Metar) AreaMetar(area *metar.Area) *dto.ApiResponse[[]*metar.QueryResult] {
+	return m.queryArea(m.metarManager, area)
+}
+
+func (m *Metar) AreaTaf(area *metar.Area) *dto.ApiResponse[[]*metar.QueryResult] {
+	return m.queryArea(m.tafManager, area)
+}
+
+func (m *Metar) queryArea(manager metar.ManagerInterface, area *metar.Area) *dto.ApiResponse[[]*metar.QueryResult] {
+	if !area.Valid() {
+		return dto.NewApiResponse[[]*metar.QueryResult](dto.ErrErrorParam, nil)
+	}
+	data, err := m.areaResults(manager, area)
+	if err != nil {
+		return dto.NewApiResponse[[]*metar.QueryResult](ErrNoStationData, nil)
+	}
+	return dto.NewApiResponse[[]*metar.QueryResult](dto.SuccessHandleRequest, data)
+}
+
+// areaResults 查询区域内所有已知站点的报文, 只返回有报文的站点
+// 区域内没有机场数据库中的站点时返回ErrStationUnknown, 与站点都没有报文区分
+func (m *Metar) areaResults(manager metar.ManagerInterface, area *metar.Area) ([]*metar.QueryResult, error) {
+	stations := m.stations.Area(area, metar.MaxAreaStations)
+	if len(stations) == 0 {
+		return nil, metar.ErrStationUnknown
+	}
+	icaos := make([]string, 0, len(stations))
+	for _, station := range stations {
+		icaos = append(icaos, station.ICAO)
+	}
+	data := make([]*metar.QueryResult, 0, len(icaos))
+	for _, result := range manager.BatchQueryResult(icaos) {
+		// 区域内的站点都会被查询, 不需要使用附近站点的报文
+		if result.Status == metar.QueryStatusOk && result.Fallback == nil {
+			data = append(data, result)
+		}
+	}
+	return data, nil
+}
+
+func (m *Metar) NearestMetar(icao string, count int) *dto.ApiResponse[[]*metar.QueryResult] {
+	data, err := m.metarManager.Nearest(icao, count)
+	if errors.Is(err, metar.ErrTargetNotFound) {
+		return dto.NewApiResponse[[]*metar.QueryResult](ErrMetarNotFound, nil)
+	}
+	if errors.Is(err, metar.ErrStationUnknown) {
+		return dto.NewApiResponse[[]*metar.QueryResult](ErrNoStationData, nil)
+	}
+	if errors.Is(err, metar.ErrICAOInvalid) {
+		return dto.NewApiResponse[[]*metar.QueryResult](dto.ErrErrorParam, nil)
+	}
+	if errors.Is(err, metar.ErrUpstreamFailed) {
+		return dto.NewApiResponse[[]*metar.QueryResult](ErrUpstreamUnavailable, nil)
+	}
+	if err != nil {
+		return dto.NewApiResponse[[]*metar.QueryResult](dto.ErrServerError, nil)
+	}
+	return dto.NewApiResponse[[]*metar.QueryResult](dto.SuccessHandleRequest, data)
+}
+
+// parseMetar 查询并解析单个机场的METAR
+func (m *Metar) parseMetar(icao string) (*metar.Metar, *dto.ApiStatus) {
+	data, err := m.metarManager.Query(icao)
+	if errors.Is(err, metar.ErrTargetNotFound) {
+		return nil, ErrMetarNotFound
+	}
+	if errors.Is(err, metar.ErrICAOInvalid) {
+		return nil, dto.ErrErrorParam
+	}
+	if errors.Is(err, metar.ErrUpstreamFailed) {
+		return nil, ErrUpstreamUnavailable
+	}
+	if err != nil {
+		return nil, dto.ErrServerError
+	}
+	result, err := m.metarParser.ParseAt(data, m.now())
+	if err != nil {
+		m.logger.Errorf("ParseMetar fail, cannot parse %s: %v", data, err)
+		return nil, dto.ErrServerError
+	}
+	m.classifyMetar(result)
+	return result, nil
+}
+
+// batchParseMetar 批量查询并解析METAR, 查询或解析失败的站点保留失败状态
+func (m *Metar) batchParseMetar(icaos []string) []*metar.BatchResult[*metar.Metar] {
+	data := m.metarManager.BatchQueryResult(icaos)
+	results := make([]*metar.BatchResult[*metar.Metar], 0, len(data))
+	for _, raw := range data {
+		result := &metar.BatchResult[*metar.Metar]{ICAO: raw.ICAO, Query: raw.Query, Status: raw.Status, Fallback: raw.Fallback}
+		results = append(results, result)
+		if raw.Status != metar.QueryStatusOk {
+			continue
+		}
+		parsed, err := m.metarParser.ParseAt(raw.Data, m.now())
+		if err != nil {
+			m.logger.Errorf("BatchParseMetar fail, cannot parse %s: %v", raw.Data, err)
+			result.Status = metar.QueryStatusParseError
+			continue
+		}
+		m.classifyMetar(parsed)
+		result.Data = parsed
+	}
+	return results
+}
+
+func (m *Metar) ParseMetar(icao string) *dto.ApiResponse[[]*metar.Metar] {
+	result, status := m.parseMetar(icao)
+	if status != nil {
+		return dto.NewApiResponse[[]*metar.Metar](status, nil)
+	}
+	return dto.NewApiResponse[[]*metar.Metar](dto.SuccessHandleRequest, []*metar.Metar{result})
+}
+
+func (m *Metar) BatchParseMetar(icaos []string) *dto.ApiResponse[[]*metar.BatchResult[*metar.Metar]] {
+	return dto.NewApiResponse[[]*metar.BatchResult[*metar.Metar]](dto.SuccessHandleRequest, m.batchParseMetar(icaos))
+}
+
+func (m *Metar) TranslateMetar(icao string, lang string) *dto.ApiResponse[[]string] {
+	result, status := m.parseMetar(icao)
+	if status != nil {
+		return dto.NewApiResponse[[]string](status, nil)
+	}
+	translated, status := m.translateMetar(result, lang)
+	if status != nil {
+		return dto.NewApiResponse[[]string](status, nil)
+	}
+	return dto.NewApiResponse[[]string](dto.SuccessHandleRequest, []string{translated})
+}
+
+func (m *Metar) BatchTranslateMetar(icaos []string, lang string) *dto.ApiResponse[[]*metar.BatchResult[string]] {
+	results, status := m.batchTranslateMetar(icaos, lang)
+	if status != nil {
+		return dto.NewApiResponse[[]*metar.BatchResult[string]](status, nil)
+	}
+	return dto.NewApiResponse[[]*metar.BatchResult[string]](dto.SuccessHandleRequest, results)
+}
+
+// batchTranslateMetar 批量翻译METAR, 查询或解析失败的站点保留失败状态
+func (m *Metar) batchTranslateMetar(icaos []string, lang string) ([]*metar.BatchResult[string], *dto.ApiStatus) {
+	data := m.batchParseMetar(icaos)
+	results := make([]*metar.BatchResult[string], 0, len(data))
+	for _, report := range data {
+		result := &metar.BatchResult[string]{ICAO: report.ICAO, Query: report.Query, Status: report.Status, Fallback: report.Fallback}
+		results = append(results, result)
+		if report.Data == nil {
+			continue
+		}
+		translated, status := m.translateMetar(report.Data, lang)
+		if status != nil {
+			return nil, status
+		}
+		result.Data = translated
+	}
+	return results, nil
+}
+
+func (m *Metar) translateMetar(report *metar.Metar, lang string) (string, *dto.ApiStatus) {
+	result, err := m.translator.TranslateMetar(report, lang)
+	if errors.Is(err, metar.ErrLanguageNotSupported) {
+		return "", dto.ErrErrorParam
+	}
+	if err != nil {
+		m.logger.Errorf("TranslateMetar fail, cannot translate %s: %v", report.Raw, err)
+		return "", dto.ErrServerError
+	}
+	return result, nil
+}
+
+var (
+	ErrTafNotFound       = dto.NewApiStatus("NOT_FOUND", "Taf not found", dto.HttpCodeNotFound)
+	ErrTafTimeOutOfRange = dto.NewApiStatus("TIME_OUT_OF_RANGE", "Time out of taf validity", dto.HttpCodeBadRequest)
+)
 
 func (m *Metar) QueryTaf(icao string) *dto.ApiResponse[[]string] {
 	data, err := m.tafManager.Query(icao)
@@ -61,6 +340,9 @@ func (m *Metar) QueryTaf(icao string) *dto.ApiResponse[[]string] {
 	if errors.Is(err, metar.ErrICAOInvalid) {
 		return dto.NewApiResponse[[]string](dto.ErrErrorParam, nil)
 	}
+	if errors.Is(err, metar.ErrUpstreamFailed) {
+		return dto.NewApiResponse[[]string](ErrUpstreamUnavailable, nil)
+	}
 	if err != nil {
 		return dto.NewApiResponse[[]string](dto.ErrServerError, nil)
 	}
@@ -71,3 +353,188 @@ func (m *Metar) BatchQueryTaf(icaos []string) *dto.ApiResponse[[]string] {
 	data := m.tafManager.BatchQuery(icaos)
 	return dto.NewApiResponse[[]string](dto.SuccessHandleRequest, data)
 }
+
+func (m *Metar) QueryTafResult(icao string) *dto.ApiResponse[[]*metar.QueryResult] {
+	data, err := m.tafManager.QueryResult(icao)
+	if errors.Is(err, metar.ErrTargetNotFound) {
+		return dto.NewApiResponse[[]*metar.QueryResult](ErrTafNotFound, nil)
+	}
+	if errors.Is(err, metar.ErrICAOInvalid) {
+		return dto.NewApiResponse[[]*metar.QueryResult](dto.ErrErrorParam, nil)
+	}
+	if errors.Is(err, metar.ErrUpstreamFailed) {
+		return dto.NewApiResponse[[]*metar.QueryResult](ErrUpstreamUnavailable, nil)
+	}
+	if err != nil {
+		return dto.NewApiResponse[[]*metar.QueryResult](dto.ErrServerError, nil)
+	}
+	return dto.NewApiResponse[[]*metar.QueryResult](dto.SuccessHandleRequest, []*metar.QueryResult{data})
+}
+
+func (m *Metar) BatchQueryTafResult(icaos []string) *dto.ApiResponse[[]*metar.QueryResult] {
+	data := m.tafManager.BatchQueryResult(icaos)
+	return dto.NewApiResponse[[]*metar.QueryResult](dto.SuccessHandleRequest, data)
+}
+
+func (m *Metar) NearestTaf(icao string, count int) *dto.ApiResponse[[]*metar.QueryResult] {
+	data, err := m.tafManager.Nearest(icao, count)
+	if errors.Is(err, metar.ErrTargetNotFound) {
+		return dto.NewApiResponse[[]*metar.QueryResult](ErrTafNotFound, nil)
+	}
+	if errors.Is(err, metar.ErrStationUnknown) {
+		return dto.NewApiResponse[[]*metar.QueryResult](ErrNoStationData, nil)
+	}
+	if errors.Is(err, metar.ErrICAOInvalid) {
+		return dto.NewApiResponse[[]*metar.QueryResult](dto.ErrErrorParam, nil)
+	}
+	if errors.Is(err, metar.ErrUpstreamFailed) {
+		return dto.NewApiResponse[[]*metar.QueryResult](ErrUpstreamUnavailable, nil)
+	}
+	if err != nil {
+		return dto.NewApiResponse[[]*metar.QueryResult](dto.ErrServerError, nil)
+	}
+	return dto.NewApiResponse[[]*metar.QueryResult](dto.SuccessHandleRequest, data)
+}
+
+// parseTaf 查询并解析单个机场的TAF
+func (m *Metar) parseTaf(icao string) (*metar.Taf, *dto.ApiStatus) {
+	data, err := m.tafManager.Query(icao)
+	if errors.Is(err, metar.ErrTargetNotFound) {
+		return nil, ErrTafNotFound
+	}
+	if errors.Is(err, metar.ErrICAOInvalid) {
+		return nil, dto.ErrErrorParam
+	}
+	if errors.Is(err, metar.ErrUpstreamFailed) {
+		return nil, ErrUpstreamUnavailable
+	}
+	if err != nil {
+		return nil, dto.ErrServerError
+	}
+	result, err := m.tafParser.ParseAt(data, m.now())
+	if err != nil {
+		m.logger.Errorf("ParseTaf fail, cannot parse %s: %v", data, err)
+		return nil, dto.ErrServerError
+	}
+	m.classifyTaf(result)
+	return result, nil
+}
+
+// batchParseTaf 批量查询并解析TAF, 查询或解析失败的站点保留失败状态
+func (m *Metar) batchParseTaf(icaos []string) []*metar.BatchResult[*metar.Taf] {
+	data := m.tafManager.BatchQueryResult(icaos)
+	results := make([]*metar.BatchResult[*metar.Taf], 0, len(data))
+	for _, raw := range data {
+		result := &metar.BatchResult[*metar.Taf]{ICAO: raw.ICAO, Query: raw.Query, Status: raw.Status, Fallback: raw.Fallback}
+		results = append(results, result)
+		if raw.Status != metar.QueryStatusOk {
+			continue
+		}
+		parsed, err := m.tafParser.ParseAt(raw.Data, m.now())
+		if err != nil {
+			m.logger.Errorf("BatchParseTaf fail, cannot parse %s: %v", raw.Data, err)
+			result.Status = metar.QueryStatusParseError
+			continue
+		}
+		m.classifyTaf(parsed)
+		result.Data = parsed
+	}
+	return results
+}
+
+func (m *Metar) ParseTaf(icao string) *dto.ApiResponse[[]*metar.Taf] {
+	result, status := m.parseTaf(icao)
+	if status != nil {
+		return dto.NewApiResponse[[]*metar.Taf](status, nil)
+	}
+	return dto.NewApiResponse[[]*metar.Taf](dto.SuccessHandleRequest, []*metar.Taf{result})
+}
+
+func (m *Metar) BatchParseTaf(icaos []string) *dto.ApiResponse[[]*metar.BatchResult[*metar.Taf]] {
+	return dto.NewApiResponse[[]*metar.BatchResult[*metar.Taf]](dto.SuccessHandleRequest, m.batchParseTaf(icaos))
+}
+
+func (m *Metar) TranslateTaf(icao string, lang string) *dto.ApiResponse[[]string] {
+	result, status := m.parseTaf(icao)
+	if status != nil {
+		return dto.NewApiResponse[[]string](status, nil)
+	}
+	translated, status := m.translateTaf(result, lang)
+	if status != nil {
+		return dto.NewApiResponse[[]string](status, nil)
+	}
+	return dto.NewApiResponse[[]string](dto.SuccessHandleRequest, []string{translated})
+}
+
+func (m *Metar) BatchTranslateTaf(icaos []string, lang string) *dto.ApiResponse[[]*metar.BatchResult[string]] {
+	results, status := m.batchTranslateTaf(icaos, lang)
+	if status != nil {
+		return dto.NewApiResponse[[]*metar.BatchResult[string]](status, nil)
+	}
+	return dto.NewApiResponse[[]*metar.BatchResult[string]](dto.SuccessHandleRequest, results)
+}
+
+// batchTranslateTaf 批量翻译TAF, 查询或解析失败的站点保留失败状态
+func (m *Metar) batchTranslateTaf(icaos []string, lang string) ([]*metar.BatchResult[string], *dto.ApiStatus) {
+	data := m.batchParseTaf(icaos)
+	results := make([]*metar.BatchResult[string], 0, len(data))
+	for _, report := range data {
+		result := &metar.BatchResult[string]{ICAO: report.ICAO, Query: report.Query, Status: report.Status, Fallback: report.Fallback}
+		results = append(results, result)
+		if report.Data == nil {
+			continue
+		}
+		translated, status := m.translateTaf(report.Data, lang)
+		if status != nil {
+			return nil, status
+		}
+		result.Data = translated
+	}
+	return results, nil
+}
+
+func (m *Metar) translateTaf(report *metar.Taf, lang string) (string, *dto.ApiStatus) {
+	result, err := m.translator.TranslateTaf(report, lang)
+	if errors.Is(err, metar.ErrLanguageNotSupported) {
+		return "", dto.ErrErrorParam
+	}
+	if err != nil {
+		m.logger.Errorf("TranslateTaf fail, cannot translate %s: %v", report.Raw, err)
+		return "", dto.ErrServerError
+	}
+	return result, nil
+}
+
+func (m *Metar) QueryTafAt(icao string, at time.Time) *dto.ApiResponse[*metar.TafConditions] {
+	data, err := m.tafManager.Query(icao)
+	if errors.Is(err, metar.ErrTargetNotFound) {
+		return dto.NewApiResponse[*metar.TafConditions](ErrTafNotFound, nil)
+	}
+	if errors.Is(err, metar.ErrICAOInvalid) {
+		return dto.NewApiResponse[*metar.TafConditions](dto.ErrErrorParam, nil)
+	}
+	if errors.Is(err, metar.ErrUpstreamFailed) {
+		return dto.NewApiResponse[*metar.TafConditions](ErrUpstreamUnavailable, nil)
+	}
+	if err != nil {
+		return dto.NewApiResponse[*metar.TafConditions](dto.ErrServerError, nil)
+	}
+	taf, err := m.tafParser.ParseAt(data, m.now())
+	if err != nil {
+		m.logger.Errorf("QueryTafAt fail, cannot parse %s: %v", data, err)
+		return dto.NewApiResponse[*metar.TafConditions](dto.ErrServerError, nil)
+	}
+	result, err := forecast.ConditionsAt(taf, at)
+	if errors.Is(err, metar.ErrTargetNotFound) {
+		return dto.NewApiResponse[*metar.TafConditions](ErrTafNotFound, nil)
+	}
+	if errors.Is(err, metar.ErrTimeOutOfRange) {
+		return dto.NewApiResponse[*metar.TafConditions](ErrTafTimeOutOfRange, nil)
+	}
+	if err != nil {
+		return dto.NewApiResponse[*metar.TafConditions](dto.ErrServerError, nil)
+	}
+	result.Prevailing.FlightCategory = m.classifier.Classify(result.Prevailing)
+	result.Worst.FlightCategory = m.classifier.Classify(result.Worst)
+	return dto.NewApiResponse[*metar.TafConditions](dto.SuccessHandleRequest, result)
+}
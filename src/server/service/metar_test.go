@@ -0,0 +1,68 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"metar-service/src/interfaces/config"
+	"metar-service/src/interfaces/metar"
+	"metar-service/src/metar/category"
+	"metar-service/src/metar/parser"
+	"metar-service/src/metar/translator"
+	"testing"
+)
+
+// manager 只有ZBAA有报文, 其他站点返回未找到
+type manager struct {
+	metar.ManagerInterface
+}
+
+func (manager) BatchQueryResult(icaos []string) []*metar.QueryResult {
+	results := make([]*metar.QueryResult, 0, len(icaos))
+	for _, icao := range icaos {
+		if icao == "ZBAA" {
+			results = append(results, &metar.QueryResult{ICAO: icao, Query: icao, Status: metar.QueryStatusOk,
+				Data: "METAR ZBAA 151100Z 36005MPS CAVOK 12/M05 Q1021 NOSIG"})
+			continue
+		}
+		results = append(results, &metar.QueryResult{ICAO: icao, Query: icao, Status: metar.QueryStatusNotFound})
+	}
+	return results
+}
+
+func newBatchService() *Metar {
+	return &Metar{
+		logger:       nopLogger{},
+		metarManager: manager{},
+		metarParser:  parser.NewMetarParser(),
+		classifier:   category.NewClassifier(&config.FlightCategoryConfig{Standard: "faa"}),
+		translator:   translator.NewTranslator(),
+	}
+}
+
+func TestBatchParseMetarKeepsFailedStations(t *testing.T) {
+	results := newBatchService().batchParseMetar([]string{"ZBAA", "ZSSS"})
+	if len(results) != 2 {
+		t.Fatalf("results = %d, want 2", len(results))
+	}
+	if ok := results[0]; ok.ICAO != "ZBAA" || ok.Status != metar.QueryStatusOk || ok.Data == nil || ok.Data.Station != "ZBAA" {
+		t.Errorf("ZBAA result = %+v, want decoded report", ok)
+	}
+	if failed := results[1]; failed.ICAO != "ZSSS" || failed.Status != metar.QueryStatusNotFound || failed.Data != nil {
+		t.Errorf("ZSSS result = %+v, want not_found without data", failed)
+	}
+}
+
+func TestBatchTranslateMetarKeepsFailedStations(t *testing.T) {
+	results, status := newBatchService().batchTranslateMetar([]string{"ZSSS", "ZBAA"}, translator.LanguageEnglish)
+	if status != nil || len(results) != 2 {
+		t.Fatalf("batchTranslateMetar() = %d results, %v, want 2 results", len(results), status)
+	}
+	if failed := results[0]; failed.ICAO != "ZSSS" || failed.Status != metar.QueryStatusNotFound || failed.Data != "" {
+		t.Errorf("ZSSS result = %+v, want not_found without data", failed)
+	}
+	if ok := results[1]; ok.ICAO != "ZBAA" || ok.Status != metar.QueryStatusOk || ok.Data == "" {
+		t.Errorf("ZBAA result = %+v, want translation", ok)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package service
+package service
+
+import (
+	"metar-service/src/interfaces/metar"
+
+	"half-nothing.cn/service-core/interfaces/http/dto"
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+type Station struct {
+	logger   logger.Interface
+	database metar.StationDatabaseInterface
+}
+
+func NewStation(
+	lg logger.Interface,
+	database metar.StationDatabaseInterface,
+) *Station {
+	return &Station{
+		logger:   logger.NewLoggerAdapter(lg, "station-service"),
+		database: database,
+	}
+}
+
+var ErrStationNotFound = dto.NewApiStatus("NOT_FOUND", "Station not found", dto.HttpCodeNotFound)
+
+func (s *Station) QueryStation(icao string) *dto.ApiResponse[*metar.Station] {
+	icao, err := metar.ResolveICAO(s.database, icao)
+	if err != nil {
+		return dto.NewApiResponse[*metar.Station](dto.ErrErrorParam, nil)
+	}
+	station, ok := s.database.Get(icao)
+	if !ok {
+		return dto.NewApiResponse[*metar.Station](ErrStationNotFound, nil)
+	}
+	return dto.NewApiResponse[*metar.Station](dto.SuccessHandleRequest, station)
+}
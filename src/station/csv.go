@@ -0,0 +1,189 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package station
+package station
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"metar-service/src/interfaces/metar"
+	"strconv"
+	"strings"
+)
+
+// record 按表头名称读取CSV中的一行
+type record struct {
+	columns map[string]int
+	values  []string
+}
+
+func (r *record) get(name string) string {
+	index, ok := r.columns[name]
+	if !ok || index >= len(r.values) {
+		return ""
+	}
+	return strings.TrimSpace(r.values[index])
+}
+
+func (r *record) intValue(name string) *int {
+	value, err := strconv.ParseFloat(r.get(name), 64)
+	if err != nil {
+		return nil
+	}
+	result := int(math.Round(value))
+	return &result
+}
+
+func (r *record) floatValue(name string) *float64 {
+	value, err := strconv.ParseFloat(r.get(name), 64)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+func (r *record) boolValue(name string) bool {
+	return r.get(name) == "1"
+}
+
+// readCSV 逐行读取CSV, 第一行为表头, 缺少必需的列时返回错误
+func readCSV(reader io.Reader, required []string, handle func(r *record) error) error {
+	csvReader := csv.NewReader(reader)
+	csvReader.ReuseRecord = true
+	// 列数不足的行按缺少的列为空处理, 不影响其他行
+	csvReader.FieldsPerRecord = -1
+	header, err := csvReader.Read()
+	if err != nil {
+		return fmt.Errorf("fail to read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for index, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = index
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return fmt.Errorf("missing column %s", name)
+		}
+	}
+	r := &record{columns: columns}
+	for {
+		values, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		r.values = values
+		if err := handle(r); err != nil {
+			return err
+		}
+	}
+}
+
+// stationICAO 返回机场的ICAO代码, 旧版本数据集没有icao_code列时使用gps_code或ident
+func stationICAO(r *record) string {
+	for _, name := range []string{"icao_code", "gps_code", "ident"} {
+		if value := strings.ToUpper(r.get(name)); metar.ValidICAO(value) {
+			return value
+		}
+	}
+	return ""
+}
+
+// loadAirports 读取机场数据, 返回以ICAO代码与数据集ident为键的机场, 没有ICAO代码或已关闭的机场被忽略
+func loadAirports(reader io.Reader) (map[string]*metar.Station, map[string]*metar.Station, error) {
+	stations := make(map[string]*metar.Station)
+	idents := make(map[string]*metar.Station)
+	err := readCSV(reader, []string{"ident", "name", "latitude_deg", "longitude_deg"}, func(r *record) error {
+		if r.get("type") == "closed" {
+			return nil
+		}
+		icao := stationICAO(r)
+		if icao == "" {
+			return nil
+		}
+		latitude, longitude := r.floatValue("latitude_deg"), r.floatValue("longitude_deg")
+		if latitude == nil || longitude == nil {
+			return nil
+		}
+		station := &metar.Station{
+			ICAO:         icao,
+			IATA:         strings.ToUpper(r.get("iata_code")),
+			LocalCode:    strings.ToUpper(r.get("local_code")),
+			Name:         r.get("name"),
+			Type:         r.get("type"),
+			Latitude:     *latitude,
+			Longitude:    *longitude,
+			Elevation:    r.intValue("elevation_ft"),
+			Country:      r.get("iso_country"),
+			Region:       r.get("iso_region"),
+			Municipality: r.get("municipality"),
+			Runways:      make([]*metar.Runway, 0),
+		}
+		// 同一ICAO代码有多条记录时保留类型更大的机场
+		if exist, ok := stations[icao]; ok && airportRank(exist.Type) >= airportRank(station.Type) {
+			return nil
+		}
+		stations[icao] = station
+		idents[strings.ToUpper(r.get("ident"))] = station
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return stations, idents, nil
+}
+
+func airportRank(airportType string) int {
+	switch airportType {
+	case "large_airport":
+		return 3
+	case "medium_airport":
+		return 2
+	case "small_airport":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// loadRunways 读取跑道数据并添加到对应的机场, 返回添加的跑道数量
+func loadRunways(reader io.Reader, idents map[string]*metar.Station) (int, error) {
+	count := 0
+	err := readCSV(reader, []string{"airport_ident", "le_ident", "he_ident"}, func(r *record) error {
+		station, ok := idents[strings.ToUpper(r.get("airport_ident"))]
+		if !ok {
+			return nil
+		}
+		runway := &metar.Runway{
+			Length:  r.intValue("length_ft"),
+			Width:   r.intValue("width_ft"),
+			Surface: r.get("surface"),
+			Lighted: r.boolValue("lighted"),
+			Closed:  r.boolValue("closed"),
+			Ends:    make([]*metar.RunwayEnd, 0, 2),
+		}
+		for _, prefix := range []string{"le_", "he_"} {
+			ident := strings.ToUpper(r.get(prefix + "ident"))
+			if ident == "" {
+				continue
+			}
+			runway.Ends = append(runway.Ends, &metar.RunwayEnd{
+				Ident:     ident,
+				Heading:   r.floatValue(prefix + "heading_degT"),
+				Latitude:  r.floatValue(prefix + "latitude_deg"),
+				Longitude: r.floatValue(prefix + "longitude_deg"),
+				Elevation: r.intValue(prefix + "elevation_ft"),
+			})
+		}
+		station.Runways = append(station.Runways, runway)
+		count++
+		return nil
+	})
+	return count, err
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package station
+package station
+
+import (
+	"strings"
+	"testing"
+)
+
+const airportHeader = `"ident","type","name","latitude_deg","longitude_deg","elevation_ft","iso_country","icao_code","iata_code","gps_code","local_code"`
+
+func TestLoadAirports(t *testing.T) {
+	data := airportHeader + `
+"ZBAA","large_airport","Beijing Capital",40.080101,116.584999,116,"CN","ZBAA","PEK","ZBAA",
+"ZBXX","closed","Closed Airport",40,116,,"CN","ZBXX",,"ZBXX",
+"US-0001","heliport","No ICAO Code",40,-100,,"US",,,,"1A2"
+"ZSSS","large_airport","Missing Latitude",,121.336,,"CN","ZSSS","SHA","ZSSS",
+"ZSPD","large_airport","Bad Longitude",31.1434,abc,,"CN","ZSPD","PVG","ZSPD",
+"KJFK","small_airport","Duplicate Small",40.6,-73.7,,"US","KJFK",,"KJFK",
+"US-KJFK","large_airport","John F Kennedy",40.639447,-73.779317,13.4,"US","KJFK","JFK","KJFK","JFK"
+"KSEA","medium_airport","Short Row",47.449,-122.309
+`
+	stations, idents, err := loadAirports(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("loadAirports() error = %v", err)
+	}
+	if len(stations) != 3 {
+		t.Fatalf("stations = %d, want ZBAA, KJFK and KSEA", len(stations))
+	}
+	for _, icao := range []string{"ZBXX", "ZSSS", "ZSPD"} {
+		if _, ok := stations[icao]; ok {
+			t.Errorf("station %s was loaded", icao)
+		}
+	}
+
+	zbaa := stations["ZBAA"]
+	if zbaa.IATA != "PEK" || zbaa.Latitude != 40.080101 || zbaa.Elevation == nil || *zbaa.Elevation != 116 {
+		t.Errorf("ZBAA = %+v", zbaa)
+	}
+	// 同一ICAO代码保留类型更大的机场, 并以该机场的ident索引
+	if kjfk := stations["KJFK"]; kjfk.Name != "John F Kennedy" || *kjfk.Elevation != 13 || kjfk.LocalCode != "JFK" {
+		t.Errorf("KJFK = %+v, want large airport", kjfk)
+	}
+	if idents["US-KJFK"] != stations["KJFK"] {
+		t.Error("ident US-KJFK is not indexed")
+	}
+	if ksea := stations["KSEA"]; ksea.ICAO != "KSEA" || ksea.Elevation != nil || ksea.IATA != "" {
+		t.Errorf("KSEA = %+v, want ICAO from ident without optional columns", ksea)
+	}
+}
+
+func TestLoadAirportsWithoutICAOColumn(t *testing.T) {
+	data := `"ident","type","name","latitude_deg","longitude_deg","gps_code"
+"00AA","small_airport","Local Only",38.7,-101.4,"00AA"
+"CYYZ-OLD","large_airport","Toronto Pearson",43.677,-79.630,"CYYZ"
+`
+	stations, _, err := loadAirports(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("loadAirports() error = %v", err)
+	}
+	if len(stations) != 1 || stations["CYYZ"] == nil {
+		t.Errorf("stations = %v, want only CYYZ from gps_code", stations)
+	}
+}
+
+func TestLoadAirportsMissingColumn(t *testing.T) {
+	data := `"ident","name","latitude_deg"
+"ZBAA","Beijing Capital",40.080101
+`
+	if _, _, err := loadAirports(strings.NewReader(data)); err == nil || !strings.Contains(err.Error(), "longitude_deg") {
+		t.Errorf("loadAirports() error = %v, want missing column longitude_deg", err)
+	}
+	if _, _, err := loadAirports(strings.NewReader("")); err == nil {
+		t.Error("loadAirports() of empty file error = nil")
+	}
+}
+
+func TestLoadRunways(t *testing.T) {
+	stations, idents, err := loadAirports(strings.NewReader(airportHeader + `
+"ZBAA","large_airport","Beijing Capital",40.080101,116.584999,116,"CN","ZBAA","PEK","ZBAA",
+`))
+	if err != nil {
+		t.Fatalf("loadAirports() error = %v", err)
+	}
+	data := `"airport_ident","length_ft","width_ft","surface","lighted","closed","le_ident","le_heading_degT","he_ident","he_heading_degT"
+"ZBAA",12467,197,"CON",1,0,"01",1,"19",181
+"zbaa",10499,164,"ASP",0,1,"18L",,,
+"ZZZZ",5000,100,"ASP",1,0,"09",90,"27",270
+`
+	count, err := loadRunways(strings.NewReader(data), idents)
+	if err != nil {
+		t.Fatalf("loadRunways() error = %v", err)
+	}
+	runways := stations["ZBAA"].Runways
+	if count != 2 || len(runways) != 2 {
+		t.Fatalf("loadRunways() = %d, runways = %d, want 2", count, len(runways))
+	}
+	if first := runways[0]; !first.Lighted || first.Closed || len(first.Ends) != 2 || *first.Ends[1].Heading != 181 {
+		t.Errorf("runway 01/19 = %+v", first)
+	}
+	if second := runways[1]; second.Lighted || !second.Closed || len(second.Ends) != 1 || second.Ends[0].Heading != nil {
+		t.Errorf("runway 18L = %+v, want single closed end", second)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package station
+package station
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io"
+	"math"
+	"metar-service/src/interfaces/config"
+	"metar-service/src/interfaces/metar"
+	"os"
+	"sort"
+	"strings"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// 内置数据仅包含部分主要机场, 完整数据需在配置文件中指定OurAirports数据集文件
+//
+//go:embed data/airports.csv data/runways.csv
+var embedded embed.FS
+
+// Database 只读的内存机场数据库, 启动时加载
+type Database struct {
+	stations map[string]*metar.Station
+	iata     map[string]*metar.Station
+	lids     map[string]*metar.Station // 美国FAA LID
+	strict   bool
+}
+
+func NewDatabase(lg logger.Interface, c *config.StationConfig) (*Database, error) {
+	lg = logger.NewLoggerAdapter(lg, "station-database")
+
+	airports, runways, err := openData(c)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = airports.Close() }()
+	if runways != nil {
+		defer func() { _ = runways.Close() }()
+	}
+
+	stations, idents, err := loadAirports(airports)
+	if err != nil {
+		return nil, fmt.Errorf("fail to load airports: %w", err)
+	}
+	count := 0
+	if runways != nil {
+		if count, err = loadRunways(runways, idents); err != nil {
+			return nil, fmt.Errorf("fail to load runways: %w", err)
+		}
+	}
+	lg.Infof("Loaded %d stations with %d runways", len(stations), count)
+	if c.Airports == "" {
+		lg.Warnf("Using built-in sample of %d stations, nearest, area and geojson queries only cover these stations, "+
+			"configure station.airports with OurAirports data for full coverage", len(stations))
+	}
+
+	database := &Database{
+		stations: stations,
+		iata:     make(map[string]*metar.Station),
+		lids:     make(map[string]*metar.Station),
+		strict:   c.Strict,
+	}
+	for _, station := range stations {
+		addCode(database.iata, station.IATA, station)
+		if station.Country == "US" {
+			addCode(database.lids, station.LocalCode, station)
+		}
+	}
+	return database, nil
+}
+
+// addCode 添加三字代码索引, 同一代码对应多个机场时保留类型更大的机场
+func addCode(index map[string]*metar.Station, code string, station *metar.Station) {
+	if len(code) != 3 {
+		return
+	}
+	if exist, ok := index[code]; ok {
+		rank, existRank := airportRank(station.Type), airportRank(exist.Type)
+		if existRank > rank || (existRank == rank && exist.ICAO < station.ICAO) {
+			return
+		}
+	}
+	index[code] = station
+}
+
+// openData 打开机场与跑道数据, 未配置文件时使用内置数据, 只配置了机场文件时不加载跑道
+func openData(c *config.StationConfig) (io.ReadCloser, io.ReadCloser, error) {
+	if c.Airports == "" {
+		airports, err := embedded.ReadFile("data/airports.csv")
+		if err != nil {
+			return nil, nil, err
+		}
+		runways, err := embedded.ReadFile("data/runways.csv")
+		if err != nil {
+			return nil, nil, err
+		}
+		return io.NopCloser(bytes.NewReader(airports)), io.NopCloser(bytes.NewReader(runways)), nil
+	}
+	airports, err := os.Open(c.Airports)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fail to open airports file: %w", err)
+	}
+	if c.Runways == "" {
+		return airports, nil, nil
+	}
+	runways, err := os.Open(c.Runways)
+	if err != nil {
+		_ = airports.Close()
+		return nil, nil, fmt.Errorf("fail to open runways file: %w", err)
+	}
+	return airports, runways, nil
+}
+
+func (d *Database) Get(icao string) (*metar.Station, bool) {
+	station, ok := d.stations[strings.ToUpper(icao)]
+	return station, ok
+}
+
+// Resolve 四字代码按ICAO代码处理, 三字代码依次按IATA代码与美国FAA LID解析
+// 严格模式下只接受数据库中存在的ICAO代码
+func (d *Database) Resolve(code string) (string, error) {
+	switch len(code) {
+	case 4:
+		if !metar.ValidICAO(code) {
+			return "", metar.ErrICAOInvalid
+		}
+		if _, ok := d.stations[code]; !ok && d.strict {
+			return "", metar.ErrICAOInvalid
+		}
+		return code, nil
+	case 3:
+		if station, ok := d.iata[code]; ok {
+			return station.ICAO, nil
+		}
+		if station, ok := d.lids[code]; ok {
+			return station.ICAO, nil
+		}
+	}
+	return "", metar.ErrICAOInvalid
+}
+
+// Nearby 返回半径内除中心机场外的机场, 距离与方位未取整, 由调用方换算单位后取整
+func (d *Database) Nearby(icao string, radius float64, limit int) []*metar.NearbyStation {
+	center, ok := d.Get(icao)
+	if !ok || limit <= 0 {
+		return nil
+	}
+	results := make([]*metar.NearbyStation, 0)
+	for _, nearby := range d.within(center.Latitude, center.Longitude, radius) {
+		if nearby.Station != center {
+			results = append(results, nearby)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Distance != results[j].Distance {
+			return results[i].Distance < results[j].Distance
+		}
+		return results[i].Station.ICAO < results[j].Station.ICAO
+	})
+	return results[:min(limit, len(results))]
+}
+
+// Area 返回矩形或圆形区域内的机场
+func (d *Database) Area(area *metar.Area, limit int) []*metar.Station {
+	if limit <= 0 {
+		return nil
+	}
+	results := make([]*metar.Station, 0)
+	if area.Radius > 0 {
+		for _, nearby := range d.within(area.Latitude, area.Longitude, area.Radius) {
+			results = append(results, nearby.Station)
+		}
+	} else {
+		for _, station := range d.stations {
+			if inBox(area, station.Latitude, station.Longitude) {
+				results = append(results, station)
+			}
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		rankI, rankJ := airportRank(results[i].Type), airportRank(results[j].Type)
+		if rankI != rankJ {
+			return rankI > rankJ
+		}
+		return results[i].ICAO < results[j].ICAO
+	})
+	return results[:min(limit, len(results))]
+}
+
+// within 遍历所有机场计算距离, 纬度差已超出半径的机场直接跳过
+func (d *Database) within(latitude float64, longitude float64, radius float64) []*metar.NearbyStation {
+	latitudeRange := radius / (earthRadius * math.Pi / 180)
+	results := make([]*metar.NearbyStation, 0)
+	for _, station := range d.stations {
+		if math.Abs(station.Latitude-latitude) > latitudeRange {
+			continue
+		}
+		dist := distance(latitude, longitude, station.Latitude, station.Longitude)
+		if dist > radius {
+			continue
+		}
+		results = append(results, &metar.NearbyStation{
+			Station:  station,
+			Distance: dist,
+			Bearing:  bearing(latitude, longitude, station.Latitude, station.Longitude),
+		})
+	}
+	return results
+}
+
+func (d *Database) Len() int {
+	return len(d.stations)
+}
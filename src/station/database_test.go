@@ -0,0 +1,217 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package station
+package station
+
+import (
+	"errors"
+	"math"
+	"metar-service/src/interfaces/config"
+	"metar-service/src/interfaces/metar"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"half-nothing.cn/service-core/interfaces/logger"
+)
+
+// nopLogger 丢弃测试中的日志
+type nopLogger struct {
+	logger.Interface
+}
+
+func (nopLogger) Infof(string, ...any) {}
+func (nopLogger) Warnf(string, ...any) {}
+
+// testAirports 包含代码冲突的机场与赤道上间隔固定的机场
+const testAirports = airportHeader + `
+"ZSSS","large_airport","Shanghai Hongqiao",31.1979,121.336,10,"CN","ZSSS","SHA","ZSSS",
+"ZSPD","large_airport","Shanghai Pudong",31.1434,121.805,13,"CN","ZSPD","PVG","ZSPD",
+"KAAA","small_airport","Small ABC",40,-100,,"US","KAAA","ABC","KAAA","AAA"
+"KBBB","large_airport","Large ABC",41,-100,,"US","KBBB","ABC","KBBB","BBB"
+"KDDD","medium_airport","Medium XYZ D",43,-100,,"US","KDDD","XYZ","KDDD",
+"KCCC","medium_airport","Medium XYZ C",42,-100,,"US","KCCC","XYZ","KCCC",
+"KEEE","small_airport","LID PVG",44,-100,,"US","KEEE",,"KEEE","PVG"
+"KFFF","small_airport","LID Only",45,-100,,"US","KFFF",,"KFFF","FFF"
+"EGGG","small_airport","Local Code Outside US",50,0,,"GB","EGGG",,"EGGG","GGG"
+"FAAA","small_airport","Center",0,0,,"GA","FAAA",,"FAAA",
+"FBBB","small_airport","East 1",0,1,,"GA","FBBB",,"FBBB",
+"FCCC","small_airport","West 1",0,-1,,"GA","FCCC",,"FCCC",
+"FDDD","small_airport","East 0.5",0,0.5,,"GA","FDDD",,"FDDD",
+"FEEE","small_airport","East 3",0,3,,"GA","FEEE",,"FEEE",
+"NFFN","international","Nadi",-17.755399,177.443001,59,"FJ","NFFN","NAN","NFFN",
+"NSTU","medium_airport","Pago Pago",-14.331,-170.710,32,"AS","NSTU","PPG","NSTU",
+"NZAA","large_airport","Auckland",-37.008099,174.792007,23,"NZ","NZAA","AKL","NZAA",
+`
+
+// newTestDatabase 将机场数据写入临时文件并加载, 不加载跑道
+func newTestDatabase(t *testing.T, airports string, strict bool) *Database {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "airports.csv")
+	if err := os.WriteFile(path, []byte(airports), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	database, err := NewDatabase(nopLogger{}, &config.StationConfig{Airports: path, Strict: strict})
+	if err != nil {
+		t.Fatalf("NewDatabase() error = %v", err)
+	}
+	return database
+}
+
+func TestDatabaseEmbedded(t *testing.T) {
+	database, err := NewDatabase(nopLogger{}, &config.StationConfig{})
+	if err != nil {
+		t.Fatalf("NewDatabase() error = %v", err)
+	}
+	station, ok := database.Get("zbaa")
+	if !ok || station.IATA != "PEK" || len(station.Runways) == 0 {
+		t.Errorf("Get(zbaa) = %+v, %v, want ZBAA with runways", station, ok)
+	}
+}
+
+func TestDatabaseResolveCodes(t *testing.T) {
+	database := newTestDatabase(t, testAirports, false)
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"SHA", "ZSSS"},
+		// IATA代码冲突时保留类型更大的机场, 类型相同时保留ICAO代码较小的机场
+		{"ABC", "KBBB"},
+		{"XYZ", "KCCC"},
+		// IATA代码优先于FAA LID
+		{"PVG", "ZSPD"},
+		{"FFF", "KFFF"},
+		{"AAA", "KAAA"},
+		// 只有美国机场的本地代码作为FAA LID
+		{"GGG", ""},
+		{"QQQ", ""},
+		{"ZB", ""},
+	}
+	for _, tt := range tests {
+		got, err := database.Resolve(tt.code)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("Resolve(%s) = %s, want error", tt.code, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Resolve(%s) = %s, %v, want %s", tt.code, got, err, tt.want)
+		}
+	}
+}
+
+func TestDatabaseResolveStrict(t *testing.T) {
+	tests := []struct {
+		code    string
+		lenient bool
+		strict  bool
+	}{
+		{"ZSSS", true, true},
+		// 数据库中没有的ICAO代码只在宽松模式下接受
+		{"ZZZZ", true, false},
+		{"K1A2", true, false},
+		// 三字代码在两种模式下都需要能解析
+		{"SHA", true, true},
+		{"QQQ", false, false},
+		{"1ZSS", false, false},
+		{"zsss", false, false},
+		{"ZSSSS", false, false},
+	}
+	lenient, strict := newTestDatabase(t, testAirports, false), newTestDatabase(t, testAirports, true)
+	for _, tt := range tests {
+		if _, err := lenient.Resolve(tt.code); (err == nil) != tt.lenient {
+			t.Errorf("lenient Resolve(%s) error = %v, want accepted %v", tt.code, err, tt.lenient)
+		}
+		if _, err := strict.Resolve(tt.code); (err == nil) != tt.strict {
+			t.Errorf("strict Resolve(%s) error = %v, want accepted %v", tt.code, err, tt.strict)
+		}
+	}
+}
+
+func TestResolveICAO(t *testing.T) {
+	strict := newTestDatabase(t, testAirports, true)
+	if icao, err := metar.ResolveICAO(strict, " pvg "); err != nil || icao != "ZSPD" {
+		t.Errorf("ResolveICAO(pvg) = %s, %v, want ZSPD", icao, err)
+	}
+	if _, err := metar.ResolveICAO(strict, "zzzz"); !errors.Is(err, metar.ErrICAOInvalid) {
+		t.Errorf("ResolveICAO(zzzz) error = %v, want %v", err, metar.ErrICAOInvalid)
+	}
+	// 未提供机场数据库时只检查格式
+	if icao, err := metar.ResolveICAO(nil, "zzzz"); err != nil || icao != "ZZZZ" {
+		t.Errorf("ResolveICAO(nil, zzzz) = %s, %v, want ZZZZ", icao, err)
+	}
+	if _, err := metar.ResolveICAO(nil, "SHA"); !errors.Is(err, metar.ErrICAOInvalid) {
+		t.Errorf("ResolveICAO(nil, SHA) error = %v, want %v", err, metar.ErrICAOInvalid)
+	}
+}
+
+func TestDatabaseNearby(t *testing.T) {
+	database := newTestDatabase(t, testAirports, false)
+
+	// 按距离从近到远排序, 距离相同时按ICAO代码排序, 不包含中心机场与半径外的机场
+	nearby := database.Nearby("FAAA", 200, 10)
+	want := []string{"FDDD", "FBBB", "FCCC"}
+	if len(nearby) != len(want) {
+		t.Fatalf("Nearby() = %d stations, want %v", len(nearby), want)
+	}
+	for i, icao := range want {
+		if nearby[i].Station.ICAO != icao {
+			t.Errorf("Nearby()[%d] = %s, want %s", i, nearby[i].Station.ICAO, icao)
+		}
+	}
+	if math.Abs(nearby[1].Distance-111.195) > 0.001 || math.Abs(nearby[1].Bearing-90) > 1e-9 || math.Abs(nearby[2].Bearing-270) > 1e-9 {
+		t.Errorf("Nearby() FBBB = %+v, FCCC = %+v", nearby[1], nearby[2])
+	}
+
+	if limited := database.Nearby("FAAA", 200, 2); len(limited) != 2 || limited[1].Station.ICAO != "FBBB" {
+		t.Errorf("Nearby() with limit 2 = %v", limited)
+	}
+	if empty := database.Nearby("FAAA", 200, 0); len(empty) != 0 {
+		t.Errorf("Nearby() with limit 0 = %v, want empty", empty)
+	}
+	if unknown := database.Nearby("ZZZZ", 200, 10); len(unknown) != 0 {
+		t.Errorf("Nearby() of unknown station = %v, want empty", unknown)
+	}
+	if len(database.Nearby("ZSSS", 100, 10)) != 1 {
+		t.Error("Nearby(ZSSS) does not contain ZSPD")
+	}
+}
+
+func TestDatabaseArea(t *testing.T) {
+	database := newTestDatabase(t, testAirports, false)
+	tests := []struct {
+		name  string
+		area  *metar.Area
+		limit int
+		want  []string
+	}{
+		{"box", &metar.Area{South: -1, West: -2, North: 1, East: 2}, 10, []string{"FAAA", "FBBB", "FCCC", "FDDD"}},
+		{"box with limit", &metar.Area{South: -1, West: -2, North: 1, East: 2}, 2, []string{"FAAA", "FBBB"}},
+		// 按机场类型从大到小排序
+		{"larger airport first", &metar.Area{South: 39.5, West: -101, North: 41.5, East: -99}, 10, []string{"KBBB", "KAAA"}},
+		// 西边界大于东边界时跨越180度经线
+		{"across antimeridian", &metar.Area{South: -40, West: 170, North: -10, East: -165}, 10, []string{"NZAA", "NSTU", "NFFN"}},
+		{"not across antimeridian", &metar.Area{South: -40, West: -165, North: -10, East: 170}, 10, []string{}},
+		// 圆形区域包含中心的机场
+		{"radius", &metar.Area{Latitude: 0, Longitude: 0, Radius: 120}, 10, []string{"FAAA", "FBBB", "FCCC", "FDDD"}},
+		{"radius across antimeridian", &metar.Area{Latitude: -15, Longitude: 180, Radius: 1200}, 10, []string{"NSTU", "NFFN"}},
+		{"no station", &metar.Area{South: 60, West: 0, North: 70, East: 10}, 10, []string{}},
+		{"zero limit", &metar.Area{South: -1, West: -2, North: 1, East: 2}, 0, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stations := database.Area(tt.area, tt.limit)
+			got := make([]string, 0, len(stations))
+			for _, station := range stations {
+				got = append(got, station.ICAO)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Area() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
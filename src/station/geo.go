@@ -0,0 +1,46 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package station
+package station
+
+import (
+	"math"
+	"metar-service/src/interfaces/metar"
+)
+
+// earthRadius 地球平均半径(公里)
+const earthRadius = 6371.0088
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// distance 两点间的大圆距离(公里)
+func distance(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := radians(lat1), radians(lat2)
+	deltaPhi, deltaLambda := radians(lat2-lat1), radians(lon2-lon1)
+	a := math.Sin(deltaPhi/2)*math.Sin(deltaPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(deltaLambda/2)*math.Sin(deltaLambda/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// bearing 从第一个点到第二个点的初始真方位(度), 范围为[0, 360)
+func bearing(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := radians(lat1), radians(lat2)
+	deltaLambda := radians(lon2 - lon1)
+	y := math.Sin(deltaLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(deltaLambda)
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
+
+// inBox 检查坐标是否在矩形区域内, 西边界大于东边界时区域跨越180度经线
+func inBox(area *metar.Area, latitude float64, longitude float64) bool {
+	if latitude < area.South || latitude > area.North {
+		return false
+	}
+	if area.West <= area.East {
+		return longitude >= area.West && longitude <= area.East
+	}
+	return longitude >= area.West || longitude <= area.East
+}
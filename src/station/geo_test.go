@@ -0,0 +1,64 @@
+// Copyright (c) 2025 Half_nothing
+// SPDX-License-Identifier: MIT
+
+// Package station
+package station
+
+import (
+	"metar-service/src/interfaces/metar"
+	"testing"
+)
+
+func TestInBox(t *testing.T) {
+	box := &metar.Area{South: 30, West: 120, North: 32, East: 122}
+	antimeridian := &metar.Area{South: -40, West: 170, North: -10, East: -170}
+	tests := []struct {
+		name      string
+		area      *metar.Area
+		latitude  float64
+		longitude float64
+		want      bool
+	}{
+		{"inside", box, 31, 121, true},
+		{"on boundary", box, 32, 120, true},
+		{"south of box", box, 29.9, 121, false},
+		{"east of box", box, 31, 122.1, false},
+		{"west of antimeridian", antimeridian, -17.7, 177.4, true},
+		{"east of antimeridian", antimeridian, -14.3, -169.5, false},
+		{"on antimeridian", antimeridian, -20, 180, true},
+		{"on antimeridian negative", antimeridian, -20, -180, true},
+		{"inside east part", antimeridian, -20, -175, true},
+		{"outside between bounds", antimeridian, -20, 0, false},
+		{"outside latitude", antimeridian, -5, 175, false},
+	}
+	for _, tt := range tests {
+		if got := inBox(tt.area, tt.latitude, tt.longitude); got != tt.want {
+			t.Errorf("inBox() %s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAreaValid(t *testing.T) {
+	tests := []struct {
+		name string
+		area *metar.Area
+		want bool
+	}{
+		{"box", &metar.Area{South: 30, West: 120, North: 32, East: 122}, true},
+		{"box across antimeridian", &metar.Area{South: -40, West: 170, North: -10, East: -170}, true},
+		{"south above north", &metar.Area{South: 32, West: 120, North: 30, East: 122}, false},
+		{"zero height", &metar.Area{South: 30, West: 120, North: 30, East: 122}, false},
+		{"zero width", &metar.Area{South: 30, West: 120, North: 32, East: 120}, false},
+		{"latitude out of range", &metar.Area{South: -91, West: 120, North: 32, East: 122}, false},
+		{"longitude out of range", &metar.Area{South: 30, West: 120, North: 32, East: 181}, false},
+		{"radius", &metar.Area{Latitude: -90, Longitude: 180, Radius: 100}, true},
+		{"radius latitude out of range", &metar.Area{Latitude: 91, Longitude: 0, Radius: 100}, false},
+		{"radius longitude out of range", &metar.Area{Latitude: 0, Longitude: -180.5, Radius: 100}, false},
+		{"empty", &metar.Area{}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.area.Valid(); got != tt.want {
+			t.Errorf("Valid() %s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}